@@ -1,6 +1,10 @@
 package api
 
 import (
+	"fmt"
+	"io"
+	"runtime"
+
 	"cauthon-axis/internal/server"
 
 	"github.com/gofiber/fiber/v2"
@@ -20,11 +24,14 @@ func handleListBackups(c *fiber.Ctx) error {
 func handleCreateBackup(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var body struct {
-		Name string `json:"name"`
+		ID     string              `json:"id"`
+		Name   string              `json:"name"`
+		Ignore string              `json:"ignore"`
+		Hooks  *server.BackupHooks `json:"hooks"`
 	}
 	c.BodyParser(&body)
 
-	backup, err := server.CreateBackup(id, body.Name)
+	backup, err := server.CreateBackup(id, body.ID, body.Name, body.Ignore, body.Hooks)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false, "error": err.Error(),
@@ -49,15 +56,65 @@ func handleDownloadBackup(c *fiber.Ctx) error {
 	id := c.Params("id")
 	backupID := c.Params("backupId")
 
-	path, err := server.GetBackupPath(id, backupID)
+	c.Set("Content-Disposition", "attachment; filename=\""+backupID+".tar.gz\"")
+
+	if path, err := server.GetBackupPath(id, backupID); err == nil {
+		c.Set("Content-Type", "application/gzip")
+		return serveFile(c, path)
+	}
+
+	c.Set("Content-Type", "application/gzip")
+	if c.Get(fiber.HeaderRange) == "" {
+		archive, err := server.OpenBackupArchive(id, backupID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false, "error": err.Error(),
+			})
+		}
+		c.Set(fiber.HeaderAcceptRanges, "bytes")
+		if size, ok := server.KnownBackupArchiveSize(id, backupID); ok {
+			c.Set(fiber.HeaderETag, backupArchiveETag(backupID, size))
+			return c.SendStream(archive, int(size))
+		}
+		return c.SendStream(archive)
+	}
+
+	// Backup archives are rebuilt from the store on every request, so a
+	// ranged download regenerates the stream and discards everything before
+	// the offset. Resuming late in a large backup costs nearly a full encode.
+	size, err := server.BackupArchiveSize(id, backupID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"success": false, "error": err.Error(),
 		})
 	}
+	etag := backupArchiveETag(backupID, size)
+	c.Set(fiber.HeaderETag, etag)
+	start, end, ok := byteRange(c, size, etag)
+	if !ok {
+		return c.JSON(fiber.Map{"success": false, "error": "range not satisfiable"})
+	}
 
-	c.Set("Content-Disposition", "attachment; filename=\""+backupID+".tar.gz\"")
-	return c.SendFile(path)
+	archive, err := server.OpenBackupArchive(id, backupID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	if _, err := io.CopyN(io.Discard, archive, start); err != nil {
+		archive.Close()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return sendRange(c, archive, start, end)
+}
+
+// backupArchiveETag names the runtime that encodes the archive, since gzip
+// output can change between Go releases and a resumed download must not mix
+// bytes from two encodings.
+func backupArchiveETag(backupID string, size int64) string {
+	return fmt.Sprintf(`"%s-%s-%d"`, backupID, runtime.Version(), size)
 }
 
 func handleRestoreBackup(c *fiber.Ctx) error {
@@ -71,7 +128,12 @@ func handleRestoreBackup(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := server.RestoreBackup(id, backupID); err != nil {
+	var body struct {
+		Paths []string `json:"paths"`
+	}
+	c.BodyParser(&body)
+
+	if err := server.RestoreBackup(id, backupID, body.Paths); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false, "error": err.Error(),
 		})
@@ -79,3 +141,39 @@ func handleRestoreBackup(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{"success": true, "message": "Backup restored"})
 }
+
+func handleListBackupFiles(c *fiber.Ctx) error {
+	files, err := server.ListBackupFiles(c.Params("id"), c.Params("backupId"), c.Query("path", "/"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": files})
+}
+
+func handleGetBackupStorage(c *fiber.Ctx) error {
+	cfg, err := server.GetBackupStorage(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": cfg.Redacted()})
+}
+
+func handleSetBackupStorage(c *fiber.Ctx) error {
+	var cfg server.StorageConfig
+	if err := c.BodyParser(&cfg); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false, "error": "Invalid request body",
+		})
+	}
+
+	if err := server.SetBackupStorage(c.Params("id"), cfg); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": cfg.Redacted()})
+}
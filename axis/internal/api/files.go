@@ -1,14 +1,30 @@
 package api
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"cauthon-axis/internal/server"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+func writeErrorStatus(err error) int {
+	if errors.Is(err, server.ErrDiskQuotaExceeded) {
+		return fiber.StatusInsufficientStorage
+	}
+	return fiber.StatusInternalServerError
+}
+
 func handleListFiles(c *fiber.Ctx) error {
 	id := c.Params("id")
 	path := c.Query("path", "/")
@@ -69,6 +85,50 @@ func handleSearchFiles(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"success": true, "data": results})
 }
 
+// handleGrepFiles streams content matches as newline-delimited JSON so large
+// searches show results before the walk finishes. The last line is a summary.
+func handleGrepFiles(c *fiber.Ctx) error {
+	id := c.Params("id")
+	opts := server.GrepOptions{
+		Pattern:       c.Query("pattern"),
+		Regex:         c.QueryBool("regex", false),
+		CaseSensitive: c.QueryBool("case_sensitive", false),
+		Path:          c.Query("path", "/"),
+		MaxFileSize:   int64(c.QueryInt("max_size", 0)),
+		Limit:         c.QueryInt("limit", 0),
+	}
+	if include := c.Query("include"); include != "" {
+		opts.Include = strings.Split(include, ",")
+	}
+
+	if opts.Pattern == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "pattern required"})
+	}
+	if opts.Regex {
+		if _, err := regexp.Compile(opts.Pattern); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "invalid pattern: " + err.Error()})
+		}
+	}
+
+	c.Set("Content-Type", "application/x-ndjson")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		enc := json.NewEncoder(w)
+		summary, err := server.GrepFiles(id, opts, func(m server.GrepMatch) error {
+			if err := enc.Encode(fiber.Map{"type": "match", "path": m.Path, "line": m.Line, "text": m.Text}); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+		done := fiber.Map{"type": "done", "files_scanned": summary.FilesScanned, "files_skipped": summary.FilesSkipped, "matches": summary.Matches, "truncated": summary.Truncated}
+		if err != nil {
+			done["error"] = err.Error()
+		}
+		enc.Encode(done)
+		w.Flush()
+	})
+	return nil
+}
+
 func handleCreateFolder(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var body struct {
@@ -93,7 +153,7 @@ func handleWriteFile(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "path required"})
 	}
 	if err := server.WriteFile(id, body.Path, []byte(body.Content)); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return c.Status(writeErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 	return c.JSON(fiber.Map{"success": true})
 }
@@ -111,6 +171,10 @@ func handleUploadFile(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "file too large (max 100MB)"})
 	}
 
+	if err := server.CheckDiskSpace(id, file.Size); err != nil {
+		return c.Status(fiber.StatusInsufficientStorage).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
 	src, err := file.Open()
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
@@ -125,7 +189,7 @@ func handleUploadFile(c *fiber.Ctx) error {
 	}
 
 	if err := server.WriteFileStream(id, filePath, src); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return c.Status(writeErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 	return c.JSON(fiber.Map{"success": true})
 }
@@ -188,7 +252,7 @@ func handleCompressPath(c *fiber.Ctx) error {
 	}
 
 	if err := server.CompressPath(id, body.Path, body.Dest, body.Format); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return c.Status(writeErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 	return c.JSON(fiber.Map{"success": true})
 }
@@ -204,7 +268,7 @@ func handleDecompressPath(c *fiber.Ctx) error {
 	}
 
 	if err := server.DecompressPath(id, body.Path, body.Dest); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return c.Status(writeErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 	return c.JSON(fiber.Map{"success": true})
 }
@@ -234,7 +298,116 @@ func handleDownloadFile(c *fiber.Ctx) error {
 	c.Set("Content-Disposition", "attachment; filename=\""+fileName+"\"")
 	c.Set("Content-Type", "application/octet-stream")
 
-	return c.SendFile(filePath)
+	return serveFile(c, filePath)
+}
+
+// serveFile sends a file from disk and honours a single byte range so an
+// interrupted download can resume.
+func serveFile(c *fiber.Ctx, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": "file not found"})
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	lastModified := info.ModTime().UTC().Format(http.TimeFormat)
+	c.Set(fiber.HeaderLastModified, lastModified)
+
+	start, end, ok := byteRange(c, info.Size(), lastModified)
+	if !ok {
+		f.Close()
+		return c.JSON(fiber.Map{"success": false, "error": "range not satisfiable"})
+	}
+	if _, err := f.Seek(start, io.SeekStart); err != nil {
+		f.Close()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return sendRange(c, f, start, end)
+}
+
+// byteRange resolves the Range header against a body of size bytes and sets
+// the status and Content-Range to match. A range is only honoured when
+// If-Range is absent or equals validator; multi-range requests get the whole
+// body. ok is false when the range cannot be satisfied.
+func byteRange(c *fiber.Ctx, size int64, validator string) (start, end int64, ok bool) {
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Status(fiber.StatusOK)
+
+	header := c.Get(fiber.HeaderRange)
+	if header == "" {
+		return 0, size - 1, true
+	}
+	if ifRange := c.Get(fiber.HeaderIfRange); ifRange != "" && ifRange != validator {
+		return 0, size - 1, true
+	}
+
+	start, end, partial, ok := parseByteRange(header, size)
+	if !ok {
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+		c.Status(fiber.StatusRequestedRangeNotSatisfiable)
+		return 0, 0, false
+	}
+	if !partial {
+		return 0, size - 1, true
+	}
+	c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+	c.Status(fiber.StatusPartialContent)
+	return start, end, true
+}
+
+// sendRange streams bytes start through end from r, which must already be
+// positioned at start. r is closed once the response is written.
+func sendRange(c *fiber.Ctx, r io.ReadCloser, start, end int64) error {
+	length := end - start + 1
+	return c.SendStream(struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(r, length), r}, int(length))
+}
+
+// parseByteRange parses a Range header against a file of the given size.
+// partial is false when the header should be ignored and the whole file
+// sent; ok is false when the range cannot be satisfied.
+func parseByteRange(header string, size int64) (start, end int64, partial, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, true
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false, true
+	}
+
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return 0, 0, false, false
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, size - 1, true, true
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false, false
+	}
+	end = size - 1
+	if last != "" {
+		e, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || e < start {
+			return 0, 0, false, false
+		}
+		if e < end {
+			end = e
+		}
+	}
+	return start, end, true, true
 }
 
 func handleBulkDelete(c *fiber.Ctx) error {
@@ -282,7 +455,7 @@ func handleBulkCompress(c *fiber.Ctx) error {
 	}
 
 	if err := server.BulkCompress(id, body.Paths, body.Dest, body.Format); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return c.Status(writeErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 	return c.JSON(fiber.Map{"success": true})
 }
@@ -298,7 +471,7 @@ func handleDownloadURL(c *fiber.Ctx) error {
 	}
 
 	if err := server.DownloadURL(id, body.URL, body.Path); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return c.Status(writeErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 	return c.JSON(fiber.Map{"success": true})
 }
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cauthon-axis/internal/config"
+	"cauthon-axis/internal/logger"
+	"cauthon-axis/internal/server"
+	"cauthon-axis/internal/system"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// NewMetricsServer serves /metrics on its own listener so scrapers do not need
+// the panel token or a node client certificate.
+func NewMetricsServer() *fiber.App {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/metrics", requireMetricsToken, handleMetrics)
+	return app
+}
+
+func requireMetricsToken(c *fiber.Ctx) error {
+	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	expected := config.Get().Metrics.Token
+	if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false, "error": "Unauthorized",
+		})
+	}
+	return c.Next()
+}
+
+type metricFamily struct {
+	name, kind, help string
+	samples          []string
+}
+
+func (f *metricFamily) add(value float64, labels ...string) {
+	name := f.name
+	if f.kind == "counter" {
+		name += "_total"
+	}
+	f.samples = append(f.samples, name+formatLabels(labels)+" "+strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func formatLabels(pairs []string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+`="`+escaper.Replace(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func handleMetrics(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	state := &metricFamily{name: "birdactyl_server_state", kind: "gauge", help: "Whether the server container is in the given state."}
+	cpu := &metricFamily{name: "birdactyl_server_cpu_percent", kind: "gauge", help: "Container CPU usage, 100 per core."}
+	memory := &metricFamily{name: "birdactyl_server_memory_bytes", kind: "gauge", help: "Container memory usage."}
+	memoryLimit := &metricFamily{name: "birdactyl_server_memory_limit_bytes", kind: "gauge", help: "Container memory limit."}
+	disk := &metricFamily{name: "birdactyl_server_disk_bytes", kind: "gauge", help: "Server data directory size."}
+	diskLimit := &metricFamily{name: "birdactyl_server_disk_limit_bytes", kind: "gauge", help: "Server disk quota."}
+	rx := &metricFamily{name: "birdactyl_server_network_receive_bytes", kind: "counter", help: "Bytes received by the container."}
+	tx := &metricFamily{name: "birdactyl_server_network_transmit_bytes", kind: "counter", help: "Bytes sent by the container."}
+
+	snapshots, err := server.SnapshotServers(ctx)
+	if err != nil {
+		logger.Warn("Failed to list containers for metrics: %v", err)
+	}
+	for _, snap := range snapshots {
+		id := []string{"server_id", snap.ServerID}
+		running, stopped := 0.0, 1.0
+		if snap.Running {
+			running, stopped = 1, 0
+		}
+		state.add(running, "server_id", snap.ServerID, "state", "running")
+		state.add(stopped, "server_id", snap.ServerID, "state", "stopped")
+		disk.add(float64(snap.DiskUsage), id...)
+		if snap.DiskLimit > 0 {
+			diskLimit.add(float64(snap.DiskLimit), id...)
+		}
+		if s := snap.Stats; s != nil {
+			cpu.add(s.CPUPercent, id...)
+			memory.add(float64(s.MemoryUsage), id...)
+			memoryLimit.add(float64(s.MemoryLimit), id...)
+			rx.add(float64(s.NetRx), id...)
+			tx.add(float64(s.NetTx), id...)
+		}
+	}
+
+	info := system.GetInfo()
+	netRx, netTx := system.GetNetworkIO()
+	node := []*metricFamily{
+		{name: "birdactyl_node_cpu_cores", kind: "gauge", help: "CPU cores on the node."},
+		{name: "birdactyl_node_cpu_percent", kind: "gauge", help: "Node CPU usage."},
+		{name: "birdactyl_node_memory_used_bytes", kind: "gauge", help: "Node memory in use."},
+		{name: "birdactyl_node_memory_total_bytes", kind: "gauge", help: "Node memory installed."},
+		{name: "birdactyl_node_disk_used_bytes", kind: "gauge", help: "Node root filesystem usage."},
+		{name: "birdactyl_node_disk_total_bytes", kind: "gauge", help: "Node root filesystem size."},
+		{name: "birdactyl_node_network_receive_bytes", kind: "counter", help: "Bytes received on the node's external interfaces."},
+		{name: "birdactyl_node_network_transmit_bytes", kind: "counter", help: "Bytes sent on the node's external interfaces."},
+		{name: "birdactyl_node_uptime_seconds", kind: "gauge", help: "Node uptime."},
+	}
+	for i, v := range []float64{
+		float64(info.CPU.Cores), info.CPU.Usage,
+		float64(info.Memory.Used), float64(info.Memory.Total),
+		float64(info.Disk.Used), float64(info.Disk.Total),
+		float64(netRx), float64(netTx),
+		float64(info.Uptime),
+	} {
+		node[i].add(v)
+	}
+
+	var out strings.Builder
+	for _, f := range append([]*metricFamily{state, cpu, memory, memoryLimit, disk, diskLimit, rx, tx}, node...) {
+		fmt.Fprintf(&out, "# TYPE %s %s\n# HELP %s %s\n", f.name, f.kind, f.name, f.help)
+		for _, s := range f.samples {
+			out.WriteString(s + "\n")
+		}
+	}
+	out.WriteString("# EOF\n")
+
+	c.Set(fiber.HeaderContentType, openMetricsContentType)
+	return c.SendString(out.String())
+}
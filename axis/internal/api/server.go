@@ -16,17 +16,23 @@ import (
 func NewServer() *fiber.App {
 	app := fiber.New(fiber.Config{
 		DisableStartupMessage: true,
-		BodyLimit:             100 * 1024 * 1024 * 1024,
+		// Bodies over the limit are streamed rather than rejected, so large
+		// multipart uploads spill to temporary files and upload chunks are
+		// copied straight into staging instead of being held in memory.
+		BodyLimit:         4 * 1024 * 1024,
+		StreamRequestBody: true,
 	})
 
 	app.Get("/api/health", handleHealth)
 	app.Post("/api/pair", handlePairing)
+	app.Post("/api/pair/certificate", requirePanelAuth, handlePairingCertificate)
 	app.Get("/api/system", requirePanelAuth, handleSystemInfo)
+	app.Get("/api/system/metrics", requirePanelAuth, handleSystemMetrics)
 
 	app.Use("/api/servers/:id/ws", func(c *fiber.Ctx) error {
 		cfg := config.Get()
-		token := c.Query("token")
-		if token != cfg.Panel.Token {
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		if token == "" || token != cfg.Panel.Token {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
 		}
 		if err := server.ValidateServerID(c.Params("id")); err != nil {
@@ -44,6 +50,7 @@ func NewServer() *fiber.App {
 	servers.Use("/:id", validateServerID)
 	servers.Use("/:id/*", validateServerID)
 	servers.Get("/:id/status", handleServerStatus)
+	servers.Get("/:id/metrics", handleServerMetrics)
 	servers.Get("/:id/logs", handleGetLogs)
 	servers.Get("/:id/logs/full", handleGetFullLog)
 	servers.Get("/:id/logs/search", handleSearchLogs)
@@ -60,9 +67,15 @@ func NewServer() *fiber.App {
 	servers.Get("/:id/files/hashes", handleListFilesWithHashes)
 	servers.Get("/:id/files/read", handleReadFile)
 	servers.Get("/:id/files/search", handleSearchFiles)
+	servers.Get("/:id/files/grep", handleGrepFiles)
 	servers.Post("/:id/files/folder", handleCreateFolder)
 	servers.Post("/:id/files/write", handleWriteFile)
 	servers.Post("/:id/files/upload", handleUploadFile)
+	servers.Post("/:id/files/uploads", handleCreateUpload)
+	servers.Get("/:id/files/uploads/:uploadId", handleGetUpload)
+	servers.Patch("/:id/files/uploads/:uploadId", handlePatchUpload)
+	servers.Post("/:id/files/uploads/:uploadId/complete", handleCompleteUpload)
+	servers.Delete("/:id/files/uploads/:uploadId", handleCancelUpload)
 	servers.Delete("/:id/files", handleDeletePath)
 	servers.Post("/:id/files/move", handleMovePath)
 	servers.Post("/:id/files/copy", handleCopyPath)
@@ -75,9 +88,12 @@ func NewServer() *fiber.App {
 	servers.Post("/:id/files/download-url", handleDownloadURL)
 	servers.Post("/:id/modpack/install", handleInstallModpack)
 	servers.Get("/:id/backups", handleListBackups)
+	servers.Get("/:id/backups/storage", handleGetBackupStorage)
+	servers.Put("/:id/backups/storage", handleSetBackupStorage)
 	servers.Post("/:id/backups", handleCreateBackup)
 	servers.Delete("/:id/backups/:backupId", handleDeleteBackup)
 	servers.Get("/:id/backups/:backupId/download", handleDownloadBackup)
+	servers.Get("/:id/backups/:backupId/files", handleListBackupFiles)
 	servers.Post("/:id/backups/:backupId/restore", handleRestoreBackup)
 	servers.Post("/:id/archive", handleCreateArchive)
 	servers.Get("/:id/archive/download", handleDownloadArchive)
@@ -138,7 +154,7 @@ func handlePairing(c *fiber.Ctx) error {
 		})
 	}
 
-	result := pairing.HandlePairingRequest(req.PanelURL, req.Code)
+	result := pairing.HandlePairingRequest(req.PanelURL, req.Code, req.CACert)
 	if !result.Success {
 		return c.Status(fiber.StatusForbidden).JSON(result)
 	}
@@ -146,10 +162,43 @@ func handlePairing(c *fiber.Ctx) error {
 	return c.JSON(result)
 }
 
+func handlePairingCertificate(c *fiber.Ctx) error {
+	var req struct {
+		Certificate string `json:"certificate"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.Certificate == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false, "error": "certificate is required",
+		})
+	}
+
+	if err := pairing.HandleCertificate(req.Certificate); err != nil {
+		logger.Error("Failed to install node certificate: %v", err)
+		status := fiber.StatusBadRequest
+		if err == pairing.ErrNoPendingCertificate {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"success": true})
+}
+
 func handleSystemInfo(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"success": true, "data": system.GetInfo()})
 }
 
+func handleSystemMetrics(c *fiber.Ctx) error {
+	window, err := server.ParseMetricRange(c.Query("range"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": server.GetNodeMetrics(window)})
+}
+
 func handleCreateServer(c *fiber.Ctx) error {
 	var cfg server.ServerConfig
 	if err := c.BodyParser(&cfg); err != nil {
@@ -214,6 +263,16 @@ func handleServerStatus(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"success": true, "data": data})
 }
 
+func handleServerMetrics(c *fiber.Ctx) error {
+	window, err := server.ParseMetricRange(c.Query("range"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false, "error": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": server.GetServerMetrics(c.Params("id"), window)})
+}
+
 func handleGetLogs(c *fiber.Ctx) error {
 	id := c.Params("id")
 	lines := c.QueryInt("lines", 100)
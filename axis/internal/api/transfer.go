@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"cauthon-axis/internal/config"
 	"cauthon-axis/internal/logger"
 	"cauthon-axis/internal/server"
 
@@ -102,6 +103,15 @@ func handleImportFromURL(c *fiber.Ctx, id, url, token string) error {
 	}
 
 	client := &http.Client{}
+	if tlsCfg := config.Get().Node.TLS; req.URL.Scheme == "https" && tlsCfg.Enabled() {
+		clientTLS, err := tlsCfg.ClientConfig()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"success": false, "error": "failed to load node certificate: " + err.Error(),
+			})
+		}
+		client.Transport = &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: clientTLS}
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		logger.Error("Failed to fetch archive for %s: %v", id, err)
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"cauthon-axis/internal/server"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const statusChecksumMismatch = 460
+
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, server.ErrUploadInvalid):
+		return fiber.StatusBadRequest
+	case errors.Is(err, server.ErrUploadNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, server.ErrUploadOffset), errors.Is(err, server.ErrUploadIncomplete):
+		return fiber.StatusConflict
+	case errors.Is(err, server.ErrUploadOverflow), errors.Is(err, server.ErrUploadChunkSize):
+		return fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, server.ErrUploadChecksum):
+		return statusChecksumMismatch
+	}
+	return writeErrorStatus(err)
+}
+
+func setUploadHeaders(c *fiber.Ctx, upload *server.Upload) {
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(upload.Size, 10))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+}
+
+func handleCreateUpload(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var body struct {
+		Path     string `json:"path"`
+		Size     int64  `json:"size"`
+		Checksum string `json:"checksum"`
+	}
+	if err := c.BodyParser(&body); err != nil || body.Path == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "path and size required"})
+	}
+
+	upload, err := server.CreateUpload(id, body.Path, body.Size, body.Checksum)
+	if err != nil {
+		return c.Status(uploadErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	setUploadHeaders(c, upload)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": upload})
+}
+
+// handleGetUpload also answers HEAD, which clients use to find the offset
+// to resume from.
+func handleGetUpload(c *fiber.Ctx) error {
+	upload, err := server.GetUpload(c.Params("id"), c.Params("uploadId"))
+	if err != nil {
+		return c.Status(uploadErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	setUploadHeaders(c, upload)
+	return c.JSON(fiber.Map{"success": true, "data": upload})
+}
+
+// handlePatchUpload appends one chunk. Upload-Offset must match the current
+// offset, and an optional "Upload-Checksum: sha256 <base64>" header is
+// verified before the chunk is kept. The body is streamed into staging rather
+// than buffered, so an oversized chunk is cut off at MaxUploadChunkSize.
+func handlePatchUpload(c *fiber.Ctx) error {
+	id := c.Params("id")
+	uploadID := c.Params("uploadId")
+
+	if c.Get(fiber.HeaderContentType) != "application/offset+octet-stream" {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"success": false, "error": "content type must be application/offset+octet-stream"})
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Upload-Offset header required"})
+	}
+	if c.Request().Header.ContentLength() > server.MaxUploadChunkSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"success": false, "error": server.ErrUploadChunkSize.Error()})
+	}
+	body := c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+
+	var sum []byte
+	if header := c.Get("Upload-Checksum"); header != "" {
+		algo, encoded, _ := strings.Cut(header, " ")
+		if algo != "sha256" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "unsupported checksum algorithm"})
+		}
+		if sum, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "invalid checksum"})
+		}
+	}
+
+	newOffset, err := server.WriteUploadChunk(id, uploadID, offset, body, sum)
+	if !errors.Is(err, server.ErrUploadNotFound) {
+		c.Set("Upload-Offset", strconv.FormatInt(newOffset, 10))
+	}
+	if err != nil {
+		return c.Status(uploadErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func handleCompleteUpload(c *fiber.Ctx) error {
+	upload, err := server.CompleteUpload(c.Params("id"), c.Params("uploadId"))
+	if err != nil {
+		return c.Status(uploadErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": upload})
+}
+
+func handleCancelUpload(c *fiber.Ctx) error {
+	if err := server.CancelUpload(c.Params("id"), c.Params("uploadId")); err != nil {
+		return c.Status(uploadErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true})
+}
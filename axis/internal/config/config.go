@@ -12,12 +12,19 @@ var ErrConfigGenerated = errors.New("config file generated")
 type Config struct {
 	Panel   PanelConfig   `yaml:"panel"`
 	Node    NodeConfig    `yaml:"node"`
+	Metrics MetricsConfig `yaml:"metrics"`
 	Logging LoggingConfig `yaml:"logging"`
 }
 
 type PanelConfig struct {
-	URL   string `yaml:"url"`
-	Token string `yaml:"token"`
+	URL    string `yaml:"url"`
+	Token  string `yaml:"token"`
+	Tunnel bool   `yaml:"tunnel"`
+}
+
+type MetricsConfig struct {
+	Listen string `yaml:"listen"`
+	Token  string `yaml:"token"`
 }
 
 type LoggingConfig struct {
@@ -25,16 +32,35 @@ type LoggingConfig struct {
 }
 
 type NodeConfig struct {
-	Listen          string `yaml:"listen"`
-	DataDir         string `yaml:"data_dir"`
-	BackupDir       string `yaml:"backup_dir"`
-	DisplayIP       string `yaml:"display_ip"`
-	SFTPPort        int    `yaml:"sftp_port"`
-	ContainerEngine string `yaml:"container_engine"`
-	DockerSocket    string `yaml:"docker_socket"`
-	IgnoreWSL       bool   `yaml:"ignore_wsl"`
+	Listen            string    `yaml:"listen"`
+	DataDir           string    `yaml:"data_dir"`
+	BackupDir         string    `yaml:"backup_dir"`
+	UploadDir         string    `yaml:"upload_dir"`
+	DisplayIP         string    `yaml:"display_ip"`
+	SFTPPort          int       `yaml:"sftp_port"`
+	ContainerEngine   string    `yaml:"container_engine"`
+	DockerSocket      string    `yaml:"docker_socket"`
+	IgnoreWSL         bool      `yaml:"ignore_wsl"`
+	DiskCheckInterval int       `yaml:"disk_check_interval"`
+	DiskOverage       string    `yaml:"disk_overage"`
+	TLS               TLSConfig `yaml:"tls"`
+}
+
+type TLSConfig struct {
+	Cert string `yaml:"cert"`
+	Key  string `yaml:"key"`
+	CA   string `yaml:"ca"`
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.Cert != "" && t.Key != "" && t.CA != ""
 }
 
+const (
+	DiskOverageWarn = "warn"
+	DiskOverageStop = "stop"
+)
+
 var cfg *Config
 var configPath string
 
@@ -67,12 +93,21 @@ func Load(path string) (*Config, error) {
 	if cfg.Node.BackupDir == "" {
 		cfg.Node.BackupDir = "/var/lib/birdactyl/backups"
 	}
+	if cfg.Node.UploadDir == "" {
+		cfg.Node.UploadDir = "/var/lib/birdactyl/uploads"
+	}
 	if cfg.Node.SFTPPort == 0 {
 		cfg.Node.SFTPPort = 2022
 	}
 	if cfg.Node.ContainerEngine == "" {
 		cfg.Node.ContainerEngine = "docker"
 	}
+	if cfg.Node.DiskCheckInterval <= 0 {
+		cfg.Node.DiskCheckInterval = 60
+	}
+	if cfg.Node.DiskOverage != DiskOverageWarn {
+		cfg.Node.DiskOverage = DiskOverageStop
+	}
 
 	return cfg, nil
 }
@@ -98,16 +133,28 @@ func generateDefaultConfig(path string) error {
 	defaultConfig := `panel:
   url: "http://localhost:3000"
   token: ""
+  tunnel: false
 
 node:
   listen: "0.0.0.0:8443"
   data_dir: "/var/lib/birdactyl/servers"
   backup_dir: "/var/lib/birdactyl/backups"
+  upload_dir: "/var/lib/birdactyl/uploads"
   display_ip: ""
   sftp_port: 2022
   container_engine: "docker"
   docker_socket: ""
   ignore_wsl: false
+  disk_check_interval: 60
+  disk_overage: "stop"
+  tls:
+    cert: ""
+    key: ""
+    ca: ""
+
+metrics:
+  listen: ""
+  token: ""
 
 logging:
   file: "logs/axis.log"
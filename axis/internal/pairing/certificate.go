@@ -0,0 +1,108 @@
+package pairing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cauthon-axis/internal/config"
+	"cauthon-axis/internal/logger"
+)
+
+const certDir = "certs"
+
+var ErrNoPendingCertificate = errors.New("no certificate request pending")
+
+func requestCertificate(caCert string) (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	hostname, _ := os.Hostname()
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: hostname},
+	}, key)
+	if err != nil {
+		return "", err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	state.mu.Lock()
+	state.pendingKey = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	state.pendingCA = []byte(caCert)
+	state.mu.Unlock()
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})), nil
+}
+
+func HandleCertificate(certPEM string) error {
+	state.mu.Lock()
+	keyPEM, caPEM := state.pendingKey, state.pendingCA
+	state.mu.Unlock()
+	if keyPEM == nil {
+		return ErrNoPendingCertificate
+	}
+
+	pair, err := tls.X509KeyPair([]byte(certPEM), keyPEM)
+	if err != nil {
+		return fmt.Errorf("certificate does not match the pending request: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return errors.New("panel CA certificate is invalid")
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}); err != nil {
+		return fmt.Errorf("certificate is not signed by the panel CA: %v", err)
+	}
+
+	dir, err := filepath.Abs(certDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tlsCfg := config.TLSConfig{
+		Cert: filepath.Join(dir, "node.crt"),
+		Key:  filepath.Join(dir, "node.key"),
+		CA:   filepath.Join(dir, "ca.crt"),
+	}
+	if err := os.WriteFile(tlsCfg.Key, keyPEM, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(tlsCfg.Cert, []byte(certPEM), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(tlsCfg.CA, caPEM, 0644); err != nil {
+		return err
+	}
+
+	cfg := config.Get()
+	cfg.Node.TLS = tlsCfg
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	state.mu.Lock()
+	state.pendingKey = nil
+	state.pendingCA = nil
+	state.mu.Unlock()
+
+	logger.Success("Node certificate installed, the API will use mutual TLS on next start")
+	return nil
+}
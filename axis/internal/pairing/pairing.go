@@ -21,6 +21,8 @@ type PairingState struct {
 	PendingCode string
 	PendingURL  string
 	ResultChan  chan PairingResult
+	pendingKey  []byte
+	pendingCA   []byte
 	mu          sync.Mutex
 }
 
@@ -33,12 +35,14 @@ type PairingResult struct {
 type PairingRequest struct {
 	PanelURL string `json:"panel_url"`
 	Code     string `json:"code"`
+	CACert   string `json:"ca_cert"`
 }
 
 type PairingResponse struct {
 	Success bool   `json:"success"`
 	TokenID string `json:"token_id,omitempty"`
 	Token   string `json:"token,omitempty"`
+	CSR     string `json:"csr,omitempty"`
 	Error   string `json:"error,omitempty"`
 }
 
@@ -74,7 +78,7 @@ func IsActive() bool {
 	return state.Active && time.Now().Before(state.ExpiresAt)
 }
 
-func HandlePairingRequest(panelURL, code string) PairingResponse {
+func HandlePairingRequest(panelURL, code, caCert string) PairingResponse {
 	state.mu.Lock()
 
 	if !state.Active || time.Now().After(state.ExpiresAt) {
@@ -129,8 +133,17 @@ func HandlePairingRequest(panelURL, code string) PairingResponse {
 
 		if result.Accepted {
 			saveToken(result.TokenID, result.Token, panelURL)
+			resp := PairingResponse{Success: true, TokenID: result.TokenID, Token: result.Token}
+			if caCert != "" {
+				csr, err := requestCertificate(caCert)
+				if err != nil {
+					logger.Warn("Could not create a certificate request, the API will stay on plain HTTP: %v", err)
+				} else {
+					resp.CSR = csr
+				}
+			}
 			logger.Success("Pairing accepted! Node is now connected to panel.")
-			return PairingResponse{Success: true, TokenID: result.TokenID, Token: result.Token}
+			return resp
 		}
 		logger.Warn("Pairing rejected by user")
 		return PairingResponse{Success: false, Error: "Pairing rejected by user"}
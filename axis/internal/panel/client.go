@@ -3,14 +3,18 @@ package panel
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"cauthon-axis/internal/config"
 	"cauthon-axis/internal/system"
+	"cauthon-axis/internal/tunnel"
 )
 
+var ErrUnknownServer = errors.New("server is not known to the panel")
+
 type Client struct {
 	httpClient *http.Client
 	panelURL   string
@@ -40,6 +44,9 @@ func (c *Client) SendHeartbeat() error {
 	if cfg.Node.DisplayIP != "" {
 		payload["display_ip"] = cfg.Node.DisplayIP
 	}
+	if cfg.Panel.Tunnel {
+		return tunnel.Send("heartbeat", payload)
+	}
 
 	body, _ := json.Marshal(payload)
 	req, err := http.NewRequest("POST", c.panelURL+"/api/v1/internal/nodes/heartbeat", bytes.NewReader(body))
@@ -63,14 +70,151 @@ func (c *Client) SendHeartbeat() error {
 	return nil
 }
 
-func (c *Client) ValidateSFTPCredentials(serverID, password string) error {
-	payload := map[string]string{
+type SFTPAccess struct {
+	UserID      string   `json:"user_id"`
+	Username    string   `json:"username"`
+	Permissions []string `json:"permissions"`
+}
+
+func (c *Client) ValidateSFTPCredentials(serverID, username, password, remoteIP string) (*SFTPAccess, error) {
+	return c.validateSFTP(map[string]string{
 		"server_id": serverID,
+		"username":  username,
 		"password":  password,
-	}
+		"remote_ip": remoteIP,
+	})
+}
+
+func (c *Client) ValidateSFTPKey(serverID, username, fingerprint string) (*SFTPAccess, error) {
+	return c.validateSFTP(map[string]string{
+		"server_id":   serverID,
+		"username":    username,
+		"fingerprint": fingerprint,
+	})
+}
 
+func (c *Client) validateSFTP(payload map[string]string) (*SFTPAccess, error) {
 	body, _ := json.Marshal(payload)
 	req, err := http.NewRequest("POST", c.panelURL+"/api/v1/internal/sftp/auth", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to panel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authentication failed")
+	}
+
+	var result struct {
+		Data SFTPAccess `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid auth response: %w", err)
+	}
+
+	return &result.Data, nil
+}
+
+type SFTPEvent struct {
+	ServerID string    `json:"server_id"`
+	UserID   string    `json:"user_id"`
+	Action   string    `json:"action"`
+	Path     string    `json:"path"`
+	Target   string    `json:"target,omitempty"`
+	Bytes    int64     `json:"bytes,omitempty"`
+	IP       string    `json:"ip"`
+	Client   string    `json:"client"`
+	Time     time.Time `json:"time"`
+}
+
+func (c *Client) ReportSFTPActivity(events []SFTPEvent) error {
+	payload := map[string]interface{}{"events": events}
+	if config.Get().Panel.Tunnel {
+		return tunnel.Send("sftp_activity", payload)
+	}
+
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", c.panelURL+"/api/v1/internal/nodes/sftp/activity", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to panel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("panel returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type BackupReport struct {
+	ServerID string   `json:"server_id"`
+	BackupID string   `json:"backup_id"`
+	Status   string   `json:"status"`
+	Size     int64    `json:"size"`
+	Checksum string   `json:"checksum"`
+	Storage  string   `json:"storage"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (c *Client) ReportBackup(report BackupReport) error {
+	body, _ := json.Marshal(report)
+	req, err := http.NewRequest("POST", c.panelURL+"/api/v1/internal/nodes/backups", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to panel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("panel returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type ServerState struct {
+	ServerID string    `json:"server_id"`
+	State    string    `json:"state"`
+	ExitCode int       `json:"exit_code"`
+	OOM      bool      `json:"oom"`
+	Health   string    `json:"health,omitempty"`
+	Logs     []string  `json:"logs,omitempty"`
+	Sync     bool      `json:"sync,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+func (c *Client) ReportServerState(state ServerState) error {
+	if config.Get().Panel.Tunnel {
+		return tunnel.Send("server_state", state)
+	}
+
+	body, _ := json.Marshal(state)
+	req, err := http.NewRequest("POST", c.panelURL+"/api/v1/internal/nodes/servers/state", bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
@@ -84,8 +228,11 @@ func (c *Client) ValidateSFTPCredentials(serverID, password string) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrUnknownServer
+	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("authentication failed")
+		return fmt.Errorf("panel returned status %d", resp.StatusCode)
 	}
 
 	return nil
@@ -1,10 +1,11 @@
 package server
 
 import (
-	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -13,16 +14,24 @@ import (
 	"time"
 
 	"cauthon-axis/internal/config"
+	"cauthon-axis/internal/logger"
+	"cauthon-axis/internal/panel"
 )
 
 type Backup struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Size      int64  `json:"size"`
-	CreatedAt int64  `json:"created_at"`
-	Completed bool   `json:"completed"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Size      int64    `json:"size"`
+	CreatedAt int64    `json:"created_at"`
+	Completed bool     `json:"completed"`
+	Storage   string   `json:"storage"`
+	Checksum  string   `json:"checksum,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
 }
 
+const backupReportAttempts = 5
+
 var (
 	inProgressBackups   = make(map[string]map[string]*Backup)
 	inProgressBackupsMu sync.RWMutex
@@ -44,10 +53,6 @@ func ListBackups(serverID string) ([]Backup, error) {
 		return nil, err
 	}
 
-	inProgressBackupsMu.RLock()
-	serverInProgress := inProgressBackups[serverID]
-	inProgressBackupsMu.RUnlock()
-
 	var backups []Backup
 
 	for _, e := range entries {
@@ -59,44 +64,40 @@ func ListBackups(serverID string) ([]Backup, error) {
 			continue
 		}
 		id := strings.TrimSuffix(e.Name(), ".tar.gz")
-
-		if serverInProgress != nil {
-			if inProg, ok := serverInProgress[id]; ok {
-				backups = append(backups, Backup{
-					ID:        id,
-					Name:      inProg.Name,
-					Size:      info.Size(),
-					CreatedAt: inProg.CreatedAt,
-					Completed: false,
-				})
-				continue
-			}
-		}
-
 		backups = append(backups, Backup{
 			ID:        id,
 			Name:      id,
 			Size:      info.Size(),
 			CreatedAt: info.ModTime().Unix(),
 			Completed: true,
+			Storage:   StorageLocal,
 		})
 	}
 
-	if serverInProgress != nil {
-		for id, b := range serverInProgress {
-			found := false
-			for _, existing := range backups {
-				if existing.ID == id {
-					found = true
-					break
-				}
-			}
-			if !found {
-				backups = append(backups, *b)
-			}
+	for _, store := range serverBackupStores(serverID) {
+		snapshots, err := store.listSnapshots(serverID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s backups: %v", store.kind, err)
+		}
+		for _, snap := range snapshots {
+			backups = append(backups, Backup{
+				ID:        snap.ID,
+				Name:      snap.Name,
+				Size:      snap.Size,
+				CreatedAt: snap.CreatedAt,
+				Completed: true,
+				Storage:   store.kind,
+				Checksum:  snap.Checksum,
+			})
 		}
 	}
 
+	inProgressBackupsMu.RLock()
+	for _, b := range inProgressBackups[serverID] {
+		backups = append(backups, *b)
+	}
+	inProgressBackupsMu.RUnlock()
+
 	sort.Slice(backups, func(i, j int) bool {
 		return backups[i].CreatedAt > backups[j].CreatedAt
 	})
@@ -104,21 +105,16 @@ func ListBackups(serverID string) ([]Backup, error) {
 	return backups, nil
 }
 
-func CreateBackup(serverID, name string) (*Backup, error) {
+func CreateBackup(serverID, id, name, ignore string, hooks *BackupHooks) (*Backup, error) {
 	if name == "" {
 		name = fmt.Sprintf("Backup at %s", time.Now().Format("2006-01-02 15:04:05"))
 	}
-
-	dir := backupDir(serverID)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, err
+	if id == "" {
+		id = newBackupID()
+	} else if !validBackupID(id) {
+		return nil, fmt.Errorf("invalid backup id")
 	}
 
-	safeID := strings.ReplaceAll(name, " ", "-")
-	safeID = strings.ReplaceAll(safeID, ":", "-")
-	filename := fmt.Sprintf("%s.tar.gz", safeID)
-	destPath := filepath.Join(dir, filename)
-
 	srcDir := serverDataDir(serverID)
 	if _, err := os.Stat(srcDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(srcDir, 0755); err != nil {
@@ -126,78 +122,118 @@ func CreateBackup(serverID, name string) (*Backup, error) {
 		}
 	}
 
+	store, err := serverBackupStore(serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := GetBackupPath(serverID, id); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", id)
+	}
+	if ok, _ := store.objects.Has(snapshotInfoKey(serverID, id)); ok {
+		return nil, fmt.Errorf("backup %s already exists", id)
+	}
+
 	backup := &Backup{
-		ID:        safeID,
+		ID:        id,
 		Name:      name,
 		Size:      0,
 		CreatedAt: time.Now().Unix(),
 		Completed: false,
+		Storage:   store.kind,
 	}
 
 	inProgressBackupsMu.Lock()
 	if inProgressBackups[serverID] == nil {
 		inProgressBackups[serverID] = make(map[string]*Backup)
 	}
-	inProgressBackups[serverID][safeID] = backup
+	if _, exists := inProgressBackups[serverID][backup.ID]; exists {
+		inProgressBackupsMu.Unlock()
+		return nil, fmt.Errorf("backup %s already exists", id)
+	}
+	inProgressBackups[serverID][backup.ID] = backup
 	inProgressBackupsMu.Unlock()
 
 	go func() {
 		BroadcastLog(serverID, fmt.Sprintf("Creating backup: %s", name))
-		
-		var success bool
-		containerName := "birdactyl-" + serverID
-		
-		status, _ := GetStatus(serverID)
-		if status == "running" {
-			cmd := exec.Command("docker", "exec", containerName, "tar", "-czf", "-", "-C", "/home/container", ".")
-			outFile, err := os.Create(destPath)
-			if err != nil {
-				BroadcastLog(serverID, fmt.Sprintf("Backup failed: %v", err))
-				inProgressBackupsMu.Lock()
-				delete(inProgressBackups[serverID], safeID)
-				inProgressBackupsMu.Unlock()
-				return
-			}
-			cmd.Stdout = outFile
-			var stderr bytes.Buffer
-			cmd.Stderr = &stderr
-			err = cmd.Run()
-			outFile.Close()
-			if err != nil {
-				BroadcastLog(serverID, fmt.Sprintf("Backup failed: %v - %s", err, stderr.String()))
-				os.Remove(destPath)
-			} else {
-				success = true
-			}
+
+		result := *backup
+		runHooks := false
+		if hooks != nil {
+			status, _ := GetStatus(serverID)
+			runHooks = status == "running"
+		}
+		if runHooks {
+			result.Warnings = append(result.Warnings, runBackupHooks(serverID, "pre", hooks.Pre)...)
+		}
+
+		if snap, err := store.createSnapshot(serverID, backup.ID, name, srcDir, loadBackupIgnore(srcDir, ignore)); err != nil {
+			BroadcastLog(serverID, fmt.Sprintf("Backup failed: %v", err))
+			result.Error = err.Error()
+			go store.gc()
 		} else {
-			cmd := exec.Command("tar", "-czf", destPath, "-C", srcDir, ".")
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				BroadcastLog(serverID, fmt.Sprintf("Backup failed: %v - %s", err, string(output)))
-				os.Remove(destPath)
-			} else {
-				success = true
-			}
+			BroadcastLog(serverID, fmt.Sprintf("Backup completed (%d files, %d bytes)", snap.Files, snap.Size))
+			result.Completed = true
+			result.Size = snap.Size
+			result.Checksum = snap.Checksum
 		}
 
-		if success {
-			BroadcastLog(serverID, "Backup completed")
+		if runHooks {
+			result.Warnings = append(result.Warnings, runBackupHooks(serverID, "post", hooks.Post)...)
 		}
 
 		inProgressBackupsMu.Lock()
-		delete(inProgressBackups[serverID], safeID)
+		delete(inProgressBackups[serverID], backup.ID)
 		inProgressBackupsMu.Unlock()
+
+		reportBackup(serverID, result)
 	}()
 
 	return backup, nil
 }
 
+func reportBackup(serverID string, b Backup) {
+	report := panel.BackupReport{
+		ServerID: serverID,
+		BackupID: b.ID,
+		Status:   "completed",
+		Size:     b.Size,
+		Checksum: b.Checksum,
+		Storage:  b.Storage,
+		Error:    b.Error,
+		Warnings: b.Warnings,
+	}
+	if !b.Completed {
+		report.Status = "failed"
+	}
+
+	client := panel.NewClient()
+	var err error
+	for attempt := 1; attempt <= backupReportAttempts; attempt++ {
+		if err = client.ReportBackup(report); err == nil {
+			return
+		}
+		time.Sleep(time.Duration(attempt) * 5 * time.Second)
+	}
+	logger.Warn("Failed to report backup %s for server %s to panel: %v", b.ID, serverID, err)
+}
+
 func DeleteBackup(serverID, backupID string) error {
-	path := filepath.Join(backupDir(serverID), backupID+".tar.gz")
-	return os.Remove(path)
+	if path, err := GetBackupPath(serverID, backupID); err == nil {
+		return os.Remove(path)
+	}
+	store, _, err := findSnapshot(serverID, backupID)
+	if err != nil {
+		return err
+	}
+	archiveSizes.Delete(serverID + "/" + backupID)
+	return store.deleteSnapshot(serverID, backupID)
 }
 
 func GetBackupPath(serverID, backupID string) (string, error) {
+	if !validBackupID(backupID) {
+		return "", fmt.Errorf("backup not found")
+	}
 	path := filepath.Join(backupDir(serverID), backupID+".tar.gz")
 	if _, err := os.Stat(path); err != nil {
 		return "", fmt.Errorf("backup not found")
@@ -205,6 +241,83 @@ func GetBackupPath(serverID, backupID string) (string, error) {
 	return path, nil
 }
 
+func OpenBackupArchive(serverID, backupID string) (io.ReadCloser, error) {
+	store, snap, err := findSnapshot(serverID, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		var w countingWriter
+		err := store.writeTar(snap, io.MultiWriter(pw, &w))
+		pw.CloseWithError(err)
+		if err == nil {
+			rememberArchiveSize(store, serverID, backupID, w.n)
+		}
+	}()
+	return pr, nil
+}
+
+type countingWriter struct{ n int64 }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+var archiveSizes sync.Map
+
+func rememberArchiveSize(store *backupStore, serverID, backupID string, size int64) {
+	key := serverID + "/" + backupID
+	if _, ok := archiveSizes.Load(key); ok {
+		return
+	}
+	archiveSizes.Store(key, size)
+	if err := store.saveArchiveSize(serverID, backupID, size); err != nil {
+		logger.Warn("Failed to save archive size for backup %s: %v", backupID, err)
+	}
+}
+
+// KnownBackupArchiveSize returns the length of the stream OpenBackupArchive
+// produces if it has already been measured, without generating the archive.
+func KnownBackupArchiveSize(serverID, backupID string) (int64, bool) {
+	key := serverID + "/" + backupID
+	if size, ok := archiveSizes.Load(key); ok {
+		return size.(int64), true
+	}
+	if !validBackupID(backupID) {
+		return 0, false
+	}
+	for _, store := range serverBackupStores(serverID) {
+		if size, ok := store.loadArchiveSize(serverID, backupID); ok {
+			archiveSizes.Store(key, size)
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+// BackupArchiveSize returns the length of the stream OpenBackupArchive
+// produces, which lets ranged downloads skip ahead in a fresh stream. The size
+// is recorded the first time an archive is streamed in full or measured, and
+// kept with the snapshot so it survives restarts.
+func BackupArchiveSize(serverID, backupID string) (int64, error) {
+	if size, ok := KnownBackupArchiveSize(serverID, backupID); ok {
+		return size, nil
+	}
+	store, snap, err := findSnapshot(serverID, backupID)
+	if err != nil {
+		return 0, err
+	}
+	var w countingWriter
+	if err := store.writeTar(snap, &w); err != nil {
+		return 0, err
+	}
+	rememberArchiveSize(store, serverID, backupID, w.n)
+	return w.n, nil
+}
+
 func ArchiveServer(serverID string) (string, error) {
 	cfg := config.Get()
 	archiveDir := filepath.Join(cfg.Node.BackupDir, "transfers")
@@ -228,37 +341,100 @@ func ArchiveServer(serverID string) (string, error) {
 	return archivePath, nil
 }
 
-func RestoreBackup(serverID, backupID string) error {
-	backupPath, err := GetBackupPath(serverID, backupID)
-	if err != nil {
-		return err
+func cleanBackupPaths(paths []string) ([]string, error) {
+	cleaned := make([]string, 0, len(paths))
+	for _, p := range paths {
+		c := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(p)), "/")
+		if c == "" {
+			return nil, fmt.Errorf("path must not be the server root")
+		}
+		cleaned = append(cleaned, c)
 	}
+	return cleaned, nil
+}
 
-	destDir := serverDataDir(serverID)
+func pathSelected(p string, selected []string) bool {
+	for _, s := range selected {
+		if p == s || strings.HasPrefix(p, s+"/") {
+			return true
+		}
+	}
+	return false
+}
 
-	entries, err := os.ReadDir(destDir)
-	if err == nil {
-		for _, entry := range entries {
-			os.RemoveAll(filepath.Join(destDir, entry.Name()))
+func RestoreBackup(serverID, backupID string, paths []string) error {
+	backupPath, legacyErr := GetBackupPath(serverID, backupID)
+	var store *backupStore
+	var snap *snapshot
+	if legacyErr != nil {
+		var err error
+		if store, snap, err = findSnapshot(serverID, backupID); err != nil {
+			return err
 		}
 	}
 
+	selected, err := cleanBackupPaths(paths)
+	if err != nil {
+		return err
+	}
+	var include func(string) bool
+	if len(selected) > 0 {
+		if snap == nil {
+			return fmt.Errorf("partial restore is not supported for legacy backups")
+		}
+		include = func(p string) bool { return pathSelected(p, selected) }
+		found := false
+		for _, e := range snap.Entries {
+			if include(e.Path) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("none of the requested paths exist in the backup")
+		}
+	}
+
+	destDir := serverDataDir(serverID)
 	if err := os.MkdirAll(destDir, 0755); err != nil {
 		return fmt.Errorf("failed to create server directory: %v", err)
 	}
 
-	BroadcastLog(serverID, fmt.Sprintf("Restoring backup: %s", backupID))
+	var ignore *ignoreMatcher
+	if snap != nil {
+		if snap.Ignore != "" {
+			ignore = newIgnoreMatcher(snap.Ignore)
+		} else {
+			ignore = loadBackupIgnore(destDir, "")
+		}
+	}
+	if err := clearRestoreTargets(destDir, selected, ignore); err != nil {
+		return fmt.Errorf("failed to clear restore targets: %v", err)
+	}
 
-	cmd := exec.Command("tar", "-xzf", backupPath, "-C", destDir)
-	if err := cmd.Run(); err != nil {
-		BroadcastLog(serverID, fmt.Sprintf("Restore failed: %v", err))
-		return fmt.Errorf("failed to extract backup: %v", err)
+	if include != nil {
+		BroadcastLog(serverID, fmt.Sprintf("Restoring %s from backup: %s", strings.Join(selected, ", "), backupID))
+	} else {
+		BroadcastLog(serverID, fmt.Sprintf("Restoring backup: %s", backupID))
+	}
+
+	if snap != nil {
+		if err := store.restoreSnapshot(snap, destDir, include); err != nil {
+			BroadcastLog(serverID, fmt.Sprintf("Restore failed: %v", err))
+			return fmt.Errorf("failed to restore backup: %v", err)
+		}
+	} else {
+		cmd := exec.Command("tar", "-xzf", backupPath, "-C", destDir)
+		if err := cmd.Run(); err != nil {
+			BroadcastLog(serverID, fmt.Sprintf("Restore failed: %v", err))
+			return fmt.Errorf("failed to extract backup: %v", err)
+		}
 	}
 
 	uid, _ := strconv.Atoi(GetServerUID())
 	filepath.Walk(destDir, func(path string, info os.FileInfo, err error) error {
 		if err == nil {
-			os.Chown(path, uid, uid)
+			os.Lchown(path, uid, uid)
 		}
 		return nil
 	})
@@ -267,6 +443,114 @@ func RestoreBackup(serverID, backupID string) error {
 	return nil
 }
 
+// clearRestoreTargets deletes what a restore is about to replace: the
+// selected paths, or the whole server directory when none are selected.
+// Paths the backup's ignore rules excluded were never captured, so they are
+// kept rather than lost, along with the directories that hold them.
+// Snapshots without stored rules use the server's current .birdactylignore.
+func clearRestoreTargets(destDir string, selected []string, ignore *ignoreMatcher) error {
+	roots := []string{destDir}
+	if len(selected) > 0 {
+		roots = roots[:0]
+		for _, p := range selected {
+			target, err := restoreTarget(destDir, p)
+			if err != nil {
+				return err
+			}
+			roots = append(roots, target)
+		}
+	}
+
+	for _, root := range roots {
+		var dirs []string
+		err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if p == destDir {
+				return nil
+			}
+			rel, err := filepath.Rel(destDir, p)
+			if err != nil {
+				return err
+			}
+			if ignore.ignored(filepath.ToSlash(rel), d.IsDir()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				dirs = append(dirs, p)
+				return nil
+			}
+			return os.Remove(p)
+		})
+		if err != nil {
+			return err
+		}
+		for i := len(dirs) - 1; i >= 0; i-- {
+			os.Remove(dirs[i])
+		}
+	}
+	return nil
+}
+
+func ListBackupFiles(serverID, backupID, dir string) ([]FileEntry, error) {
+	if _, err := GetBackupPath(serverID, backupID); err == nil {
+		return nil, fmt.Errorf("file listing is not supported for legacy backups")
+	}
+	_, snap, err := findSnapshot(serverID, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(dir)), "/")
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+		found := false
+		for _, e := range snap.Entries {
+			if e.Path == dir && e.Type == entryDir {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("directory not found in backup")
+		}
+	}
+
+	files := []FileEntry{}
+	for _, e := range snap.Entries {
+		if !strings.HasPrefix(e.Path, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(e.Path, prefix)
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		mode := os.FileMode(e.Mode)
+		switch e.Type {
+		case entryDir:
+			mode |= os.ModeDir
+		case entrySymlink:
+			mode |= os.ModeSymlink
+		}
+		files = append(files, FileEntry{
+			Name:    name,
+			Size:    e.Size,
+			IsDir:   e.Type == entryDir,
+			ModTime: time.Unix(0, e.ModTime).Unix(),
+			Mode:    mode.String(),
+		})
+	}
+	return files, nil
+}
+
 func GetArchivePath(serverID string) (string, error) {
 	cfg := config.Get()
 	path := filepath.Join(cfg.Node.BackupDir, "transfers", fmt.Sprintf("%s-transfer.tar.gz", serverID))
@@ -297,7 +581,7 @@ func ImportServer(serverID string, archivePath string) error {
 	uid, _ := strconv.Atoi(GetServerUID())
 	filepath.Walk(destDir, func(path string, info os.FileInfo, err error) error {
 		if err == nil {
-			os.Chown(path, uid, uid)
+			os.Lchown(path, uid, uid)
 		}
 		return nil
 	})
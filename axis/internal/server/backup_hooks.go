@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cauthon-axis/internal/logger"
+)
+
+const defaultBackupHookTimeout = 30 * time.Second
+
+type BackupHook struct {
+	Command string `json:"command"`
+	WaitFor string `json:"wait_for,omitempty"`
+	Timeout int    `json:"timeout,omitempty"`
+}
+
+type BackupHooks struct {
+	Pre  []BackupHook `json:"pre"`
+	Post []BackupHook `json:"post"`
+}
+
+var logTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\S+ `)
+
+func runBackupHooks(serverID, stage string, hooks []BackupHook) []string {
+	var warnings []string
+	for _, hook := range hooks {
+		if err := runBackupHook(serverID, hook); err != nil {
+			msg := fmt.Sprintf("%s-backup command %q: %v", stage, hook.Command, err)
+			logger.Warn("Server %s: %s", serverID, msg)
+			BroadcastLog(serverID, "Backup warning: "+msg)
+			warnings = append(warnings, msg)
+		}
+	}
+	return warnings
+}
+
+func runBackupHook(serverID string, hook BackupHook) error {
+	if hook.WaitFor == "" {
+		return SendCommand(serverID, hook.Command)
+	}
+
+	pattern, err := regexp.Compile(hook.WaitFor)
+	if err != nil {
+		return fmt.Errorf("invalid wait pattern: %v", err)
+	}
+	timeout := defaultBackupHookTimeout
+	if hook.Timeout > 0 {
+		timeout = time.Duration(hook.Timeout) * time.Second
+	}
+
+	logs, err := GetLogs(serverID, "0", true)
+	if err != nil {
+		return fmt.Errorf("failed to follow console: %v", err)
+	}
+	defer logs.Close()
+
+	matched := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(logs)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) > 8 {
+				line = line[8:]
+			}
+			text := logTimestampPattern.ReplaceAllString(strings.TrimRight(string(line), "\r"), "")
+			if pattern.MatchString(text) {
+				close(matched)
+				return
+			}
+		}
+	}()
+
+	if err := SendCommand(serverID, hook.Command); err != nil {
+		return err
+	}
+
+	select {
+	case <-matched:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("pattern %q did not appear within %s", hook.WaitFor, timeout)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreTargetRefusesSymlinkedParent(t *testing.T) {
+	destDir := t.TempDir()
+	outside := t.TempDir()
+	writeTestFile(t, filepath.Join(outside, "region", "r.0.0.mca"), "host data")
+	if err := os.Symlink(outside, filepath.Join(destDir, "world")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := restoreTarget(destDir, "world/region/r.0.0.mca"); err == nil {
+		t.Fatal("expected restore through a symlinked parent to be refused")
+	}
+	if err := clearRestoreTargets(destDir, []string{"world/region"}, nil); err == nil {
+		t.Fatal("expected clearing through a symlinked parent to be refused")
+	}
+	if data, err := os.ReadFile(filepath.Join(outside, "region", "r.0.0.mca")); err != nil || string(data) != "host data" {
+		t.Fatalf("file outside the server directory was modified: %q, %v", data, err)
+	}
+}
+
+func TestRestoreTargetReplacesSymlinkedFile(t *testing.T) {
+	destDir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "passwd")
+	writeTestFile(t, outside, "host data")
+	if err := os.Symlink(outside, filepath.Join(destDir, "server.properties")); err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := restoreTarget(destDir, "server.properties")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Fatalf("expected symlink at the target to be removed, got %v", err)
+	}
+	if data, _ := os.ReadFile(outside); string(data) != "host data" {
+		t.Fatalf("symlink target was modified: %q", data)
+	}
+}
+
+func TestClearRestoreTargetsKeepsIgnoredPaths(t *testing.T) {
+	destDir := t.TempDir()
+	outside := t.TempDir()
+	writeTestFile(t, filepath.Join(outside, "keep"), "host data")
+	writeTestFile(t, filepath.Join(destDir, "world", "level.dat"), "level")
+	writeTestFile(t, filepath.Join(destDir, "plugins", "dynmap", "web", "tiles", "0.png"), "tile")
+	writeTestFile(t, filepath.Join(destDir, "plugins", "dynmap", "config.yml"), "config")
+	if err := os.Symlink(outside, filepath.Join(destDir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := clearRestoreTargets(destDir, nil, newIgnoreMatcher("/plugins/dynmap/web/tiles/")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, gone := range []string{"world", "plugins/dynmap/config.yml", "link"} {
+		if _, err := os.Lstat(filepath.Join(destDir, filepath.FromSlash(gone))); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", gone)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "plugins", "dynmap", "web", "tiles", "0.png")); err != nil {
+		t.Errorf("expected ignored tiles to be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "keep")); err != nil {
+		t.Errorf("symlink target was removed: %v", err)
+	}
+}
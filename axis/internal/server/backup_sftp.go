@@ -0,0 +1,200 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const sftpDialTimeout = 15 * time.Second
+
+type sftpObjectStore struct {
+	cfg    SFTPConfig
+	root   string
+	mu     sync.Mutex
+	conn   *ssh.Client
+	client *sftp.Client
+}
+
+func newSFTPObjectStore(cfg *SFTPConfig, serverID string) *sftpObjectStore {
+	c := *cfg
+	if c.Port == 0 {
+		c.Port = 22
+	}
+	return &sftpObjectStore{
+		cfg:  c,
+		root: path.Join(c.Path, serverID),
+	}
+}
+
+func (s *sftpObjectStore) connect() (*sftp.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	var auth []ssh.AuthMethod
+	if s.cfg.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(s.cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sftp private key: %v", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if s.cfg.Password != "" {
+		auth = append(auth, ssh.Password(s.cfg.Password))
+	}
+
+	conn, err := ssh.Dial("tcp", net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port)), &ssh.ClientConfig{
+		User:            s.cfg.Username,
+		Auth:            auth,
+		HostKeyCallback: s.checkHostKey,
+		Timeout:         sftpDialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sftp connection failed: %v", err)
+	}
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("sftp session failed: %v", err)
+	}
+
+	s.conn = conn
+	s.client = client
+	go func() {
+		conn.Wait()
+		s.mu.Lock()
+		if s.conn == conn {
+			s.conn = nil
+			s.client = nil
+		}
+		s.mu.Unlock()
+	}()
+	return client, nil
+}
+
+func (s *sftpObjectStore) checkHostKey(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	fingerprint := ssh.FingerprintSHA256(key)
+	if s.cfg.HostKey == "" {
+		return fmt.Errorf("no host_key configured for %s, refusing unverified host key %s", hostname, fingerprint)
+	}
+	want := s.cfg.HostKey
+	if !strings.HasPrefix(want, "SHA256:") {
+		want = "SHA256:" + want
+	}
+	if fingerprint != want {
+		return fmt.Errorf("host key mismatch for %s: got %s", hostname, fingerprint)
+	}
+	return nil
+}
+
+func (s *sftpObjectStore) path(key string) string {
+	return path.Join(s.root, key)
+}
+
+func (s *sftpObjectStore) Has(key string) (bool, error) {
+	client, err := s.connect()
+	if err != nil {
+		return false, err
+	}
+	_, err = client.Stat(s.path(key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *sftpObjectStore) Put(key string, data []byte) error {
+	client, err := s.connect()
+	if err != nil {
+		return err
+	}
+	dest := s.path(key)
+	if err := client.MkdirAll(path.Dir(dest)); err != nil {
+		return err
+	}
+
+	tmp := path.Join(path.Dir(dest), ".tmp-"+path.Base(dest))
+	f, err := client.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		client.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		client.Remove(tmp)
+		return err
+	}
+
+	if err := client.PosixRename(tmp, dest); err != nil {
+		client.Remove(dest)
+		if err := client.Rename(tmp, dest); err != nil {
+			client.Remove(tmp)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sftpObjectStore) Get(key string) ([]byte, error) {
+	client, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	f, err := client.Open(s.path(key))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func (s *sftpObjectStore) Delete(key string) error {
+	client, err := s.connect()
+	if err != nil {
+		return err
+	}
+	err = client.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *sftpObjectStore) List(prefix string) ([]string, error) {
+	client, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	walker := client.Walk(s.path(prefix))
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		info := walker.Stat()
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".tmp-") {
+			continue
+		}
+		keys = append(keys, strings.TrimPrefix(walker.Path(), s.root+"/"))
+	}
+	return keys, nil
+}
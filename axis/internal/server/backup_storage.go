@@ -0,0 +1,231 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"cauthon-axis/internal/config"
+)
+
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+	StorageSFTP  = "sftp"
+)
+
+type StorageConfig struct {
+	Type string      `json:"type"`
+	S3   *S3Config   `json:"s3,omitempty"`
+	SFTP *SFTPConfig `json:"sftp,omitempty"`
+}
+
+type S3Config struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	PathStyle bool   `json:"path_style"`
+}
+
+type SFTPConfig struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	PrivateKey string `json:"private_key"`
+	HostKey    string `json:"host_key"`
+	Path       string `json:"path"`
+}
+
+var (
+	remoteStores   = make(map[string]*backupStore)
+	remoteStoresMu sync.Mutex
+	storageFileMu  sync.Mutex
+)
+
+func (c *StorageConfig) Validate() error {
+	switch c.Type {
+	case "", StorageLocal:
+		return nil
+	case StorageS3:
+		if c.S3 == nil || c.S3.Endpoint == "" || c.S3.Bucket == "" {
+			return fmt.Errorf("s3 storage requires endpoint and bucket")
+		}
+		if c.S3.AccessKey == "" || c.S3.SecretKey == "" {
+			return fmt.Errorf("s3 storage requires access_key and secret_key")
+		}
+		if !strings.HasPrefix(c.S3.Endpoint, "http://") && !strings.HasPrefix(c.S3.Endpoint, "https://") {
+			return fmt.Errorf("s3 endpoint must start with http:// or https://")
+		}
+	case StorageSFTP:
+		if c.SFTP == nil || c.SFTP.Host == "" || c.SFTP.Username == "" || c.SFTP.Path == "" {
+			return fmt.Errorf("sftp storage requires host, username and path")
+		}
+		if c.SFTP.Password == "" && c.SFTP.PrivateKey == "" {
+			return fmt.Errorf("sftp storage requires a password or private_key")
+		}
+		if c.SFTP.HostKey == "" {
+			return fmt.Errorf("sftp storage requires a host_key")
+		}
+	default:
+		return fmt.Errorf("unknown storage type %q", c.Type)
+	}
+	return nil
+}
+
+func (c *StorageConfig) isLocal() bool {
+	return c.Type == "" || c.Type == StorageLocal
+}
+
+func (c *StorageConfig) Redacted() StorageConfig {
+	out := StorageConfig{Type: c.Type}
+	if out.Type == "" {
+		out.Type = StorageLocal
+	}
+	if c.S3 != nil {
+		s3 := *c.S3
+		s3.SecretKey = ""
+		out.S3 = &s3
+	}
+	if c.SFTP != nil {
+		sftp := *c.SFTP
+		sftp.Password = ""
+		sftp.PrivateKey = ""
+		out.SFTP = &sftp
+	}
+	return out
+}
+
+func storageFile(serverID string) string {
+	return filepath.Join(config.Get().Node.BackupDir, "storage", serverID+".json")
+}
+
+func GetBackupStorage(serverID string) (StorageConfig, error) {
+	var cfg StorageConfig
+	data, err := os.ReadFile(storageFile(serverID))
+	if os.IsNotExist(err) {
+		return StorageConfig{Type: StorageLocal}, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid storage config: %v", err)
+	}
+	return cfg, nil
+}
+
+func SetBackupStorage(serverID string, cfg StorageConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	storageFileMu.Lock()
+	defer storageFileMu.Unlock()
+
+	path := storageFile(serverID)
+	if cfg.isLocal() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	store, err := remoteBackupStore(serverID, &cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := store.objects.List("index/" + serverID + "/"); err != nil {
+		return fmt.Errorf("storage check failed: %v", err)
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func DeleteBackupStorage(serverID string) {
+	storageFileMu.Lock()
+	defer storageFileMu.Unlock()
+	os.Remove(storageFile(serverID))
+}
+
+func remoteBackupStore(serverID string, cfg *StorageConfig) (*backupStore, error) {
+	data, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(append(data, serverID...))
+	key := hex.EncodeToString(sum[:])
+
+	remoteStoresMu.Lock()
+	defer remoteStoresMu.Unlock()
+	if store, ok := remoteStores[key]; ok {
+		return store, nil
+	}
+
+	var objects objectStore
+	switch cfg.Type {
+	case StorageS3:
+		objects = newS3ObjectStore(cfg.S3, serverID)
+	case StorageSFTP:
+		objects = newSFTPObjectStore(cfg.SFTP, serverID)
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", cfg.Type)
+	}
+
+	store := &backupStore{objects: objects, kind: cfg.Type}
+	remoteStores[key] = store
+	return store, nil
+}
+
+func serverBackupStore(serverID string) (*backupStore, error) {
+	cfg, err := GetBackupStorage(serverID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.isLocal() {
+		return getBackupStore(), nil
+	}
+	return remoteBackupStore(serverID, &cfg)
+}
+
+func serverBackupStores(serverID string) []*backupStore {
+	local := getBackupStore()
+	store, err := serverBackupStore(serverID)
+	if err != nil || store == local {
+		return []*backupStore{local}
+	}
+	return []*backupStore{store, local}
+}
+
+func findSnapshot(serverID, backupID string) (*backupStore, *snapshot, error) {
+	if !validBackupID(backupID) {
+		return nil, nil, fmt.Errorf("backup not found")
+	}
+	for _, store := range serverBackupStores(serverID) {
+		if ok, err := store.objects.Has(snapshotInfoKey(serverID, backupID)); err != nil || !ok {
+			continue
+		}
+		snap, err := store.loadSnapshot(serverID, backupID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, snap, nil
+	}
+	return nil, nil, fmt.Errorf("backup not found")
+}
@@ -0,0 +1,727 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/config"
+	"cauthon-axis/internal/logger"
+)
+
+const (
+	chunkMinSize = 512 << 10
+	chunkMaxSize = 8 << 20
+	chunkMask    = uint64(1<<20-1) << 44
+	gearSeed     = 0x6269726461637479
+)
+
+var gearTable = func() [256]uint64 {
+	var table [256]uint64
+	state := uint64(gearSeed)
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+type chunker struct {
+	r   io.Reader
+	buf []byte
+	n   int
+	eof bool
+}
+
+func newChunker(r io.Reader) *chunker {
+	return &chunker{r: r, buf: make([]byte, chunkMaxSize)}
+}
+
+func (c *chunker) next() ([]byte, error) {
+	for c.n < len(c.buf) && !c.eof {
+		m, err := c.r.Read(c.buf[c.n:])
+		c.n += m
+		if err == io.EOF {
+			c.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if c.n == 0 {
+		return nil, io.EOF
+	}
+
+	cut := c.n
+	if c.n > chunkMinSize {
+		var h uint64
+		for i := chunkMinSize - 64; i < c.n; i++ {
+			h = (h << 1) + gearTable[c.buf[i]]
+			if i >= chunkMinSize && h&chunkMask == 0 {
+				cut = i + 1
+				break
+			}
+		}
+	}
+
+	chunk := make([]byte, cut)
+	copy(chunk, c.buf[:cut])
+	c.n = copy(c.buf, c.buf[cut:c.n])
+	return chunk, nil
+}
+
+type objectStore interface {
+	Has(key string) (bool, error)
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+	List(prefix string) ([]string, error)
+}
+
+type localObjectStore struct {
+	root string
+}
+
+func (s *localObjectStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+func (s *localObjectStore) Has(key string) (bool, error) {
+	_, err := os.Stat(s.path(key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *localObjectStore) Put(key string, data []byte) error {
+	dest := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+func (s *localObjectStore) Get(key string) ([]byte, error) {
+	return os.ReadFile(s.path(key))
+}
+
+func (s *localObjectStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *localObjectStore) List(prefix string) ([]string, error) {
+	var keys []string
+	root := s.path(prefix)
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(rel))
+		return nil
+	})
+	return keys, err
+}
+
+type snapshotInfo struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ServerID  string `json:"server_id"`
+	CreatedAt int64  `json:"created_at"`
+	Size      int64  `json:"size"`
+	Files     int    `json:"files"`
+	Checksum  string `json:"checksum"`
+}
+
+// archiveSize records the length of the stream writeTar produced for a
+// snapshot. Gzip output can change between Go releases, so it only applies to
+// the runtime that measured it.
+type archiveSize struct {
+	Size int64  `json:"size"`
+	Go   string `json:"go"`
+}
+
+type snapshot struct {
+	snapshotInfo
+	Ignore  string          `json:"ignore,omitempty"`
+	Entries []snapshotEntry `json:"entries"`
+}
+
+type snapshotEntry struct {
+	Path    string   `json:"path"`
+	Type    string   `json:"type"`
+	Mode    uint32   `json:"mode"`
+	ModTime int64    `json:"mod_time"`
+	Size    int64    `json:"size,omitempty"`
+	Link    string   `json:"link,omitempty"`
+	Chunks  []string `json:"chunks,omitempty"`
+}
+
+const (
+	entryDir     = "dir"
+	entryFile    = "file"
+	entrySymlink = "symlink"
+)
+
+type backupStore struct {
+	objects objectStore
+	kind    string
+	mu      sync.RWMutex
+	gcMu    sync.Mutex
+}
+
+var (
+	defaultStore     *backupStore
+	defaultStoreOnce sync.Once
+)
+
+func getBackupStore() *backupStore {
+	defaultStoreOnce.Do(func() {
+		root := filepath.Join(config.Get().Node.BackupDir, "store")
+		defaultStore = &backupStore{objects: &localObjectStore{root: root}, kind: StorageLocal}
+	})
+	return defaultStore
+}
+
+func chunkKey(hash string) string {
+	return "chunks/" + hash[:2] + "/" + hash
+}
+
+func snapshotKey(serverID, backupID string) string {
+	return "snapshots/" + serverID + "/" + backupID + ".json.gz"
+}
+
+func snapshotInfoKey(serverID, backupID string) string {
+	return "index/" + serverID + "/" + backupID + ".json"
+}
+
+func archiveSizeKey(serverID, backupID string) string {
+	return "archives/" + serverID + "/" + backupID + ".json"
+}
+
+func newBackupID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+func validBackupID(id string) bool {
+	return id != "" && id != "." && id != ".." && !strings.ContainsAny(id, `/\`) && !strings.Contains(id, "..")
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gunzipBytes(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
+
+func (s *backupStore) putChunk(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	key := chunkKey(hash)
+
+	exists, err := s.objects.Has(key)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return hash, nil
+	}
+
+	compressed, err := gzipBytes(data)
+	if err != nil {
+		return "", err
+	}
+	return hash, s.objects.Put(key, compressed)
+}
+
+func (s *backupStore) getChunk(hash string) ([]byte, error) {
+	raw, err := s.objects.Get(chunkKey(hash))
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s missing: %v", hash, err)
+	}
+	data, err := gunzipBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s corrupt: %v", hash, err)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != hash {
+		return nil, fmt.Errorf("chunk %s failed verification", hash)
+	}
+	return data, nil
+}
+
+func (s *backupStore) saveSnapshot(snap *snapshot) error {
+	entries, err := json.Marshal(snap.Entries)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(entries)
+	snap.Checksum = hex.EncodeToString(sum[:])
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	compressed, err := gzipBytes(data)
+	if err != nil {
+		return err
+	}
+	if err := s.objects.Put(snapshotKey(snap.ServerID, snap.ID), compressed); err != nil {
+		return err
+	}
+
+	info, err := json.Marshal(snap.snapshotInfo)
+	if err != nil {
+		return err
+	}
+	return s.objects.Put(snapshotInfoKey(snap.ServerID, snap.ID), info)
+}
+
+func (s *backupStore) loadSnapshot(serverID, backupID string) (*snapshot, error) {
+	if !validBackupID(backupID) {
+		return nil, fmt.Errorf("backup not found")
+	}
+	raw, err := s.objects.Get(snapshotKey(serverID, backupID))
+	if err != nil {
+		return nil, fmt.Errorf("backup not found")
+	}
+	data, err := gunzipBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("backup manifest corrupt: %v", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("backup manifest corrupt: %v", err)
+	}
+	return &snap, nil
+}
+
+func (s *backupStore) listSnapshots(serverID string) ([]snapshotInfo, error) {
+	keys, err := s.objects.List("index/" + serverID + "/")
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]snapshotInfo, 0, len(keys))
+	for _, key := range keys {
+		data, err := s.objects.Get(key)
+		if err != nil {
+			continue
+		}
+		var info snapshotInfo
+		if json.Unmarshal(data, &info) == nil {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].CreatedAt > infos[j].CreatedAt })
+	return infos, nil
+}
+
+func (s *backupStore) deleteSnapshot(serverID, backupID string) error {
+	if !validBackupID(backupID) {
+		return fmt.Errorf("backup not found")
+	}
+	if ok, _ := s.objects.Has(snapshotInfoKey(serverID, backupID)); !ok {
+		return fmt.Errorf("backup not found")
+	}
+	if err := s.objects.Delete(snapshotInfoKey(serverID, backupID)); err != nil {
+		return err
+	}
+	if err := s.objects.Delete(snapshotKey(serverID, backupID)); err != nil {
+		return err
+	}
+	s.objects.Delete(archiveSizeKey(serverID, backupID))
+	go s.gc()
+	return nil
+}
+
+func (s *backupStore) loadArchiveSize(serverID, backupID string) (int64, bool) {
+	data, err := s.objects.Get(archiveSizeKey(serverID, backupID))
+	if err != nil {
+		return 0, false
+	}
+	var info archiveSize
+	if json.Unmarshal(data, &info) != nil || info.Go != runtime.Version() {
+		return 0, false
+	}
+	return info.Size, true
+}
+
+func (s *backupStore) saveArchiveSize(serverID, backupID string, size int64) error {
+	if ok, _ := s.objects.Has(snapshotInfoKey(serverID, backupID)); !ok {
+		return fmt.Errorf("backup not found")
+	}
+	data, err := json.Marshal(archiveSize{Size: size, Go: runtime.Version()})
+	if err != nil {
+		return err
+	}
+	return s.objects.Put(archiveSizeKey(serverID, backupID), data)
+}
+
+func (s *backupStore) createSnapshot(serverID, backupID, name, srcDir string, ignore *ignoreMatcher) (*snapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	previous := make(map[string]snapshotEntry)
+	if infos, err := s.listSnapshots(serverID); err == nil && len(infos) > 0 {
+		if prev, err := s.loadSnapshot(serverID, infos[0].ID); err == nil {
+			for _, e := range prev.Entries {
+				if e.Type == entryFile {
+					previous[e.Path] = e
+				}
+			}
+		}
+	}
+
+	snap := &snapshot{snapshotInfo: snapshotInfo{
+		ID:        backupID,
+		Name:      name,
+		ServerID:  serverID,
+		CreatedAt: time.Now().Unix(),
+	}}
+	if ignore != nil {
+		snap.Ignore = ignore.source
+	}
+
+	err := filepath.WalkDir(srcDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == srcDir {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		if ignore.ignored(filepath.ToSlash(rel), d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		entry := snapshotEntry{
+			Path:    filepath.ToSlash(rel),
+			Mode:    uint32(info.Mode().Perm()),
+			ModTime: info.ModTime().UnixNano(),
+		}
+
+		switch {
+		case d.IsDir():
+			entry.Type = entryDir
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			entry.Type = entrySymlink
+			entry.Link = link
+		case info.Mode().IsRegular():
+			entry.Type = entryFile
+			entry.Size = info.Size()
+			if prev, ok := previous[entry.Path]; ok && prev.Size == entry.Size && prev.ModTime == entry.ModTime && prev.Mode == entry.Mode {
+				entry.Chunks = prev.Chunks
+			} else if entry.Chunks, entry.Size, err = s.chunkFile(p); err != nil {
+				return fmt.Errorf("%s: %v", entry.Path, err)
+			}
+			snap.Size += entry.Size
+			snap.Files++
+		default:
+			return nil
+		}
+
+		snap.Entries = append(snap.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.saveSnapshot(snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func (s *backupStore) chunkFile(p string) ([]string, int64, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	var hashes []string
+	var size int64
+	c := newChunker(f)
+	for {
+		data, err := c.next()
+		if err == io.EOF {
+			return hashes, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		hash, err := s.putChunk(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		hashes = append(hashes, hash)
+		size += int64(len(data))
+	}
+}
+
+func (s *backupStore) writeEntry(w io.Writer, e snapshotEntry) error {
+	for _, hash := range e.Chunks {
+		data, err := s.getChunk(hash)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *backupStore) writeTar(snap *snapshot, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, e := range snap.Entries {
+		hdr := &tar.Header{
+			Name:    e.Path,
+			Mode:    int64(e.Mode),
+			ModTime: time.Unix(0, e.ModTime),
+		}
+		switch e.Type {
+		case entryDir:
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+		case entrySymlink:
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = e.Link
+		case entryFile:
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = e.Size
+		default:
+			continue
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if e.Type == entryFile {
+			if err := s.writeEntry(tw, e); err != nil {
+				return fmt.Errorf("%s: %v", e.Path, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// restoreTarget joins rel onto destDir and refuses paths that pass through a
+// symlinked directory, so a link planted by the server process cannot point
+// a restore at host files. A symlink at rel itself is removed so it gets
+// replaced instead of followed.
+func restoreTarget(destDir, rel string) (string, error) {
+	destDir = filepath.Clean(destDir)
+	target := filepath.Join(destDir, filepath.FromSlash(rel))
+	if !strings.HasPrefix(target, destDir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("%s: path escapes the server directory", rel)
+	}
+
+	cur := destDir
+	parts := strings.Split(strings.TrimPrefix(target, destDir+string(os.PathSeparator)), string(os.PathSeparator))
+	for i, part := range parts {
+		cur = filepath.Join(cur, part)
+		info, err := os.Lstat(cur)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		if i < len(parts)-1 {
+			return "", fmt.Errorf("%s: refusing to restore through symlink %s", rel, strings.Join(parts[:i+1], "/"))
+		}
+		if err := os.Remove(cur); err != nil {
+			return "", err
+		}
+	}
+	return target, nil
+}
+
+func (s *backupStore) restoreSnapshot(snap *snapshot, destDir string, include func(string) bool) error {
+	var links []snapshotEntry
+	var dirs []snapshotEntry
+
+	for _, e := range snap.Entries {
+		if include != nil && !include(e.Path) {
+			continue
+		}
+		target, err := restoreTarget(destDir, e.Path)
+		if err != nil {
+			return err
+		}
+
+		switch e.Type {
+		case entryDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			dirs = append(dirs, e)
+		case entrySymlink:
+			links = append(links, e)
+		case entryFile:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(e.Mode))
+			if err != nil {
+				return err
+			}
+			err = s.writeEntry(f, e)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("%s: %v", e.Path, err)
+			}
+			os.Chmod(target, os.FileMode(e.Mode))
+			mtime := time.Unix(0, e.ModTime)
+			os.Chtimes(target, mtime, mtime)
+		}
+	}
+
+	for _, e := range links {
+		target, err := restoreTarget(destDir, e.Path)
+		if err != nil {
+			return err
+		}
+		os.MkdirAll(filepath.Dir(target), 0755)
+		os.Remove(target)
+		if err := os.Symlink(e.Link, target); err != nil {
+			return err
+		}
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		target := filepath.Join(destDir, filepath.FromSlash(dirs[i].Path))
+		os.Chmod(target, os.FileMode(dirs[i].Mode))
+		mtime := time.Unix(0, dirs[i].ModTime)
+		os.Chtimes(target, mtime, mtime)
+	}
+	return nil
+}
+
+func (s *backupStore) gc() {
+	s.gcMu.Lock()
+	defer s.gcMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.objects.List("snapshots/")
+	if err != nil {
+		return
+	}
+	referenced := make(map[string]bool)
+	for _, key := range keys {
+		parts := strings.Split(key, "/")
+		if len(parts) != 3 {
+			continue
+		}
+		snap, err := s.loadSnapshot(parts[1], strings.TrimSuffix(parts[2], ".json.gz"))
+		if err != nil {
+			return
+		}
+		for _, e := range snap.Entries {
+			for _, hash := range e.Chunks {
+				referenced[hash] = true
+			}
+		}
+	}
+
+	chunks, err := s.objects.List("chunks/")
+	if err != nil {
+		return
+	}
+	removed := 0
+	for _, key := range chunks {
+		if !referenced[path.Base(key)] {
+			if s.objects.Delete(key) == nil {
+				removed++
+			}
+		}
+	}
+	if removed > 0 {
+		logger.Info("Removed %d unreferenced backup chunks", removed)
+	}
+}
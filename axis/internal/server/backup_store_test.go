@@ -0,0 +1,45 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveSizeFollowsSnapshot(t *testing.T) {
+	srcDir := t.TempDir()
+	writeTestFile(t, filepath.Join(srcDir, "server.properties"), "motd=hello")
+
+	store := &backupStore{objects: &localObjectStore{root: t.TempDir()}, kind: StorageLocal}
+	if _, err := store.createSnapshot("archive-test", "snap", "Snapshot", srcDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.loadArchiveSize("archive-test", "snap"); ok {
+		t.Fatal("expected creating a snapshot not to measure its archive")
+	}
+
+	if err := store.saveArchiveSize("archive-test", "snap", 1234); err != nil {
+		t.Fatal(err)
+	}
+	if size, ok := store.loadArchiveSize("archive-test", "snap"); !ok || size != 1234 {
+		t.Errorf("expected stored archive size 1234, got %d, %v", size, ok)
+	}
+
+	stale, _ := json.Marshal(archiveSize{Size: 1234, Go: "go1.0"})
+	if err := store.objects.Put(archiveSizeKey("archive-test", "snap"), stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.loadArchiveSize("archive-test", "snap"); ok {
+		t.Error("expected a size measured by another runtime to be ignored")
+	}
+
+	if err := store.deleteSnapshot("archive-test", "snap"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := store.objects.Has(archiveSizeKey("archive-test", "snap")); ok {
+		t.Error("expected the archive size to be deleted with the snapshot")
+	}
+	if err := store.saveArchiveSize("archive-test", "snap", 1234); err == nil {
+		t.Error("expected saving a size for a deleted snapshot to fail")
+	}
+}
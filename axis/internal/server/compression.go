@@ -1,7 +1,11 @@
 package server
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -33,7 +37,7 @@ func CompressPath(serverID, srcPath, destPath, format string) error {
 		return fmt.Errorf("unsupported format: %s", format)
 	}
 
-	return cmd.Run()
+	return runWithinQuota(serverID, dest, cmd)
 }
 
 func DecompressPath(serverID, srcPath, destPath string) error {
@@ -63,7 +67,17 @@ func DecompressPath(serverID, srcPath, destPath string) error {
 		return fmt.Errorf("unsupported archive format")
 	}
 
-	return cmd.Run()
+	size, err := uncompressedSize(src)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %v", err)
+	}
+	if err := CheckDiskSpace(serverID, size); err != nil {
+		return err
+	}
+
+	err = cmd.Run()
+	rescanDiskUsage(serverID)
+	return err
 }
 
 func BulkCompress(serverID string, paths []string, destPath, format string) error {
@@ -116,7 +130,67 @@ func BulkCompress(serverID string, paths []string, destPath, format string) erro
 		return fmt.Errorf("unsupported format: %s", format)
 	}
 
-	return cmd.Run()
+	return runWithinQuota(serverID, dest, cmd)
+}
+
+func runWithinQuota(serverID, dest string, cmd *exec.Cmd) error {
+	if err := CheckDiskSpace(serverID, 0); err != nil {
+		return err
+	}
+	err := cmd.Run()
+	if usage, limit, over := overDiskLimit(serverID); over {
+		os.Remove(dest)
+		rescanDiskUsage(serverID)
+		return fmt.Errorf("%w: archive would use %d MiB of %d MiB", ErrDiskQuotaExceeded, usage/1024/1024, limit/1024/1024)
+	}
+	return err
+}
+
+func uncompressedSize(src string) (int64, error) {
+	lower := strings.ToLower(src)
+	if strings.HasSuffix(lower, ".zip") {
+		r, err := zip.OpenReader(src)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+		var total int64
+		for _, f := range r.File {
+			total += int64(f.UncompressedSize64)
+		}
+		return total, nil
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var total int64
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			total += hdr.Size
+		}
+	}
 }
 
 func uniquePath(dest string) string {
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/config"
+	"cauthon-axis/internal/docker"
+	"cauthon-axis/internal/logger"
+
+	"github.com/spf13/afero"
+)
+
+const diskLimitLabel = "birdactyl.disk"
+
+var ErrDiskQuotaExceeded = errors.New("disk quota exceeded")
+
+type diskUsageEntry struct {
+	bytes     int64
+	scannedAt time.Time
+	scanning  bool
+}
+
+var (
+	diskUsage   = make(map[string]*diskUsageEntry)
+	diskLimits  = make(map[string]int64)
+	diskUsageMu sync.Mutex
+)
+
+func diskCheckInterval() time.Duration {
+	if cfg := config.Get(); cfg != nil && cfg.Node.DiskCheckInterval > 0 {
+		return time.Duration(cfg.Node.DiskCheckInterval) * time.Second
+	}
+	return time.Minute
+}
+
+func GetDiskUsage(serverID string) int64 {
+	diskUsageMu.Lock()
+	entry, ok := diskUsage[serverID]
+	if !ok {
+		diskUsageMu.Unlock()
+		return rescanDiskUsage(serverID)
+	}
+	if !entry.scanning && time.Since(entry.scannedAt) > diskCheckInterval() {
+		entry.scanning = true
+		go rescanDiskUsage(serverID)
+	}
+	usage := entry.bytes
+	diskUsageMu.Unlock()
+	return usage
+}
+
+func rescanDiskUsage(serverID string) int64 {
+	size := getDirSize(serverDataDir(serverID))
+	diskUsageMu.Lock()
+	diskUsage[serverID] = &diskUsageEntry{bytes: size, scannedAt: time.Now()}
+	diskUsageMu.Unlock()
+	return size
+}
+
+func trackDiskUsage(serverID string, delta int64) {
+	diskUsageMu.Lock()
+	defer diskUsageMu.Unlock()
+	if entry, ok := diskUsage[serverID]; ok {
+		entry.bytes += delta
+		if entry.bytes < 0 {
+			entry.bytes = 0
+		}
+	}
+}
+
+func resetDiskUsage(serverID string) {
+	diskUsageMu.Lock()
+	delete(diskUsage, serverID)
+	delete(diskLimits, serverID)
+	diskUsageMu.Unlock()
+}
+
+func DiskLimit(serverID string) int64 {
+	serverConfigsMu.RLock()
+	cfg := serverConfigs[serverID]
+	serverConfigsMu.RUnlock()
+	if cfg != nil {
+		return int64(cfg.Disk) * 1024 * 1024
+	}
+
+	diskUsageMu.Lock()
+	limit, ok := diskLimits[serverID]
+	diskUsageMu.Unlock()
+	if ok {
+		return limit
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	id, err := docker.GetContainerID(ctx, containerName(serverID))
+	if err != nil {
+		return 0
+	}
+	info, err := docker.Client.ContainerInspect(ctx, id)
+	if err != nil || info.Config == nil {
+		return 0
+	}
+	mb, _ := strconv.ParseInt(info.Config.Labels[diskLimitLabel], 10, 64)
+	limit = mb * 1024 * 1024
+
+	diskUsageMu.Lock()
+	diskLimits[serverID] = limit
+	diskUsageMu.Unlock()
+	return limit
+}
+
+// diskHeadroom returns how many more bytes the server may use. Data staged by
+// unfinished uploads counts as used. ok is false when the server has no limit.
+func diskHeadroom(serverID string) (free, limit int64, ok bool) {
+	limit = DiskLimit(serverID)
+	if limit <= 0 {
+		return 0, 0, false
+	}
+	return limit - GetDiskUsage(serverID) - stagedUploadBytes(serverID), limit, true
+}
+
+func CheckDiskSpace(serverID string, additional int64) error {
+	if free, limit, ok := diskHeadroom(serverID); ok && additional > free {
+		return fmt.Errorf("%w: limit is %d MiB", ErrDiskQuotaExceeded, limit/1024/1024)
+	}
+	return nil
+}
+
+func overDiskLimit(serverID string) (usage, limit int64, over bool) {
+	limit = DiskLimit(serverID)
+	if limit <= 0 {
+		return 0, 0, false
+	}
+	usage = GetDiskUsage(serverID)
+	return usage, limit, usage > limit
+}
+
+func MonitorDiskUsage() {
+	ticker := time.NewTicker(diskCheckInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		entries, err := os.ReadDir(config.Get().Node.DataDir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			serverID := e.Name()
+			if !e.IsDir() || ValidateServerID(serverID) != nil {
+				continue
+			}
+			limit := DiskLimit(serverID)
+			if limit <= 0 {
+				continue
+			}
+			if usage := rescanDiskUsage(serverID); usage > limit {
+				enforceDiskLimit(serverID, usage, limit)
+			}
+		}
+	}
+}
+
+func enforceDiskLimit(serverID string, usage, limit int64) {
+	if status, _ := GetStatus(serverID); status != "running" {
+		return
+	}
+
+	msg := fmt.Sprintf("Server is using %d MiB of its %d MiB disk limit", usage/1024/1024, limit/1024/1024)
+	if config.Get().Node.DiskOverage != config.DiskOverageStop {
+		logger.Warn("Server %s is over its disk limit (%d/%d MiB)", serverID, usage/1024/1024, limit/1024/1024)
+		BroadcastLog(serverID, msg+", free up space to avoid losing data")
+		return
+	}
+
+	logger.Warn("Stopping server %s: over its disk limit (%d/%d MiB)", serverID, usage/1024/1024, limit/1024/1024)
+	BroadcastLog(serverID, msg+", stopping server")
+
+	timeout := 30
+	serverConfigsMu.RLock()
+	if cfg := serverConfigs[serverID]; cfg != nil && cfg.StopTimeout > 0 {
+		timeout = cfg.StopTimeout
+	}
+	serverConfigsMu.RUnlock()
+	if err := Stop(serverID, timeout); err != nil {
+		logger.Error("Failed to stop server %s over disk limit: %v", serverID, err)
+	}
+}
+
+type quotaFile struct {
+	afero.File
+	serverID string
+	name     string
+	mu       sync.Mutex
+	size     int64
+	append   bool
+}
+
+func openQuotaFile(serverID string, fs afero.Fs, name string, flag int, perm os.FileMode) (*quotaFile, error) {
+	var size int64
+	if info, err := fs.Stat(name); err == nil && !info.IsDir() {
+		size = info.Size()
+	}
+	f, err := fs.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	if flag&os.O_TRUNC != 0 && size > 0 {
+		trackDiskUsage(serverID, -size)
+		size = 0
+	}
+	return &quotaFile{File: f, serverID: serverID, name: name, size: size, append: flag&os.O_APPEND != 0}, nil
+}
+
+func OpenQuotaFile(serverID string, fs afero.Fs, name string, flag int, perm os.FileMode) (afero.File, error) {
+	return openQuotaFile(serverID, fs, name, flag, perm)
+}
+
+func (f *quotaFile) write(p []byte, off int64, write func() (int, error)) (int, error) {
+	if grow := off + int64(len(p)) - f.size; grow > 0 {
+		if err := CheckDiskSpace(f.serverID, grow); err != nil {
+			return 0, err
+		}
+	}
+	n, err := write()
+	if end := off + int64(n); end > f.size {
+		trackDiskUsage(f.serverID, end-f.size)
+		f.size = end
+	}
+	return n, err
+}
+
+func (f *quotaFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	off := f.size
+	if !f.append {
+		pos, err := f.File.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, err
+		}
+		off = pos
+	}
+	return f.write(p, off, func() (int, error) { return f.File.Write(p) })
+}
+
+func (f *quotaFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+func (f *quotaFile) WriteAt(p []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.write(p, off, func() (int, error) { return f.File.WriteAt(p, off) })
+}
+
+func (f *quotaFile) discard(fs afero.Fs) {
+	f.File.Close()
+	if fs.Remove(f.name) == nil {
+		trackDiskUsage(f.serverID, -f.size)
+	}
+}
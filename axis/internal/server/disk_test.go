@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cauthon-axis/internal/config"
+)
+
+// setupQuotaServer points axis at a temporary data directory and registers a
+// server with a 1 MiB disk limit.
+func setupQuotaServer(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	cfgPath := filepath.Join(root, "config.yaml")
+	cfgData := fmt.Sprintf("node:\n  data_dir: %q\n  upload_dir: %q\n", filepath.Join(root, "servers"), filepath.Join(root, "uploads"))
+	if err := os.WriteFile(cfgPath, []byte(cfgData), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Load(cfgPath); err != nil {
+		t.Fatal(err)
+	}
+
+	serverID := "quota-test"
+	serverConfigsMu.Lock()
+	serverConfigs[serverID] = &ServerConfig{Disk: 1}
+	serverConfigsMu.Unlock()
+	t.Cleanup(func() {
+		serverConfigsMu.Lock()
+		delete(serverConfigs, serverID)
+		serverConfigsMu.Unlock()
+		resetDiskUsage(serverID)
+	})
+
+	dataDir := serverDataDir(serverID)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return serverID, dataDir
+}
+
+func TestCopyPathEnforcesQuota(t *testing.T) {
+	serverID, dataDir := setupQuotaServer(t)
+	writeTestFile(t, filepath.Join(dataDir, "world", "region.mca"), string(make([]byte, 600<<10)))
+	rescanDiskUsage(serverID)
+
+	if err := CopyPath(serverID, "/world", "/world-copy"); !errors.Is(err, ErrDiskQuotaExceeded) {
+		t.Fatalf("expected copy over the limit to fail with a quota error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "world-copy", "region.mca")); !os.IsNotExist(err) {
+		t.Errorf("expected the partial copy to be discarded, got %v", err)
+	}
+	if usage := GetDiskUsage(serverID); usage != 600<<10 {
+		t.Errorf("expected usage to stay at %d, got %d", 600<<10, usage)
+	}
+}
+
+func TestDeletePathFreesTrackedUsage(t *testing.T) {
+	serverID, dataDir := setupQuotaServer(t)
+	writeTestFile(t, filepath.Join(dataDir, ".trash", "old", "region.mca"), string(make([]byte, 600<<10)))
+	rescanDiskUsage(serverID)
+
+	if err := CheckDiskSpace(serverID, 600<<10); err == nil {
+		t.Fatal("expected the server to be near its limit")
+	}
+	if err := DeletePath(serverID, "/.trash/old"); err != nil {
+		t.Fatal(err)
+	}
+	if usage := GetDiskUsage(serverID); usage != 0 {
+		t.Errorf("expected deleted bytes to be subtracted, got %d", usage)
+	}
+	if err := CheckDiskSpace(serverID, 600<<10); err != nil {
+		t.Errorf("expected space to be available after the delete, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/docker"
+	"cauthon-axis/internal/logger"
+	"cauthon-axis/internal/panel"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const (
+	StateRunning = "running"
+	StateStopped = "stopped"
+	StateCrashed = "crashed"
+
+	expectedStopWindow = 2 * time.Minute
+	stateReportRetries = 3
+	stateQueueSize     = 256
+	crashLogLines      = 50
+)
+
+var (
+	expectedStops   = make(map[string]time.Time)
+	oomKilled       = make(map[string]bool)
+	expectedStopsMu sync.Mutex
+
+	stateQueue = make(chan panel.ServerState, stateQueueSize)
+)
+
+func expectStop(serverID string) {
+	expectedStopsMu.Lock()
+	expectedStops[serverID] = time.Now().Add(expectedStopWindow)
+	expectedStopsMu.Unlock()
+}
+
+func consumeStop(serverID string) (expected, oom bool) {
+	expectedStopsMu.Lock()
+	defer expectedStopsMu.Unlock()
+	deadline, ok := expectedStops[serverID]
+	oom = oomKilled[serverID]
+	delete(expectedStops, serverID)
+	delete(oomKilled, serverID)
+	return ok && time.Now().Before(deadline), oom
+}
+
+func serverIDFromContainer(name string) (string, bool) {
+	name = strings.TrimPrefix(name, "/")
+	if !strings.HasPrefix(name, "birdactyl-") || strings.HasPrefix(name, "birdactyl-install-") {
+		return "", false
+	}
+	id := strings.TrimPrefix(name, "birdactyl-")
+	return id, ValidateServerID(id) == nil
+}
+
+func WatchEvents() {
+	go reportStates()
+
+	backoff := time.Second
+	for {
+		ctx, cancel := context.WithCancel(context.Background())
+		msgs, errs := docker.Client.Events(ctx, events.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("type", string(events.ContainerEventType))),
+		})
+		syncStates(ctx)
+
+		err := func() error {
+			for {
+				select {
+				case msg := <-msgs:
+					backoff = time.Second
+					handleContainerEvent(msg)
+				case err := <-errs:
+					return err
+				}
+			}
+		}()
+		cancel()
+
+		logger.Warn("Container event stream closed: %v, reconnecting in %s", err, backoff)
+		time.Sleep(backoff)
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func syncStates(ctx context.Context) {
+	containers, err := docker.Client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("name", "birdactyl-")),
+	})
+	if err != nil {
+		logger.Warn("Failed to list containers for state sync: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, c := range containers {
+		if len(c.Names) == 0 {
+			continue
+		}
+		serverID, ok := serverIDFromContainer(c.Names[0])
+		if !ok {
+			continue
+		}
+		state := panel.ServerState{ServerID: serverID, State: StateStopped, Sync: true, Time: now}
+		if c.State == "running" || c.State == "restarting" {
+			state.State = StateRunning
+		}
+		queueState(state)
+	}
+}
+
+func handleContainerEvent(msg events.Message) {
+	serverID, ok := serverIDFromContainer(msg.Actor.Attributes["name"])
+	if !ok {
+		return
+	}
+	at := time.Unix(0, msg.TimeNano)
+
+	switch msg.Action {
+	case events.ActionStart:
+		consumeStop(serverID)
+		queueState(panel.ServerState{ServerID: serverID, State: StateRunning, Time: at})
+
+	case events.ActionOOM:
+		expectedStopsMu.Lock()
+		oomKilled[serverID] = true
+		expectedStopsMu.Unlock()
+
+	case events.ActionDie:
+		exitCode, _ := strconv.Atoi(msg.Actor.Attributes["exitCode"])
+		expected, oom := consumeStop(serverID)
+		if !oom && exitCode != 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if info, err := docker.Client.ContainerInspect(ctx, msg.Actor.ID); err == nil {
+				oom = info.State.OOMKilled
+			}
+			cancel()
+		}
+
+		state := panel.ServerState{ServerID: serverID, State: StateStopped, ExitCode: exitCode, OOM: oom, Time: at}
+		if !expected && (exitCode != 0 || oom) {
+			state.State = StateCrashed
+			state.Logs = crashLogs(serverID)
+			if oom {
+				BroadcastLog(serverID, "Server was killed after running out of memory")
+			} else {
+				BroadcastLog(serverID, fmt.Sprintf("Server crashed with exit code %d", exitCode))
+			}
+			logger.Warn("Server %s crashed (exit code %d, oom %v)", serverID, exitCode, oom)
+		}
+		queueState(state)
+
+	case events.ActionHealthStatusHealthy, events.ActionHealthStatusUnhealthy:
+		health := strings.TrimPrefix(string(msg.Action), string(events.ActionHealthStatus)+": ")
+		queueState(panel.ServerState{ServerID: serverID, State: StateRunning, Health: health, Time: at})
+	}
+}
+
+func crashLogs(serverID string) []string {
+	lines, err := GetLogLines(serverID, crashLogLines)
+	if err != nil {
+		return nil
+	}
+	for i, line := range lines {
+		lines[i] = sanitizeLogLine(line)
+	}
+	return lines
+}
+
+func queueState(state panel.ServerState) {
+	select {
+	case stateQueue <- state:
+	default:
+		logger.Warn("State report queue full, dropping %s event for server %s", state.State, state.ServerID)
+	}
+}
+
+func reportStates() {
+	client := panel.NewClient()
+	for state := range stateQueue {
+		var err error
+		for attempt := 1; attempt <= stateReportRetries; attempt++ {
+			if err = client.ReportServerState(state); err == nil || errors.Is(err, panel.ErrUnknownServer) {
+				break
+			}
+			time.Sleep(time.Duration(attempt) * 2 * time.Second)
+		}
+		if err != nil && !errors.Is(err, panel.ErrUnknownServer) {
+			logger.Warn("Failed to report %s state for server %s to panel: %v", state.State, state.ServerID, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -211,21 +212,39 @@ func CreateFolder(serverID, subPath string) error {
 func WriteFile(serverID, subPath string, content []byte) error {
 	fs := GetVFS(serverID)
 	target := filepath.Clean("/" + subPath)
-	return afero.WriteFile(fs, target, content, 0644)
+
+	f, err := openQuotaFile(serverID, fs, target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
 
 func WriteFileStream(serverID, subPath string, r io.Reader) error {
 	fs := GetVFS(serverID)
 	target := filepath.Clean("/" + subPath)
+	return writeQuotaStream(serverID, fs, target, r)
+}
 
-	f, err := fs.Create(target)
+func writeQuotaStream(serverID string, fs afero.Fs, target string, r io.Reader) error {
+	f, err := openQuotaFile(serverID, fs, target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	_, err = io.Copy(f, r)
-	return err
+	if _, err := io.Copy(f, r); err != nil {
+		if errors.Is(err, ErrDiskQuotaExceeded) {
+			f.discard(fs)
+		} else {
+			f.Close()
+		}
+		return err
+	}
+	return f.Close()
 }
 
 func SearchFiles(serverID, query string) ([]SearchResult, error) {
@@ -263,7 +282,7 @@ func DeletePath(serverID, subPath string) error {
 	}
 
 	if strings.HasPrefix(target, "/.trash") {
-		return fs.RemoveAll(target)
+		return removeTracked(serverID, fs, target)
 	}
 
 	trashDir := "/.trash"
@@ -276,11 +295,51 @@ func DeletePath(serverID, subPath string) error {
 
 	err := fs.Rename(target, trashTarget)
 	if err != nil {
-		return fs.RemoveAll(target)
+		return removeTracked(serverID, fs, target)
 	}
 	return nil
 }
 
+// removeTracked permanently deletes target and takes its size off the
+// tracked disk usage, so freed space is available before the next rescan.
+func removeTracked(serverID string, fs afero.Fs, target string) error {
+	var size int64
+	afero.Walk(fs, target, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err := fs.RemoveAll(target); err != nil {
+		return err
+	}
+	trackDiskUsage(serverID, -size)
+	return nil
+}
+
+// RemoveQuotaFile removes a single file or empty directory and takes its
+// size off the tracked disk usage.
+func RemoveQuotaFile(serverID string, fs afero.Fs, name string) error {
+	var size int64
+	info, _, err := lstatIfPossible(fs, name)
+	if err == nil && !info.IsDir() {
+		size = info.Size()
+	}
+	if err := fs.Remove(name); err != nil {
+		return err
+	}
+	trackDiskUsage(serverID, -size)
+	return nil
+}
+
+func lstatIfPossible(fs afero.Fs, name string) (os.FileInfo, bool, error) {
+	if lfs, ok := fs.(afero.Lstater); ok {
+		return lfs.LstatIfPossible(name)
+	}
+	info, err := fs.Stat(name)
+	return info, false, err
+}
+
 func MovePath(serverID, srcPath, destPath string) error {
 	fs := GetVFS(serverID)
 	src := filepath.Clean("/" + srcPath)
@@ -311,9 +370,9 @@ func CopyPath(serverID, srcPath, destPath string) error {
 	fs.MkdirAll(filepath.Dir(dest), 0755)
 
 	if info.IsDir() {
-		return copyDirVFS(fs, src, dest)
+		return copyDirVFS(serverID, fs, src, dest)
 	}
-	return copyFileVFS(fs, src, dest)
+	return copyFileVFS(serverID, fs, src, dest)
 }
 
 func GetFilePath(serverID, subPath string) (string, error) {
@@ -334,7 +393,7 @@ func BulkDelete(serverID string, paths []string) (int, error) {
 		}
 
 		if strings.HasPrefix(target, "/.trash") {
-			if err := fs.RemoveAll(target); err == nil {
+			if err := removeTracked(serverID, fs, target); err == nil {
 				deleted++
 			}
 			continue
@@ -348,7 +407,7 @@ func BulkDelete(serverID string, paths []string) (int, error) {
 		if err := fs.Rename(target, trashTarget); err == nil {
 			deleted++
 		} else {
-			if err := fs.RemoveAll(target); err == nil {
+			if err := removeTracked(serverID, fs, target); err == nil {
 				deleted++
 			}
 		}
@@ -375,11 +434,11 @@ func BulkCopy(serverID string, paths []string, destDir string) (int, error) {
 			continue
 		}
 		if info.IsDir() {
-			if copyDirVFS(fs, src, target) == nil {
+			if copyDirVFS(serverID, fs, src, target) == nil {
 				copied++
 			}
 		} else {
-			if copyFileVFS(fs, src, target) == nil {
+			if copyFileVFS(serverID, fs, src, target) == nil {
 				copied++
 			}
 		}
@@ -387,24 +446,17 @@ func BulkCopy(serverID string, paths []string, destDir string) (int, error) {
 	return copied, nil
 }
 
-func copyFileVFS(fs afero.Fs, src, dest string) error {
+func copyFileVFS(serverID string, fs afero.Fs, src, dest string) error {
 	in, err := fs.Open(src)
 	if err != nil {
 		return err
 	}
 	defer in.Close()
 
-	out, err := fs.Create(dest)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-
-	_, err = io.Copy(out, in)
-	return err
+	return writeQuotaStream(serverID, fs, dest, in)
 }
 
-func copyDirVFS(fs afero.Fs, src, dest string) error {
+func copyDirVFS(serverID string, fs afero.Fs, src, dest string) error {
 	fs.MkdirAll(dest, 0755)
 
 	entries, err := afero.ReadDir(fs, src)
@@ -415,11 +467,11 @@ func copyDirVFS(fs afero.Fs, src, dest string) error {
 		srcPath := filepath.Join(src, e.Name())
 		destPath := filepath.Join(dest, e.Name())
 		if e.IsDir() {
-			if err := copyDirVFS(fs, srcPath, destPath); err != nil {
+			if err := copyDirVFS(serverID, fs, srcPath, destPath); err != nil {
 				return err
 			}
 		} else {
-			if err := copyFileVFS(fs, srcPath, destPath); err != nil {
+			if err := copyFileVFS(serverID, fs, srcPath, destPath); err != nil {
 				return err
 			}
 		}
@@ -499,12 +551,11 @@ func DownloadURL(serverID, rawURL, destPath string) error {
 		return fmt.Errorf("download failed: status %d", resp.StatusCode)
 	}
 
-	f, err := fs.Create(target)
-	if err != nil {
-		return err
+	if resp.ContentLength > 0 {
+		if err := CheckDiskSpace(serverID, resp.ContentLength); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 
-	_, err = io.Copy(f, resp.Body)
-	return err
+	return writeQuotaStream(serverID, fs, target, resp.Body)
 }
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	defaultGrepMaxFileSize = 5 << 20
+	maxGrepMaxFileSize     = 100 << 20
+	defaultGrepLimit       = 500
+	maxGrepLimit           = 5000
+	grepMaxLineLength      = 1 << 20
+	grepMaxTextLength      = 500
+	grepBinarySniffSize    = 8000
+)
+
+var errGrepStopped = errors.New("grep stopped")
+
+type GrepOptions struct {
+	Pattern       string
+	Regex         bool
+	CaseSensitive bool
+	Path          string
+	Include       []string
+	MaxFileSize   int64
+	Limit         int
+}
+
+type GrepMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+type GrepSummary struct {
+	FilesScanned int  `json:"files_scanned"`
+	FilesSkipped int  `json:"files_skipped"`
+	Matches      int  `json:"matches"`
+	Truncated    bool `json:"truncated"`
+}
+
+// GrepFiles searches file contents under opts.Path and calls emit for every
+// matching line. Files larger than the size limit, files that look binary and
+// files with a line longer than 1 MiB are skipped. The walk stops once the result limit is reached or emit
+// returns an error.
+func GrepFiles(serverID string, opts GrepOptions, emit func(GrepMatch) error) (GrepSummary, error) {
+	var summary GrepSummary
+
+	match, err := grepMatcher(opts)
+	if err != nil {
+		return summary, err
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultGrepMaxFileSize
+	}
+	if opts.MaxFileSize > maxGrepMaxFileSize {
+		opts.MaxFileSize = maxGrepMaxFileSize
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultGrepLimit
+	}
+	if opts.Limit > maxGrepLimit {
+		opts.Limit = maxGrepLimit
+	}
+
+	fs := GetVFS(serverID)
+	root := filepath.Clean("/" + opts.Path)
+
+	err = afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path == "/.trash" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || !grepIncluded(path, opts.Include) {
+			return nil
+		}
+		if info.Size() > opts.MaxFileSize {
+			summary.FilesSkipped++
+			return nil
+		}
+
+		scanned, err := grepFile(fs, path, match, func(line int, text string) error {
+			if summary.Matches >= opts.Limit {
+				summary.Truncated = true
+				return errGrepStopped
+			}
+			summary.Matches++
+			if len(text) > grepMaxTextLength {
+				text = text[:grepMaxTextLength]
+			}
+			return emit(GrepMatch{Path: path, Line: line, Text: text})
+		})
+		if scanned {
+			summary.FilesScanned++
+		} else {
+			summary.FilesSkipped++
+		}
+		return err
+	})
+	if errors.Is(err, errGrepStopped) {
+		err = nil
+	}
+	return summary, err
+}
+
+func grepMatcher(opts GrepOptions) (func(string) bool, error) {
+	if opts.Pattern == "" {
+		return nil, errors.New("pattern required")
+	}
+	if opts.Regex {
+		pattern := opts.Pattern
+		if !opts.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+	if opts.CaseSensitive {
+		return func(line string) bool { return strings.Contains(line, opts.Pattern) }, nil
+	}
+	needle := strings.ToLower(opts.Pattern)
+	return func(line string) bool { return strings.Contains(strings.ToLower(line), needle) }, nil
+}
+
+// grepIncluded matches globs without a slash against the file name and
+// globs with one against the path relative to the server root.
+func grepIncluded(path string, include []string) bool {
+	if len(include) == 0 {
+		return true
+	}
+	rel := strings.TrimPrefix(path, "/")
+	for _, glob := range include {
+		glob = strings.TrimPrefix(strings.TrimSpace(glob), "/")
+		if glob == "" {
+			continue
+		}
+		target := filepath.Base(path)
+		if strings.Contains(glob, "/") {
+			target = rel
+		}
+		if ok, _ := filepath.Match(glob, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// grepFile reports whether the whole file was scanned. Binary files and files
+// that cannot be read to the end, such as ones with an overlong line, are not.
+func grepFile(fs afero.Fs, path string, match func(string) bool, found func(line int, text string) error) (bool, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	reader := bufio.NewReaderSize(f, grepBinarySniffSize)
+	head, _ := reader.Peek(grepBinarySniffSize)
+	if bytes.IndexByte(head, 0) >= 0 {
+		return false, nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), grepMaxLineLength)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if match(text) {
+			if err := found(line, strings.TrimRight(text, "\r")); err != nil {
+				return true, err
+			}
+		}
+	}
+	if scanner.Err() != nil {
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGrepFilesSkipsOverlongLines(t *testing.T) {
+	serverID, dataDir := setupQuotaServer(t)
+	writeTestFile(t, filepath.Join(dataDir, "ok.txt"), "needle\n")
+	writeTestFile(t, filepath.Join(dataDir, "long.txt"), "needle\n"+strings.Repeat("x", grepMaxLineLength+1)+"\nneedle\n")
+
+	var matches []GrepMatch
+	summary, err := GrepFiles(serverID, GrepOptions{Pattern: "needle"}, func(m GrepMatch) error {
+		matches = append(matches, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.FilesScanned != 1 || summary.FilesSkipped != 1 {
+		t.Errorf("expected the file with an overlong line to be skipped, got %+v", summary)
+	}
+	for _, m := range matches {
+		if m.Path == "/long.txt" && m.Line > 1 {
+			t.Errorf("expected no matches past the overlong line, got %+v", m)
+		}
+	}
+}
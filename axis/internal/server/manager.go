@@ -48,25 +48,36 @@ func ValidateServerID(id string) error {
 
 func init() {
 	serverUID = strconv.Itoa(os.Getuid())
-	go statsRefresher()
 }
 
-func statsRefresher() {
+func CollectStats() {
+	var sampling sync.Map
 	ticker := time.NewTicker(1 * time.Second)
-	for range ticker.C {
-		statsCacheMu.RLock()
-		serverIDs := make([]string, 0, len(statsCache))
-		for id := range statsCache {
-			serverIDs = append(serverIDs, id)
+	for now := range ticker.C {
+		go recordNodeSample(now)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		containers, err := runningServers(ctx)
+		cancel()
+		if err != nil {
+			continue
 		}
-		statsCacheMu.RUnlock()
 
-		for _, id := range serverIDs {
-			if stats, err := fetchStats(id); err == nil {
+		for serverID, containerID := range containers {
+			if _, busy := sampling.LoadOrStore(serverID, true); busy {
+				continue
+			}
+			go func(serverID, containerID string, at time.Time) {
+				defer sampling.Delete(serverID)
+				stats, err := fetchContainerStats(serverID, containerID)
+				if err != nil {
+					return
+				}
 				statsCacheMu.Lock()
-				statsCache[id] = &cachedStats{stats: stats, updatedAt: time.Now()}
+				statsCache[serverID] = &cachedStats{stats: stats, updatedAt: time.Now()}
 				statsCacheMu.Unlock()
-			}
+				recordServerSample(serverID, stats, at)
+			}(serverID, containerID, now)
 		}
 	}
 }
@@ -101,11 +112,19 @@ type MountConfig struct {
 }
 
 type PortConfig struct {
+	IP        string `json:"ip,omitempty"`
 	Host      int    `json:"host"`
 	Container int    `json:"container"`
 	Protocol  string `json:"protocol"`
 }
 
+func (p PortConfig) HostIP() string {
+	if p.IP == "" {
+		return "0.0.0.0"
+	}
+	return p.IP
+}
+
 type ServerStats struct {
 	MemoryUsage int64   `json:"memory_usage"`
 	MemoryLimit int64   `json:"memory_limit"`
@@ -179,7 +198,7 @@ func Create(cfg ServerConfig) error {
 		containerPort := nat.Port(fmt.Sprintf("%d/%s", p.Container, proto))
 		exposedPorts[containerPort] = struct{}{}
 		portBindings[containerPort] = []nat.PortBinding{{
-			HostIP:   "0.0.0.0",
+			HostIP:   p.HostIP(),
 			HostPort: strconv.Itoa(p.Host),
 		}}
 	}
@@ -197,6 +216,7 @@ func Create(cfg ServerConfig) error {
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
+		Labels:       map[string]string{diskLimitLabel: strconv.Itoa(cfg.Disk)},
 	}
 
 	dockerMounts := []mount.Mount{{
@@ -220,13 +240,14 @@ func Create(cfg ServerConfig) error {
 			Memory:   int64(cfg.Memory) * 1024 * 1024,
 			NanoCPUs: int64(cfg.CPU) * 10000000,
 		},
-		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		RestartPolicy: container.RestartPolicy{Name: "no"},
 	}
 
 	name := containerName(cfg.ID)
 
 	if docker.ContainerExists(ctx, name) {
 		if id, err := docker.GetContainerID(ctx, name); err == nil {
+			expectStop(cfg.ID)
 			docker.StopContainer(ctx, id, 10)
 			docker.RemoveContainer(ctx, id, true)
 		}
@@ -352,7 +373,7 @@ func CreateContainer(cfg ServerConfig) error {
 		containerPort := nat.Port(fmt.Sprintf("%d/%s", p.Container, proto))
 		exposedPorts[containerPort] = struct{}{}
 		portBindings[containerPort] = []nat.PortBinding{{
-			HostIP:   "0.0.0.0",
+			HostIP:   p.HostIP(),
 			HostPort: strconv.Itoa(p.Host),
 		}}
 	}
@@ -370,6 +391,7 @@ func CreateContainer(cfg ServerConfig) error {
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
+		Labels:       map[string]string{diskLimitLabel: strconv.Itoa(cfg.Disk)},
 	}
 
 	dockerMounts := []mount.Mount{{
@@ -393,7 +415,7 @@ func CreateContainer(cfg ServerConfig) error {
 			Memory:   int64(cfg.Memory) * 1024 * 1024,
 			NanoCPUs: int64(cfg.CPU) * 10000000,
 		},
-		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		RestartPolicy: container.RestartPolicy{Name: "no"},
 	}
 
 	name := containerName(cfg.ID)
@@ -407,6 +429,10 @@ func CreateContainer(cfg ServerConfig) error {
 }
 
 func Start(serverID string) error {
+	if usage, limit, over := overDiskLimit(serverID); over && config.Get().Node.DiskOverage == config.DiskOverageStop {
+		return fmt.Errorf("%w: using %d MiB of %d MiB, free up space before starting", ErrDiskQuotaExceeded, usage/1024/1024, limit/1024/1024)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -430,6 +456,7 @@ func StopWithConfig(serverID string, timeout int, stopCommand string, stopSignal
 		return err
 	}
 
+	expectStop(serverID)
 	docker.Client.ContainerUpdate(ctx, id, container.UpdateConfig{
 		RestartPolicy: container.RestartPolicy{Name: "no"},
 	})
@@ -477,6 +504,7 @@ func Kill(serverID string) error {
 	if err != nil {
 		return err
 	}
+	expectStop(serverID)
 	return docker.KillContainer(ctx, id)
 }
 
@@ -511,6 +539,7 @@ func RemoveContainer(serverID string) {
 	defer cancel()
 	name := containerName(serverID)
 	if id, err := docker.GetContainerID(ctx, name); err == nil {
+		expectStop(serverID)
 		docker.StopContainer(ctx, id, 5)
 		docker.RemoveContainer(ctx, id, true)
 	}
@@ -524,6 +553,7 @@ func Reinstall(cfg ServerConfig) error {
 	if docker.ContainerExists(ctx, name) {
 		if id, err := docker.GetContainerID(ctx, name); err == nil {
 			BroadcastLog(cfg.ID, "Stopping server for reinstall...")
+			expectStop(cfg.ID)
 			docker.StopContainer(ctx, id, 10)
 			docker.RemoveContainer(ctx, id, true)
 		}
@@ -569,7 +599,7 @@ func Reinstall(cfg ServerConfig) error {
 		containerPort := nat.Port(fmt.Sprintf("%d/%s", p.Container, proto))
 		exposedPorts[containerPort] = struct{}{}
 		portBindings[containerPort] = []nat.PortBinding{{
-			HostIP:   "0.0.0.0",
+			HostIP:   p.HostIP(),
 			HostPort: strconv.Itoa(p.Host),
 		}}
 	}
@@ -587,6 +617,7 @@ func Reinstall(cfg ServerConfig) error {
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
+		Labels:       map[string]string{diskLimitLabel: strconv.Itoa(cfg.Disk)},
 	}
 
 	dockerMounts := []mount.Mount{{
@@ -610,7 +641,7 @@ func Reinstall(cfg ServerConfig) error {
 			Memory:   int64(cfg.Memory) * 1024 * 1024,
 			NanoCPUs: int64(cfg.CPU) * 10000000,
 		},
-		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		RestartPolicy: container.RestartPolicy{Name: "no"},
 	}
 
 	_, err := docker.CreateContainer(ctx, name, containerCfg, hostCfg)
@@ -618,6 +649,10 @@ func Reinstall(cfg ServerConfig) error {
 		BroadcastLog(cfg.ID, fmt.Sprintf("Failed to create container: %v", err))
 		return err
 	}
+	serverConfigsMu.Lock()
+	serverConfigs[cfg.ID] = &cfg
+	serverConfigsMu.Unlock()
+	resetDiskUsage(cfg.ID)
 
 	BroadcastLog(cfg.ID, "Reinstallation complete")
 	return nil
@@ -631,14 +666,19 @@ func Delete(serverID string) error {
 	if docker.ContainerExists(ctx, name) {
 		id, err := docker.GetContainerID(ctx, name)
 		if err == nil {
+			expectStop(serverID)
 			docker.KillContainer(ctx, id)
 			docker.RemoveContainer(ctx, id, true)
 		}
 	}
 
+	resetDiskUsage(serverID)
+	removeServerMetrics(serverID)
+
 	go func() {
 		dataDir := serverDataDir(serverID)
 		os.RemoveAll(dataDir)
+		os.RemoveAll(uploadDir(serverID))
 	}()
 
 	return nil
@@ -689,12 +729,17 @@ func GetStats(serverID string) (*ServerStats, error) {
 
 func fetchStats(serverID string) (*ServerStats, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
 	id, err := docker.GetContainerID(ctx, containerName(serverID))
+	cancel()
 	if err != nil {
 		return nil, err
 	}
+	return fetchContainerStats(serverID, id)
+}
+
+func fetchContainerStats(serverID, id string) (*ServerStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	stats, err := docker.GetContainerStats(ctx, id)
 	if err != nil {
@@ -729,13 +774,11 @@ func fetchStats(serverID string) (*ServerStats, error) {
 		netTx += int64(net.TxBytes)
 	}
 
-	diskUsage := getDirSize(serverDataDir(serverID))
-
 	return &ServerStats{
 		MemoryUsage: int64(stats.MemoryStats.Usage),
 		MemoryLimit: int64(stats.MemoryStats.Limit),
 		CPUPercent:  cpuPercent,
-		DiskUsage:   diskUsage,
+		DiskUsage:   GetDiskUsage(serverID),
 		NetRx:       netRx,
 		NetTx:       netTx,
 	}, nil
@@ -770,10 +813,6 @@ func chownRecursive(path string) {
 	})
 }
 
-func GetDiskUsage(serverID string) int64 {
-	return getDirSize(serverDataDir(serverID))
-}
-
 
 func IsDataDirEmpty(serverID string) bool {
 	dataDir := serverDataDir(serverID)
@@ -0,0 +1,310 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/docker"
+	"cauthon-axis/internal/system"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+type MetricSample struct {
+	Time        int64   `json:"time"`
+	CPUPercent  float64 `json:"cpu_percent"`
+	MemoryUsage int64   `json:"memory_usage"`
+	MemoryLimit int64   `json:"memory_limit"`
+	DiskUsage   int64   `json:"disk_usage"`
+	DiskLimit   int64   `json:"disk_limit"`
+	NetRx       int64   `json:"net_rx"`
+	NetTx       int64   `json:"net_tx"`
+}
+
+type MetricRange struct {
+	Range      int64          `json:"range"`
+	Resolution int64          `json:"resolution"`
+	Samples    []MetricSample `json:"samples"`
+}
+
+type metricResolution struct {
+	step time.Duration
+	size int
+}
+
+var metricResolutions = []metricResolution{
+	{step: time.Second, size: 900},
+	{step: time.Minute, size: 1440},
+	{step: time.Hour, size: 720},
+}
+
+const MaxMetricRange = 30 * 24 * time.Hour
+
+// metricRing keeps fixed-size history for one resolution. Samples are averaged
+// into the current bucket and only written to the ring once the bucket closes,
+// except for network counters and disk usage which keep their latest value.
+type metricRing struct {
+	step    time.Duration
+	samples []MetricSample
+	next    int
+	count   int
+
+	bucket  int64
+	pending MetricSample
+	cpuSum  float64
+	memSum  int64
+	n       int64
+}
+
+func newMetricRing(res metricResolution) *metricRing {
+	return &metricRing{step: res.step, samples: make([]MetricSample, res.size)}
+}
+
+func (r *metricRing) add(s MetricSample) {
+	bucket := time.Unix(s.Time, 0).Truncate(r.step).Unix()
+	if r.n > 0 && bucket != r.bucket {
+		r.push(r.current())
+		r.n = 0
+	}
+	if r.n == 0 {
+		r.bucket = bucket
+		r.cpuSum, r.memSum = 0, 0
+	}
+	r.cpuSum += s.CPUPercent
+	r.memSum += s.MemoryUsage
+	r.n++
+	r.pending = s
+}
+
+func (r *metricRing) current() MetricSample {
+	s := r.pending
+	s.Time = r.bucket
+	s.CPUPercent = r.cpuSum / float64(r.n)
+	s.MemoryUsage = r.memSum / r.n
+	return s
+}
+
+func (r *metricRing) push(s MetricSample) {
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.count < len(r.samples) {
+		r.count++
+	}
+}
+
+func (r *metricRing) since(from int64) []MetricSample {
+	out := make([]MetricSample, 0, r.count+1)
+	start := (r.next - r.count + len(r.samples)) % len(r.samples)
+	for i := 0; i < r.count; i++ {
+		if s := r.samples[(start+i)%len(r.samples)]; s.Time >= from {
+			out = append(out, s)
+		}
+	}
+	if r.n > 0 {
+		out = append(out, r.current())
+	}
+	return out
+}
+
+type metricSeries struct {
+	rings []*metricRing
+}
+
+func newMetricSeries() *metricSeries {
+	series := &metricSeries{}
+	for _, res := range metricResolutions {
+		series.rings = append(series.rings, newMetricRing(res))
+	}
+	return series
+}
+
+func (m *metricSeries) add(s MetricSample) {
+	for _, r := range m.rings {
+		r.add(s)
+	}
+}
+
+func (m *metricSeries) query(window time.Duration) MetricRange {
+	ring := m.rings[len(m.rings)-1]
+	for _, r := range m.rings {
+		if r.step*time.Duration(len(r.samples)) >= window {
+			ring = r
+			break
+		}
+	}
+	return MetricRange{
+		Range:      int64(window / time.Second),
+		Resolution: int64(ring.step / time.Second),
+		Samples:    ring.since(time.Now().Add(-window).Unix()),
+	}
+}
+
+var (
+	serverMetrics = make(map[string]*metricSeries)
+	nodeMetrics   = newMetricSeries()
+	metricsMu     sync.Mutex
+)
+
+func ParseMetricRange(value string) (time.Duration, error) {
+	if value == "" {
+		return time.Hour, nil
+	}
+	var window time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid range")
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid range")
+		}
+		window = d
+	}
+	if window < time.Minute || window > MaxMetricRange {
+		return 0, fmt.Errorf("range must be between 1m and 30d")
+	}
+	return window, nil
+}
+
+func GetServerMetrics(serverID string, window time.Duration) MetricRange {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	series, ok := serverMetrics[serverID]
+	if !ok {
+		series = newMetricSeries()
+	}
+	return series.query(window)
+}
+
+func GetNodeMetrics(window time.Duration) MetricRange {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	return nodeMetrics.query(window)
+}
+
+func recordServerSample(serverID string, stats *ServerStats, at time.Time) {
+	sample := MetricSample{
+		Time:        at.Unix(),
+		CPUPercent:  stats.CPUPercent,
+		MemoryUsage: stats.MemoryUsage,
+		MemoryLimit: stats.MemoryLimit,
+		DiskUsage:   stats.DiskUsage,
+		NetRx:       stats.NetRx,
+		NetTx:       stats.NetTx,
+	}
+	serverConfigsMu.RLock()
+	if cfg := serverConfigs[serverID]; cfg != nil {
+		sample.DiskLimit = int64(cfg.Disk) * 1024 * 1024
+	}
+	serverConfigsMu.RUnlock()
+
+	metricsMu.Lock()
+	series, ok := serverMetrics[serverID]
+	if !ok {
+		series = newMetricSeries()
+		serverMetrics[serverID] = series
+	}
+	series.add(sample)
+	metricsMu.Unlock()
+}
+
+func recordNodeSample(at time.Time) {
+	info := system.GetInfo()
+	rx, tx := system.GetNetworkIO()
+	sample := MetricSample{
+		Time:        at.Unix(),
+		CPUPercent:  info.CPU.Usage,
+		MemoryUsage: int64(info.Memory.Used),
+		MemoryLimit: int64(info.Memory.Total),
+		DiskUsage:   int64(info.Disk.Used),
+		DiskLimit:   int64(info.Disk.Total),
+		NetRx:       int64(rx),
+		NetTx:       int64(tx),
+	}
+	metricsMu.Lock()
+	nodeMetrics.add(sample)
+	metricsMu.Unlock()
+}
+
+func removeServerMetrics(serverID string) {
+	metricsMu.Lock()
+	delete(serverMetrics, serverID)
+	metricsMu.Unlock()
+}
+
+func runningServers(ctx context.Context) (map[string]string, error) {
+	containers, err := docker.Client.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("name", "birdactyl-"), filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(containers))
+	for _, c := range containers {
+		if len(c.Names) == 0 {
+			continue
+		}
+		if serverID, ok := serverIDFromContainer(c.Names[0]); ok {
+			ids[serverID] = c.ID
+		}
+	}
+	return ids, nil
+}
+
+type ServerSnapshot struct {
+	ServerID  string
+	Running   bool
+	Stats     *ServerStats
+	DiskUsage int64
+	DiskLimit int64
+}
+
+// SnapshotServers reports every server container with the stats last gathered
+// by CollectStats, so scrapes never wait on the container engine for stats.
+func SnapshotServers(ctx context.Context) ([]ServerSnapshot, error) {
+	containers, err := docker.Client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("name", "birdactyl-")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []ServerSnapshot
+	for _, c := range containers {
+		if len(c.Names) == 0 {
+			continue
+		}
+		serverID, ok := serverIDFromContainer(c.Names[0])
+		if !ok {
+			continue
+		}
+		snap := ServerSnapshot{
+			ServerID:  serverID,
+			Running:   c.State == "running" || c.State == "restarting",
+			DiskUsage: GetDiskUsage(serverID),
+		}
+		if snap.Running {
+			statsCacheMu.RLock()
+			if cached := statsCache[serverID]; cached != nil && cached.stats != nil && time.Since(cached.updatedAt) < 10*time.Second {
+				snap.Stats = cached.stats
+			}
+			statsCacheMu.RUnlock()
+		}
+		serverConfigsMu.RLock()
+		if cfg := serverConfigs[serverID]; cfg != nil {
+			snap.DiskLimit = int64(cfg.Disk) * 1024 * 1024
+		}
+		serverConfigsMu.RUnlock()
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, nil
+}
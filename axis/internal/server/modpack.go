@@ -163,12 +163,12 @@ func installModrinthModpack(serverID, packPath, tempDir string) (*ModpackInstall
 
 	overridesDir := filepath.Join(tempDir, "overrides")
 	if info, err := vfs.Stat(overridesDir); err == nil && info.IsDir() {
-		copyDirVFS(vfs, overridesDir, "/")
+		copyDirVFS(serverID, vfs, overridesDir, "/")
 	}
 
 	serverOverridesDir := filepath.Join(tempDir, "server-overrides")
 	if info, err := vfs.Stat(serverOverridesDir); err == nil && info.IsDir() {
-		copyDirVFS(vfs, serverOverridesDir, "/")
+		copyDirVFS(serverID, vfs, serverOverridesDir, "/")
 	}
 
 	return result, nil
@@ -231,7 +231,7 @@ func installCurseForgeModpack(serverID, packPath, tempDir, apiKey string) (*Modp
 	}
 	overridesDir := filepath.Join(tempDir, overridesName)
 	if info, err := vfs.Stat(overridesDir); err == nil && info.IsDir() {
-		copyDirVFS(vfs, overridesDir, "/")
+		copyDirVFS(serverID, vfs, overridesDir, "/")
 	}
 
 	return result, nil
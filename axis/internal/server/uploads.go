@@ -0,0 +1,326 @@
+package server
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/config"
+)
+
+const (
+	MaxUploadChunkSize  = 64 << 20
+	uploadExpiry        = 24 * time.Hour
+	uploadPruneInterval = time.Hour
+)
+
+var (
+	ErrUploadInvalid    = errors.New("invalid upload")
+	ErrUploadNotFound   = errors.New("upload not found")
+	ErrUploadOffset     = errors.New("upload offset mismatch")
+	ErrUploadOverflow   = errors.New("chunk exceeds upload length")
+	ErrUploadChunkSize  = errors.New("chunk too large")
+	ErrUploadIncomplete = errors.New("upload incomplete")
+	ErrUploadChecksum   = errors.New("checksum mismatch")
+)
+
+var (
+	uploadIDRegex = regexp.MustCompile(`^[a-f0-9]{32}$`)
+	uploadLocks   sync.Map
+)
+
+// Upload is a resumable upload staged under upload_dir. Partial data lives
+// outside the server directory but counts toward the server's disk quota
+// until the upload is completed or removed.
+type Upload struct {
+	ID        string    `json:"id"`
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	Offset    int64     `json:"offset"`
+	Checksum  string    `json:"checksum,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func uploadDir(serverID string) string {
+	return filepath.Join(config.Get().Node.UploadDir, serverID)
+}
+
+func uploadFiles(serverID, uploadID string) (meta, data string) {
+	base := filepath.Join(uploadDir(serverID), uploadID)
+	return base + ".json", base + ".part"
+}
+
+// stagedUploadBytes is the data held by the server's unfinished uploads.
+func stagedUploadBytes(serverID string) int64 {
+	entries, err := os.ReadDir(uploadDir(serverID))
+	if err != nil {
+		return 0
+	}
+	var size int64
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".part") {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+func lockUpload(uploadID string) func() {
+	mu, _ := uploadLocks.LoadOrStore(uploadID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// CreateUpload reserves a resumable upload of size bytes that will be
+// written to path once completed. checksum is an optional hex SHA-256 of the
+// whole file, verified on completion.
+func CreateUpload(serverID, path string, size int64, checksum string) (*Upload, error) {
+	if err := ValidateServerID(serverID); err != nil {
+		return nil, err
+	}
+	path = filepath.Clean("/" + path)
+	if path == "/" {
+		return nil, fmt.Errorf("%w: path required", ErrUploadInvalid)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("%w: size must not be negative", ErrUploadInvalid)
+	}
+	checksum = strings.ToLower(checksum)
+	if checksum != "" {
+		if b, err := hex.DecodeString(checksum); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("%w: checksum must be a hex encoded sha256", ErrUploadInvalid)
+		}
+	}
+	if err := CheckDiskSpace(serverID, size); err != nil {
+		return nil, err
+	}
+
+	pruneUploads(serverID)
+
+	dir := uploadDir(serverID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 16)
+	rand.Read(b)
+	upload := &Upload{
+		ID:        hex.EncodeToString(b),
+		Path:      path,
+		Size:      size,
+		Checksum:  checksum,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	metaPath, dataPath := uploadFiles(serverID, upload.ID)
+	if err := os.WriteFile(dataPath, nil, 0644); err != nil {
+		return nil, err
+	}
+	meta, _ := json.Marshal(upload)
+	if err := os.WriteFile(metaPath, meta, 0644); err != nil {
+		os.Remove(dataPath)
+		return nil, err
+	}
+	upload.ExpiresAt = upload.CreatedAt.Add(uploadExpiry)
+	return upload, nil
+}
+
+// GetUpload returns the upload with its current offset. The offset is the
+// size of the staged data, so it survives axis restarts.
+func GetUpload(serverID, uploadID string) (*Upload, error) {
+	if ValidateServerID(serverID) != nil || !uploadIDRegex.MatchString(uploadID) {
+		return nil, ErrUploadNotFound
+	}
+	metaPath, dataPath := uploadFiles(serverID, uploadID)
+
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, ErrUploadNotFound
+	}
+	var upload Upload
+	if err := json.Unmarshal(data, &upload); err != nil {
+		return nil, ErrUploadNotFound
+	}
+	info, err := os.Stat(dataPath)
+	if err != nil {
+		return nil, ErrUploadNotFound
+	}
+	upload.Offset = info.Size()
+	upload.ExpiresAt = info.ModTime().UTC().Add(uploadExpiry)
+	return &upload, nil
+}
+
+// WriteUploadChunk appends r at offset, which must equal the current upload
+// offset. r is read directly into staging and may hold at most
+// MaxUploadChunkSize bytes. When sum is set the chunk is checked against it
+// and discarded on mismatch. It returns the new offset.
+func WriteUploadChunk(serverID, uploadID string, offset int64, r io.Reader, sum []byte) (int64, error) {
+	unlock := lockUpload(uploadID)
+	defer unlock()
+
+	upload, err := GetUpload(serverID, uploadID)
+	if err != nil {
+		return 0, err
+	}
+	if offset != upload.Offset {
+		return upload.Offset, ErrUploadOffset
+	}
+
+	_, dataPath := uploadFiles(serverID, uploadID)
+	f, err := os.OpenFile(dataPath, os.O_WRONLY, 0644)
+	if err != nil {
+		return offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	hash := sha256.New()
+	limit, tooLarge := upload.Size-offset, ErrUploadOverflow
+	if limit > MaxUploadChunkSize {
+		limit, tooLarge = MaxUploadChunkSize, ErrUploadChunkSize
+	}
+	if free, quota, ok := diskHeadroom(serverID); ok && free < limit {
+		limit, tooLarge = max(free, 0), fmt.Errorf("%w: limit is %d MiB", ErrDiskQuotaExceeded, quota/1024/1024)
+	}
+	n, err := io.Copy(io.MultiWriter(f, hash), io.LimitReader(r, limit+1))
+	if err == nil && n > limit {
+		err = tooLarge
+	}
+	if err == nil && sum != nil && !bytes.Equal(hash.Sum(nil), sum) {
+		err = ErrUploadChecksum
+	}
+	if err != nil {
+		f.Truncate(offset)
+		return offset, err
+	}
+	return offset + n, nil
+}
+
+// CompleteUpload verifies the staged data and moves it into the server
+// directory through the quota tracked writer. The staged file is set aside
+// first so its bytes are not counted twice while they are copied.
+func CompleteUpload(serverID, uploadID string) (*Upload, error) {
+	unlock := lockUpload(uploadID)
+	defer unlock()
+	defer uploadLocks.Delete(uploadID)
+
+	upload, err := GetUpload(serverID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Offset != upload.Size {
+		return upload, ErrUploadIncomplete
+	}
+
+	metaPath, dataPath := uploadFiles(serverID, uploadID)
+	completing := strings.TrimSuffix(dataPath, ".part") + ".completing"
+	if err := os.Rename(dataPath, completing); err != nil {
+		return upload, err
+	}
+	f, err := os.Open(completing)
+	if err != nil {
+		os.Rename(completing, dataPath)
+		return upload, err
+	}
+	defer f.Close()
+	done := false
+	defer func() {
+		if !done {
+			os.Rename(completing, dataPath)
+		}
+	}()
+
+	if upload.Checksum != "" {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			return upload, err
+		}
+		if hex.EncodeToString(hash.Sum(nil)) != upload.Checksum {
+			return upload, ErrUploadChecksum
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return upload, err
+		}
+	}
+
+	if err := WriteFileStream(serverID, upload.Path, f); err != nil {
+		return upload, err
+	}
+	done = true
+	os.Remove(completing)
+	os.Remove(metaPath)
+	return upload, nil
+}
+
+func CancelUpload(serverID, uploadID string) error {
+	unlock := lockUpload(uploadID)
+	defer unlock()
+	defer uploadLocks.Delete(uploadID)
+
+	if _, err := GetUpload(serverID, uploadID); err != nil {
+		return err
+	}
+	metaPath, dataPath := uploadFiles(serverID, uploadID)
+	os.Remove(dataPath)
+	os.Remove(metaPath)
+	return nil
+}
+
+// pruneUploads removes uploads that have not received data within the
+// expiry window, along with data left behind by an interrupted completion.
+func pruneUploads(serverID string) {
+	dir := uploadDir(serverID)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if ext != ".part" && ext != ".completing" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || time.Since(info.ModTime()) < uploadExpiry {
+			continue
+		}
+		uploadID := strings.TrimSuffix(e.Name(), ext)
+		os.Remove(filepath.Join(dir, e.Name()))
+		os.Remove(filepath.Join(dir, uploadID+".json"))
+	}
+}
+
+// PruneUploads periodically removes expired uploads for every server, so
+// abandoned data does not hold on to disk quota until the next upload.
+func PruneUploads() {
+	ticker := time.NewTicker(uploadPruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		entries, err := os.ReadDir(config.Get().Node.UploadDir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() && ValidateServerID(e.Name()) == nil {
+				pruneUploads(e.Name())
+			}
+		}
+	}
+}
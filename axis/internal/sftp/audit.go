@@ -0,0 +1,111 @@
+package sftp
+
+import (
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/logger"
+	"cauthon-axis/internal/panel"
+)
+
+const (
+	auditFlushInterval = 5 * time.Second
+	auditBatchSize     = 100
+	// auditBufferLimit caps how many events are held while the panel is
+	// unreachable. The oldest events are dropped first.
+	auditBufferLimit = 5000
+)
+
+type auditLog struct {
+	mu     sync.Mutex
+	events []panel.SFTPEvent
+	wake   chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+var audit = &auditLog{wake: make(chan struct{}, 1)}
+
+func (a *auditLog) start() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stop != nil {
+		return
+	}
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	go a.run(a.stop, a.done)
+}
+
+func (a *auditLog) shutdown() {
+	a.mu.Lock()
+	stop, done := a.stop, a.done
+	a.stop, a.done = nil, nil
+	a.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (a *auditLog) record(ev panel.SFTPEvent) {
+	ev.Time = time.Now()
+
+	a.mu.Lock()
+	a.events = append(a.events, ev)
+	if len(a.events) > auditBufferLimit {
+		a.events = a.events[len(a.events)-auditBufferLimit:]
+	}
+	full := len(a.events) >= auditBatchSize
+	a.mu.Unlock()
+
+	if full {
+		select {
+		case a.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (a *auditLog) run(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.wake:
+		case <-stop:
+			a.flush()
+			return
+		}
+		a.flush()
+	}
+}
+
+func (a *auditLog) flush() {
+	a.mu.Lock()
+	events := a.events
+	a.events = nil
+	a.mu.Unlock()
+
+	for len(events) > 0 {
+		n := len(events)
+		if n > auditBatchSize {
+			n = auditBatchSize
+		}
+		if err := panel.NewClient().ReportSFTPActivity(events[:n]); err != nil {
+			logger.Warn("Failed to report SFTP activity: %v", err)
+			a.mu.Lock()
+			a.events = append(events, a.events...)
+			if len(a.events) > auditBufferLimit {
+				a.events = a.events[len(a.events)-auditBufferLimit:]
+			}
+			a.mu.Unlock()
+			return
+		}
+		events = events[n:]
+	}
+}
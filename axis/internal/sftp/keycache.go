@@ -0,0 +1,53 @@
+package sftp
+
+import (
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/panel"
+)
+
+// keyCacheTTL bounds how long a revoked key or permission change can go
+// unnoticed. Clients usually probe a key before signing with it, and often
+// reconnect in quick succession, so even a short window saves panel round trips.
+const keyCacheTTL = 30 * time.Second
+
+type cachedAccess struct {
+	access  *panel.SFTPAccess
+	expires time.Time
+}
+
+type accessCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedAccess
+}
+
+var keyCache = &accessCache{entries: make(map[string]cachedAccess)}
+
+func (c *accessCache) get(user, fingerprint string) *panel.SFTPAccess {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[user+"|"+fingerprint]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, user+"|"+fingerprint)
+		return nil
+	}
+	return entry.access
+}
+
+func (c *accessCache) put(user, fingerprint string, access *panel.SFTPAccess) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[user+"|"+fingerprint] = cachedAccess{access: access, expires: now.Add(keyCacheTTL)}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io"
@@ -38,7 +39,8 @@ func Start(port int) error {
 	}
 
 	sshConfig := &ssh.ServerConfig{
-		PasswordCallback: authenticateUser,
+		PasswordCallback:  authenticateUser,
+		PublicKeyCallback: authenticateKey,
 	}
 	sshConfig.AddHostKey(hostKey)
 
@@ -49,6 +51,7 @@ func Start(port int) error {
 	}
 
 	sftpListener = listener
+	audit.start()
 	logger.Success("SFTP server listening on %s", addr)
 
 	go acceptConnections(listener, sshConfig)
@@ -63,6 +66,7 @@ func Stop() {
 	if sftpListener != nil {
 		sftpListener.Close()
 		sftpListener = nil
+		audit.shutdown()
 	}
 }
 
@@ -104,7 +108,7 @@ func handleConnection(conn net.Conn, sshConfig *ssh.ServerConfig) {
 		}
 
 		go handleRequests(requests)
-		go handleSFTP(channel, sshConn.User())
+		go handleSFTP(channel, sshConn)
 	}
 }
 
@@ -121,16 +125,30 @@ func handleRequests(requests <-chan *ssh.Request) {
 	}
 }
 
-func handleSFTP(channel ssh.Channel, username string) {
+func handleSFTP(channel ssh.Channel, conn *ssh.ServerConn) {
 	defer channel.Close()
 
-	parts := strings.Split(username, ".")
-	if len(parts) < 2 {
+	perms := conn.Permissions
+	if perms == nil || perms.Extensions["server_id"] == "" {
 		return
 	}
-	serverID := parts[len(parts)-1]
+	serverID := perms.Extensions["server_id"]
 
-	handlers := newVFSHandlers(serverID)
+	var permissions []string
+	json.Unmarshal([]byte(perms.Extensions["permissions"]), &permissions)
+
+	remoteIP := conn.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
+	}
+
+	handlers := newVFSHandlers(&vfsHandler{
+		serverID:    serverID,
+		userID:      perms.Extensions["user_id"],
+		permissions: permissions,
+		remoteIP:    remoteIP,
+		client:      string(conn.ClientVersion()),
+	})
 	sftpServer := sftp.NewRequestServer(channel, handlers)
 
 	if err := sftpServer.Serve(); err != nil && err != io.EOF {
@@ -138,25 +156,65 @@ func handleSFTP(channel ssh.Channel, username string) {
 	}
 }
 
+func splitUsername(user string) (string, string, error) {
+	idx := strings.LastIndex(user, ".")
+	if idx <= 0 || idx == len(user)-1 {
+		return "", "", fmt.Errorf("invalid username format")
+	}
+	return user[:idx], user[idx+1:], nil
+}
+
 func authenticateUser(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
-	username := conn.User()
+	username, serverID, err := splitUsername(conn.User())
+	if err != nil {
+		return nil, err
+	}
 
-	parts := strings.Split(username, ".")
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("invalid username format")
+	remoteIP := conn.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(remoteIP); err == nil {
+		remoteIP = host
 	}
-	serverID := parts[len(parts)-1]
 
 	client := panel.NewClient()
-	if err := client.ValidateSFTPCredentials(serverID, string(password)); err != nil {
+	access, err := client.ValidateSFTPCredentials(serverID, username, string(password), remoteIP)
+	if err != nil {
 		return nil, fmt.Errorf("authentication failed")
 	}
 
+	return sessionPermissions(serverID, access), nil
+}
+
+func authenticateKey(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	username, serverID, err := splitUsername(conn.User())
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+	if access := keyCache.get(conn.User(), fingerprint); access != nil {
+		return sessionPermissions(serverID, access), nil
+	}
+
+	client := panel.NewClient()
+	access, err := client.ValidateSFTPKey(serverID, username, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("authentication failed")
+	}
+	keyCache.put(conn.User(), fingerprint, access)
+
+	return sessionPermissions(serverID, access), nil
+}
+
+func sessionPermissions(serverID string, access *panel.SFTPAccess) *ssh.Permissions {
+	permissions, _ := json.Marshal(access.Permissions)
 	return &ssh.Permissions{
 		Extensions: map[string]string{
-			"server_id": serverID,
+			"server_id":   serverID,
+			"user_id":     access.UserID,
+			"username":    access.Username,
+			"permissions": string(permissions),
 		},
-	}, nil
+	}
 }
 
 func loadOrGenerateHostKey() (ssh.Signer, error) {
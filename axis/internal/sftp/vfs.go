@@ -1,16 +1,64 @@
 package sftp
 
 import (
+	"cauthon-axis/internal/panel"
 	"cauthon-axis/internal/server"
 	"io"
 	"os"
+	"sync/atomic"
 
 	"github.com/pkg/sftp"
 	"github.com/spf13/afero"
 )
 
 type vfsHandler struct {
-	serverID string
+	serverID    string
+	userID      string
+	permissions []string
+	remoteIP    string
+	client      string
+}
+
+func (h *vfsHandler) audit(action, path, target string, bytes int64) {
+	audit.record(panel.SFTPEvent{
+		ServerID: h.serverID,
+		UserID:   h.userID,
+		Action:   action,
+		Path:     path,
+		Target:   target,
+		Bytes:    bytes,
+		IP:       h.remoteIP,
+		Client:   h.client,
+	})
+}
+
+// auditedFile reports a single write event with the total bytes written
+// once the client closes its handle.
+type auditedFile struct {
+	afero.File
+	written atomic.Int64
+	closed  func(written int64)
+}
+
+func (f *auditedFile) WriteAt(p []byte, off int64) (int, error) {
+	n, err := f.File.WriteAt(p, off)
+	f.written.Add(int64(n))
+	return n, err
+}
+
+func (f *auditedFile) Close() error {
+	err := f.File.Close()
+	f.closed(f.written.Load())
+	return err
+}
+
+func (h *vfsHandler) can(permission string) bool {
+	for _, p := range h.permissions {
+		if p == "*" || p == permission {
+			return true
+		}
+	}
+	return false
 }
 
 type listerat []os.FileInfo
@@ -27,6 +75,9 @@ func (f listerat) ListAt(ls []os.FileInfo, offset int64) (int, error) {
 }
 
 func (h *vfsHandler) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	if !h.can("file.list") {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
 	fs := server.GetVFS(h.serverID)
 	switch r.Method {
 	case "List":
@@ -55,15 +106,37 @@ func (h *vfsHandler) Filecmd(r *sftp.Request) error {
 	fs := server.GetVFS(h.serverID)
 	switch r.Method {
 	case "Setstat":
+		if !h.can("file.write") {
+			return sftp.ErrSSHFxPermissionDenied
+		}
 		return nil
 	case "Rename":
-		return fs.Rename(r.Filepath, r.Target)
-	case "Rmdir":
-		return fs.Remove(r.Filepath)
-	case "Remove":
-		return fs.Remove(r.Filepath)
+		if !h.can("file.move") {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		if err := fs.Rename(r.Filepath, r.Target); err != nil {
+			return err
+		}
+		h.audit("rename", r.Filepath, r.Target, 0)
+		return nil
+	case "Rmdir", "Remove":
+		if !h.can("file.delete") {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		if err := server.RemoveQuotaFile(h.serverID, fs, r.Filepath); err != nil {
+			return err
+		}
+		h.audit("delete", r.Filepath, "", 0)
+		return nil
 	case "Mkdir":
-		return fs.MkdirAll(r.Filepath, 0755)
+		if !h.can("file.create") {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		if err := fs.MkdirAll(r.Filepath, 0755); err != nil {
+			return err
+		}
+		h.audit("create_folder", r.Filepath, "", 0)
+		return nil
 	case "Symlink":
 		return sftp.ErrSSHFxOpUnsupported
 	}
@@ -71,6 +144,9 @@ func (h *vfsHandler) Filecmd(r *sftp.Request) error {
 }
 
 func (h *vfsHandler) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	if !h.can("file.read") {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
 	fs := server.GetVFS(h.serverID)
 	f, err := fs.Open(r.Filepath)
 	if err != nil {
@@ -101,15 +177,25 @@ func (h *vfsHandler) Filewrite(r *sftp.Request) (io.WriterAt, error) {
 		flags |= os.O_WRONLY
 	}
 
-	f, err := fs.OpenFile(r.Filepath, flags, 0644)
+	required := "file.create"
+	if _, err := fs.Stat(r.Filepath); err == nil {
+		required = "file.write"
+	}
+	if !h.can(required) {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	f, err := server.OpenQuotaFile(h.serverID, fs, r.Filepath, flags, 0644)
 	if err != nil {
 		return nil, err
 	}
-	return f.(io.WriterAt), nil
+	path := r.Filepath
+	return &auditedFile{File: f, closed: func(written int64) {
+		h.audit("write", path, "", written)
+	}}, nil
 }
 
-func newVFSHandlers(serverID string) sftp.Handlers {
-	h := &vfsHandler{serverID: serverID}
+func newVFSHandlers(h *vfsHandler) sftp.Handlers {
 	return sftp.Handlers{
 		FileGet:  h,
 		FilePut:  h,
@@ -184,6 +184,35 @@ func getCPUSample() (idle, total uint64) {
 	return
 }
 
+func GetNetworkIO() (rx, tx uint64) {
+	file, err := os.Open("/proc/net/dev")
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		iface, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		iface = strings.TrimSpace(iface)
+		if iface == "lo" || strings.HasPrefix(iface, "veth") || strings.HasPrefix(iface, "docker") || strings.HasPrefix(iface, "br-") {
+			continue
+		}
+		fields := strings.Fields(counters)
+		if len(fields) < 9 {
+			continue
+		}
+		r, _ := strconv.ParseUint(fields[0], 10, 64)
+		t, _ := strconv.ParseUint(fields[8], 10, 64)
+		rx += r
+		tx += t
+	}
+	return
+}
+
 func getUptime() uint64 {
 	data, err := os.ReadFile("/proc/uptime")
 	if err != nil {
@@ -0,0 +1,135 @@
+package tunnel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cauthon-axis/internal/config"
+	"cauthon-axis/internal/logger"
+
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hashicorp/yamux"
+)
+
+var ErrNotConnected = errors.New("tunnel to panel is not connected")
+
+const (
+	keepAliveInterval = 30 * time.Second
+	maxBackoff        = 30 * time.Second
+	messageTimeout    = 10 * time.Second
+)
+
+var (
+	session *yamux.Session
+	closed  bool
+	mu      sync.Mutex
+)
+
+func Run(app *fiber.App, onConnect func()) {
+	backoff := time.Second
+	for {
+		mu.Lock()
+		stop := closed
+		mu.Unlock()
+		if stop {
+			return
+		}
+
+		s, err := connect()
+		if err != nil {
+			logger.Warn("Tunnel connection failed: %v (retrying in %s)", err, backoff)
+			time.Sleep(backoff)
+			backoff = min(backoff*2, maxBackoff)
+			continue
+		}
+		backoff = time.Second
+
+		mu.Lock()
+		session = s
+		mu.Unlock()
+		logger.Success("Tunnel connected to panel")
+		if onConnect != nil {
+			go onConnect()
+		}
+
+		app.Listener(s)
+
+		mu.Lock()
+		session = nil
+		stop = closed
+		mu.Unlock()
+		if !stop {
+			logger.Warn("Tunnel to panel lost, reconnecting...")
+		}
+	}
+}
+
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	closed = true
+	if session != nil {
+		session.Close()
+	}
+}
+
+func connect() (*yamux.Session, error) {
+	cfg := config.Get()
+	url := strings.TrimRight(cfg.Panel.URL, "/") + "/api/v1/internal/nodes/tunnel"
+	url = "ws" + strings.TrimPrefix(url, "http")
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 15 * time.Second,
+	}
+	ws, resp, err := dialer.Dial(url, http.Header{"Authorization": {"Bearer " + cfg.Panel.Token}})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, errors.New("panel rejected the node token")
+		}
+		return nil, err
+	}
+
+	yamuxCfg := yamux.DefaultConfig()
+	yamuxCfg.KeepAliveInterval = keepAliveInterval
+	yamuxCfg.LogOutput = io.Discard
+	return yamux.Server(&wsConn{ws: ws}, yamuxCfg)
+}
+
+func Send(msgType string, data interface{}) error {
+	mu.Lock()
+	s := session
+	mu.Unlock()
+	if s == nil {
+		return ErrNotConnected
+	}
+
+	stream, err := s.OpenStream()
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(messageTimeout))
+
+	if err := json.NewEncoder(stream).Encode(map[string]interface{}{"type": msgType, "data": data}); err != nil {
+		return err
+	}
+	var resp struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	}
+	if err := json.NewDecoder(stream).Decode(&resp); err != nil {
+		return fmt.Errorf("no response from panel: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("panel returned error: %s", resp.Error)
+	}
+	return nil
+}
@@ -16,8 +16,10 @@ import (
 	"cauthon-axis/internal/logger"
 	"cauthon-axis/internal/pairing"
 	"cauthon-axis/internal/panel"
+	"cauthon-axis/internal/server"
 	"cauthon-axis/internal/sftp"
 	"cauthon-axis/internal/system"
+	"cauthon-axis/internal/tunnel"
 )
 
 func main() {
@@ -82,13 +84,19 @@ func main() {
 
 	client := panel.NewClient()
 
-	if err := client.SendHeartbeat(); err != nil {
-		logger.Warn("Initial heartbeat failed: %v", err)
-	} else {
-		logger.Success("Connected to panel")
+	if !cfg.Panel.Tunnel {
+		if err := client.SendHeartbeat(); err != nil {
+			logger.Warn("Initial heartbeat failed: %v", err)
+		} else {
+			logger.Success("Connected to panel")
+		}
 	}
 
 	go heartbeatLoop(client)
+	go server.MonitorDiskUsage()
+	go server.PruneUploads()
+	go server.WatchEvents()
+	go server.CollectStats()
 
 	if err := sftp.Start(cfg.Node.SFTPPort); err != nil {
 		logger.Warn("SFTP server failed to start: %v", err)
@@ -96,17 +104,48 @@ func main() {
 
 	app := api.NewServer()
 
+	if m := cfg.Metrics; m.Listen != "" {
+		if m.Token == "" {
+			logger.Warn("Metrics listener configured without a token, not starting it")
+		} else {
+			metricsApp := api.NewMetricsServer()
+			go func() {
+				logger.Info("Metrics listening on %s", m.Listen)
+				if err := metricsApp.Listen(m.Listen); err != nil {
+					logger.Error("Metrics server error: %v", err)
+				}
+			}()
+		}
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		<-quit
 		logger.Info("Shutting down...")
+		tunnel.Close()
 		app.Shutdown()
 	}()
 
-	logger.Info("API server listening on %s", cfg.Node.Listen)
-	if err := app.Listen(cfg.Node.Listen); err != nil {
+	if cfg.Panel.Tunnel {
+		logger.Info("Tunnel mode, connecting to panel at %s", cfg.Panel.URL)
+		tunnel.Run(app, func() {
+			if err := client.SendHeartbeat(); err != nil {
+				logger.Warn("Initial heartbeat failed: %v", err)
+			}
+		})
+		return
+	}
+
+	if t := cfg.Node.TLS; t.Enabled() {
+		logger.Info("API server listening on %s (mutual TLS)", cfg.Node.Listen)
+		err = app.ListenMutualTLS(cfg.Node.Listen, t.Cert, t.Key, t.CA)
+	} else {
+		logger.Warn("No node certificate configured, API server listening on %s over plain HTTP", cfg.Node.Listen)
+		err = app.Listen(cfg.Node.Listen)
+	}
+	if err != nil {
 		logger.Fatal("%v", err)
 	}
 }
@@ -102,7 +102,7 @@ export default function UploadModal({ open, onClose, serverId, path, onComplete,
 
     setItems(prev => prev.map(i => i === pending ? { ...i, status: 'uploading', startTime: Date.now() } : i));
 
-    await uploadFile(
+    const result = await uploadFile(
       serverId,
       path,
       pending.file,
@@ -117,7 +117,9 @@ export default function UploadModal({ open, onClose, serverId, path, onComplete,
     );
 
     setItems(prev => prev.map(i =>
-      i.file === pending.file ? { ...i, status: i.status === 'uploading' ? 'done' : i.status, progress: 100, loaded: i.file.size } : i
+      i.file !== pending.file ? i
+        : result.success ? { ...i, status: i.status === 'uploading' ? 'done' : i.status, progress: 100, loaded: i.file.size }
+        : { ...i, status: i.status === 'uploading' ? 'error' : i.status }
     ));
 
     uploadingRef.current = false;
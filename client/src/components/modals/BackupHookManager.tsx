@@ -0,0 +1,121 @@
+import { useState } from 'react';
+import { PackageBackupHook, PackageBackupHooks } from '../../lib/api';
+import { Input, ContextMenu, Icons } from '../';
+
+interface Props {
+  hooks: PackageBackupHooks;
+  onChange: (hooks: PackageBackupHooks) => void;
+}
+
+type Stage = 'pre' | 'post';
+
+export default function BackupHookManager({ hooks, onChange }: Props) {
+  const [stage, setStage] = useState<Stage>('pre');
+  const [command, setCommand] = useState('');
+  const [waitFor, setWaitFor] = useState('');
+  const [timeout, setTimeoutValue] = useState('');
+  const [editing, setEditing] = useState<{ stage: Stage; index: number } | null>(null);
+
+  const addOrUpdate = () => {
+    if (!command) return;
+    const hook: PackageBackupHook = { command, wait_for: waitFor || undefined, timeout: parseInt(timeout) || undefined };
+    const next = { pre: [...hooks.pre], post: [...hooks.post] };
+    if (editing) {
+      next[editing.stage] = next[editing.stage].filter((_, i) => i !== editing.index);
+      if (editing.stage === stage) {
+        next[stage].splice(editing.index, 0, hook);
+      } else {
+        next[stage].push(hook);
+      }
+      setEditing(null);
+    } else {
+      next[stage].push(hook);
+    }
+    onChange(next);
+    setCommand('');
+    setWaitFor('');
+    setTimeoutValue('');
+  };
+
+  const edit = (s: Stage, index: number) => {
+    const h = hooks[s][index];
+    setStage(s);
+    setCommand(h.command);
+    setWaitFor(h.wait_for || '');
+    setTimeoutValue(h.timeout ? String(h.timeout) : '');
+    setEditing({ stage: s, index });
+  };
+
+  const remove = (s: Stage, index: number) => {
+    onChange({ ...hooks, [s]: hooks[s].filter((_, i) => i !== index) });
+    if (editing?.stage === s && editing.index === index) setEditing(null);
+  };
+
+  const renderList = (s: Stage, label: string) => hooks[s].length > 0 && (
+    <div className="space-y-2">
+      <div className="text-xs font-medium text-neutral-400">{label}</div>
+      {hooks[s].map((hook, i) => (
+        <div key={i} className={`flex items-center justify-between p-3 rounded-lg ${editing?.stage === s && editing.index === i ? 'bg-amber-500/20 ring-1 ring-amber-500' : 'bg-neutral-800/50'}`}>
+          <div className="flex items-center gap-3 min-w-0">
+            <span className="text-sm font-mono text-neutral-100 truncate">{hook.command}</span>
+            {hook.wait_for && <span className="text-xs font-mono text-neutral-400 truncate">waits for /{hook.wait_for}/ ({hook.timeout || 30}s)</span>}
+          </div>
+          <div className="flex items-center gap-1">
+            <button onClick={() => edit(s, i)} className="text-neutral-400 hover:text-neutral-100 transition-colors p-1">
+              <Icons.edit className="w-4 h-4" />
+            </button>
+            <button onClick={() => remove(s, i)} className="text-neutral-400 hover:text-red-400 transition-colors p-1">
+              <Icons.x className="w-4 h-4" />
+            </button>
+          </div>
+        </div>
+      ))}
+    </div>
+  );
+
+  return (
+    <div className="space-y-4">
+      <div className="p-4 rounded-lg bg-neutral-900/50 border border-neutral-800 space-y-3">
+        <div className="grid grid-cols-4 gap-3">
+          <div className="flex flex-col gap-1.5">
+            <label className="block text-xs font-medium text-neutral-400">Run</label>
+            <ContextMenu
+              align="start"
+              className="w-full"
+              trigger={
+                <button type="button" className="w-full rounded-lg border border-neutral-800/60 bg-neutral-900/60 text-neutral-100 text-left transition hover:border-neutral-500 focus:outline-none focus:ring-2 focus:ring-neutral-100 focus:ring-offset-2 focus:ring-offset-[#0a0a0a] px-3 py-2 flex items-center justify-between" style={{ fontSize: '13px' }}>
+                  {stage === 'pre' ? 'Before backup' : 'After backup'}
+                  <Icons.chevronDown className="w-4 h-4 text-neutral-400" />
+                </button>
+              }
+              items={[
+                { label: 'Before backup', onClick: () => setStage('pre') },
+                { label: 'After backup', onClick: () => setStage('post') },
+              ]}
+            />
+          </div>
+          <Input label="Command" value={command} onChange={e => setCommand(e.target.value)} placeholder="save-all flush" />
+          <Input label="Wait For (regex)" value={waitFor} onChange={e => setWaitFor(e.target.value)} placeholder="Saved the game" />
+          <Input label="Timeout (seconds)" type="number" value={timeout} onChange={e => setTimeoutValue(e.target.value)} placeholder="30" />
+        </div>
+        <button
+          type="button"
+          onClick={addOrUpdate}
+          disabled={!command}
+          className="w-full py-2 text-sm font-medium text-neutral-300 bg-neutral-800 hover:bg-neutral-700 rounded-lg transition-colors disabled:opacity-50 disabled:cursor-not-allowed"
+        >
+          {editing ? 'Update Command' : 'Add Command'}
+        </button>
+      </div>
+
+      {hooks.pre.length > 0 || hooks.post.length > 0 ? (
+        <>
+          {renderList('pre', 'Before Backup')}
+          {renderList('post', 'After Backup')}
+        </>
+      ) : (
+        <p className="text-sm text-neutral-500 text-center py-4">No backup commands. They only run while the server is online.</p>
+      )}
+    </div>
+  );
+}
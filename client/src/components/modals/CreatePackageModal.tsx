@@ -1,11 +1,12 @@
 import { useState, useEffect, useCallback, useRef } from 'react';
 import { createPortal } from 'react-dom';
-import { adminCreatePackage, adminUpdatePackage, Package } from '../../lib/api';
+import { adminCreatePackage, adminUpdatePackage, getAvailableLocations, Package, type Location } from '../../lib/api';
 import { notify } from '../feedback/Notification';
 import { usePackageForm } from '../../hooks/usePackageForm';
 import PortManager from './PortManager';
 import VariableManager from './VariableManager';
 import AddonSourceManager from './AddonSourceManager';
+import BackupHookManager from './BackupHookManager';
 import { Input, Wizard, ContextMenu, Icons, Button, Checkbox } from '../';
 
 const STEPS = [
@@ -100,6 +101,7 @@ export default function CreatePackageModal({ open, onClose, onCreated, editPacka
   const [animate, setAnimate] = useState(false);
   const [closing, setClosing] = useState(false);
   const [jsonText, setJsonText] = useState('');
+  const [locations, setLocations] = useState<Location[]>([]);
   const fileInputRef = useRef<HTMLInputElement>(null);
   const submittingRef = useRef(false);
   const { data, update, toJson, fromJson, toApiData, reset } = usePackageForm(editPackage);
@@ -129,6 +131,7 @@ export default function CreatePackageModal({ open, onClose, onCreated, editPacka
       if (editPackage) {
         reset(editPackage);
       }
+      getAvailableLocations().then(res => setLocations(res.success && res.data ? res.data : []));
     }
   }, [open, editPackage, reset]);
 
@@ -262,6 +265,46 @@ export default function CreatePackageModal({ open, onClose, onCreated, editPacka
             <div className="grid grid-cols-1 gap-4">
               <Input label="Stop Timeout (seconds)" type="number" value={data.stopTimeout} onChange={e => update('stopTimeout', e.target.value)} placeholder="30" />
             </div>
+            <div>
+              <label className="block text-xs font-medium text-neutral-400 mb-1.5">Backup Ignore (optional)</label>
+              <textarea
+                value={data.backupIgnore}
+                onChange={e => update('backupIgnore', e.target.value)}
+                placeholder="logs/&#10;cache/&#10;*.log"
+                rows={3}
+                className="w-full rounded-lg border border-neutral-800/60 bg-neutral-900/60 text-neutral-100 placeholder:text-neutral-500 transition hover:border-neutral-500 focus:outline-none focus:ring-2 focus:ring-neutral-100 focus:ring-offset-2 focus:ring-offset-neutral-950 px-3 py-2 text-sm font-mono resize-none"
+              />
+              <p className="mt-1 text-xs text-neutral-500">Default exclude rules for backups, one gitignore-style pattern per line. A .birdactylignore file in the server root is applied after these.</p>
+            </div>
+            <div>
+              <label className="block text-xs font-medium text-neutral-400 mb-1.5">Backup Commands (optional)</label>
+              <BackupHookManager hooks={data.backupHooks} onChange={hooks => update('backupHooks', hooks)} />
+              <p className="mt-1 text-xs text-neutral-500">Console commands sent around backups of a running server, e.g. save-off and save-all flush before and save-on after. Wait For holds the backup until a matching console line appears.</p>
+            </div>
+            <div>
+              <div className="grid grid-cols-3 gap-4">
+                <Input label="Crash Restarts" type="number" value={data.crashPolicy.maxRestarts} onChange={e => update('crashPolicy', { ...data.crashPolicy, maxRestarts: e.target.value })} placeholder="3" />
+                <Input label="Crash Window (seconds)" type="number" value={data.crashPolicy.window} onChange={e => update('crashPolicy', { ...data.crashPolicy, window: e.target.value })} placeholder="600" />
+                <Input label="Restart Backoff (seconds)" type="number" value={data.crashPolicy.backoff} onChange={e => update('crashPolicy', { ...data.crashPolicy, backoff: e.target.value })} placeholder="10" />
+              </div>
+              <p className="mt-1 text-xs text-neutral-500">Crashed servers are restarted up to this many times within the window, waiting twice as long before each retry, then marked failed. Set restarts to 0 to never restart. Leave empty for the panel defaults.</p>
+            </div>
+            {locations.length > 0 && (
+              <div>
+                <label className="block text-xs font-medium text-neutral-400 mb-1.5">Allowed Locations</label>
+                <div className="flex flex-wrap items-center gap-x-6 gap-y-2">
+                  {locations.map(l => (
+                    <Checkbox
+                      key={l.id}
+                      checked={data.allowedLocations.includes(l.id)}
+                      onChange={() => update('allowedLocations', data.allowedLocations.includes(l.id) ? data.allowedLocations.filter(id => id !== l.id) : [...data.allowedLocations, l.id])}
+                      label={l.name}
+                    />
+                  ))}
+                </div>
+                <p className="mt-1 text-xs text-neutral-500">Servers of this package can only be created in the checked locations. Leave all unchecked to allow every location.</p>
+              </div>
+            )}
             <div className="flex items-center gap-6 pt-2">
               <Checkbox checked={data.startupEditable} onChange={() => update('startupEditable', !data.startupEditable)} label="Allow users to edit startup command" />
               <Checkbox checked={data.dockerImageEditable} onChange={() => update('dockerImageEditable', !data.dockerImageEditable)} label="Allow users to edit Docker image" />
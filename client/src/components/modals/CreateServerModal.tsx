@@ -1,5 +1,5 @@
 import { useState, useEffect, useCallback, useRef } from 'react';
-import { getAvailableNodes, getAvailablePackages, createServer, Package } from '../../lib/api';
+import { getAvailableNodes, getAvailableLocations, getAvailablePackages, createServer, Package, type Location } from '../../lib/api';
 import { notify } from '../feedback/Notification';
 import Input from '../ui/Input';
 import Wizard from '../feedback/Wizard';
@@ -7,7 +7,7 @@ import { ContextMenu } from '../ui/ContextMenu';
 import { Icons } from '../Icons';
 
 interface Node {
-  id: string; name: string; fqdn: string; is_online: boolean; icon?: string;
+  id: string; name: string; fqdn: string; is_online: boolean; icon?: string; location_id?: string | null;
   system_info: { memory: { total_bytes: number; available_bytes: number; usage_percent: number }; disk: { total_bytes: number; available_bytes: number; usage_percent: number }; cpu: { cores: number; usage_percent: number } };
 }
 
@@ -26,9 +26,9 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
   const [animate, setAnimate] = useState(false);
   const [closing, setClosing] = useState(false);
   const [ui, setUi] = useState({ loading: false, loadingData: true });
-  const [data, setData] = useState<{ nodes: Node[]; packages: Package[] }>({ nodes: [], packages: [] });
+  const [data, setData] = useState<{ nodes: Node[]; locations: Location[]; packages: Package[] }>({ nodes: [], locations: [], packages: [] });
   const [form, setForm] = useState({ name: '', description: '', memory: '1024', cpu: '100', disk: '5120' });
-  const [selected, setSelected] = useState<{ pkg: Package | null; node: Node | null }>({ pkg: null, node: null });
+  const [selected, setSelected] = useState<{ pkg: Package | null; location: Location | null; node: Node | null }>({ pkg: null, location: null, node: null });
   const submittingRef = useRef(false);
 
   const triggerClose = useCallback(() => {
@@ -52,8 +52,8 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
       requestAnimationFrame(() => requestAnimationFrame(() => setAnimate(true)));
       document.body.style.overflow = 'hidden';
       setUi(u => ({ ...u, loadingData: true }));
-      Promise.all([getAvailableNodes(), getAvailablePackages()]).then(([nodesRes, pkgsRes]) => {
-        setData({ nodes: nodesRes.success && nodesRes.data ? nodesRes.data : [], packages: pkgsRes.success && pkgsRes.data ? pkgsRes.data : [] });
+      Promise.all([getAvailableNodes(), getAvailableLocations(), getAvailablePackages()]).then(([nodesRes, locationsRes, pkgsRes]) => {
+        setData({ nodes: nodesRes.success && nodesRes.data ? nodesRes.data : [], locations: locationsRes.success && locationsRes.data ? locationsRes.data : [], packages: pkgsRes.success && pkgsRes.data ? pkgsRes.data : [] });
         setUi(u => ({ ...u, loadingData: false }));
       });
     }
@@ -68,14 +68,14 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
   const canProceed = (): boolean => {
     switch (step) {
       case 0: return !!(form.name.trim() && selected.pkg);
-      case 1: return !!selected.node;
+      case 1: return !!(selected.node || selected.location);
       case 2: return parseInt(form.memory) >= 128 && parseInt(form.cpu) >= 25 && parseInt(form.disk) >= 256;
       default: return true;
     }
   };
 
   const handleCreate = async () => {
-    if (!selected.pkg || !selected.node || submittingRef.current) return;
+    if (!selected.pkg || !(selected.node || selected.location) || submittingRef.current) return;
     submittingRef.current = true;
     setUi(u => ({ ...u, loading: true }));
 
@@ -85,7 +85,7 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
     variables['SERVER_MEMORY'] = form.memory;
 
     const res = await createServer({
-      name: form.name.trim(), description: form.description.trim() || undefined, node_id: selected.node.id, package_id: selected.pkg.id,
+      name: form.name.trim(), description: form.description.trim() || undefined, node_id: selected.node?.id, location_id: selected.node ? undefined : selected.location?.id, package_id: selected.pkg.id,
       memory: parseInt(form.memory), cpu: parseInt(form.cpu), disk: parseInt(form.disk), ports, variables,
     });
 
@@ -93,7 +93,7 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
       notify('Server Created', `${form.name} is being set up`, 'success');
       onCreated?.();
       setForm({ name: '', description: '', memory: '1024', cpu: '100', disk: '5120' });
-      setSelected({ pkg: null, node: null });
+      setSelected({ pkg: null, location: null, node: null });
       triggerClose();
     } else {
       notify('Error', res.error || 'Failed to create server', 'error');
@@ -102,6 +102,9 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
     }
   };
 
+  const allowedLocations = data.locations.filter(l => !selected.pkg?.allowed_locations?.length || selected.pkg.allowed_locations.includes(l.id));
+  const locationNodes = selected.location ? data.nodes.filter(n => n.location_id === selected.location?.id) : data.nodes;
+
   const formatBytes = (bytes: number) => { const gb = bytes / (1024 * 1024 * 1024); return gb >= 1 ? `${gb.toFixed(1)} GB` : `${(bytes / (1024 * 1024)).toFixed(0)} MB`; };
 
   if (!visible) return null;
@@ -138,7 +141,7 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
                     items={data.packages.map(pkg => ({
                       label: `${pkg.name} (${pkg.docker_image})`,
                       icon: pkg.icon ? <img src={pkg.icon} alt="" className="w-5 h-5 rounded object-cover" /> : <Icons.cube className="w-5 h-5 text-neutral-400" />,
-                      onClick: () => setSelected(s => ({ ...s, pkg })),
+                      onClick: () => setSelected(s => ({ ...s, pkg, location: null, node: null })),
                     }))}
                   />
                 )}
@@ -147,32 +150,58 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
           )}
 
           {step === 1 && (
-            <div className="w-full">
-              <label className="block text-sm font-medium text-neutral-300 mb-2">Node</label>
-              {data.nodes.length === 0 ? <p className="text-neutral-500 text-sm">No online nodes available.</p> : (
-                <ContextMenu
-                  align="start"
-                  className="w-full"
-                  trigger={
-                    <button className="w-full flex items-center justify-between px-3 py-2 text-[13px] rounded-lg border border-neutral-800 bg-neutral-800/80 text-left hover:border-neutral-500 transition-colors">
-                      <span className="truncate text-neutral-100 flex items-center gap-2">
-                        {selected.node ? (
-                          <>
-                            {selected.node.icon ? <img src={selected.node.icon} alt="" className="w-5 h-5 rounded object-cover" /> : <Icons.server className="w-5 h-5 text-neutral-400" />}
-                            {selected.node.name} ({selected.node.fqdn})
-                          </>
-                        ) : 'Select a node'}
-                      </span>
-                      <Icons.selector className="w-4 h-4 text-neutral-500 flex-shrink-0 ml-2" />
-                    </button>
-                  }
-                  items={data.nodes.map(node => ({
-                    label: <span className="flex items-center gap-1">{node.name} ({node.fqdn}){node.system_info && <span className="text-neutral-500 ml-1">-- {formatBytes(node.system_info.memory.available_bytes)} free</span>}</span>,
-                    icon: node.icon ? <img src={node.icon} alt="" className="w-5 h-5 rounded object-cover" /> : <Icons.server className="w-5 h-5 text-neutral-400" />,
-                    onClick: () => setSelected(s => ({ ...s, node })),
-                  }))}
-                />
+            <div className="space-y-6">
+              {allowedLocations.length > 0 && (
+                <div className="w-full">
+                  <label className="block text-sm font-medium text-neutral-300 mb-2">Location</label>
+                  <ContextMenu
+                    align="start"
+                    className="w-full"
+                    trigger={
+                      <button className="w-full flex items-center justify-between px-3 py-2 text-[13px] rounded-lg border border-neutral-800 bg-neutral-800/80 text-left hover:border-neutral-500 transition-colors">
+                        <span className="truncate text-neutral-100">
+                          {selected.location ? `${selected.location.name}${selected.location.region ? ` (${selected.location.region})` : ''}` : 'Select a location'}
+                        </span>
+                        <Icons.selector className="w-4 h-4 text-neutral-500 flex-shrink-0 ml-2" />
+                      </button>
+                    }
+                    items={allowedLocations.map(location => ({
+                      label: <span className="flex items-center gap-1">{location.name}{location.region && <span className="text-neutral-500 ml-1">-- {location.region}</span>}</span>,
+                      onClick: () => setSelected(s => ({ ...s, location, node: null })),
+                    }))}
+                  />
+                </div>
               )}
+              <div className="w-full">
+                <label className="block text-sm font-medium text-neutral-300 mb-2">Node</label>
+                {locationNodes.length === 0 && !selected.location ? <p className="text-neutral-500 text-sm">No online nodes available.</p> : (
+                  <ContextMenu
+                    align="start"
+                    className="w-full"
+                    trigger={
+                      <button className="w-full flex items-center justify-between px-3 py-2 text-[13px] rounded-lg border border-neutral-800 bg-neutral-800/80 text-left hover:border-neutral-500 transition-colors">
+                        <span className="truncate text-neutral-100 flex items-center gap-2">
+                          {selected.node ? (
+                            <>
+                              {selected.node.icon ? <img src={selected.node.icon} alt="" className="w-5 h-5 rounded object-cover" /> : <Icons.server className="w-5 h-5 text-neutral-400" />}
+                              {selected.node.name} ({selected.node.fqdn})
+                            </>
+                          ) : selected.location ? 'Pick automatically' : 'Select a node'}
+                        </span>
+                        <Icons.selector className="w-4 h-4 text-neutral-500 flex-shrink-0 ml-2" />
+                      </button>
+                    }
+                    items={[
+                      ...(selected.location ? [{ label: 'Pick automatically', icon: <Icons.server className="w-5 h-5 text-neutral-400" />, onClick: () => setSelected(s => ({ ...s, node: null })) }] : []),
+                      ...locationNodes.map(node => ({
+                        label: <span className="flex items-center gap-1">{node.name} ({node.fqdn}){node.system_info && <span className="text-neutral-500 ml-1">-- {formatBytes(node.system_info.memory.available_bytes)} free</span>}</span>,
+                        icon: node.icon ? <img src={node.icon} alt="" className="w-5 h-5 rounded object-cover" /> : <Icons.server className="w-5 h-5 text-neutral-400" />,
+                        onClick: () => setSelected(s => ({ ...s, node })),
+                      })),
+                    ]}
+                  />
+                )}
+              </div>
             </div>
           )}
 
@@ -206,11 +235,11 @@ export default function CreateServerModal({ open, onClose, onCreated }: Props) {
                   </div>
                   <div className="flex justify-between">
                     <span className="text-neutral-400">Node</span>
-                    <span className="text-neutral-100">{selected.node?.name || '-'}</span>
+                    <span className="text-neutral-100">{selected.node?.name || (selected.location ? 'Picked automatically' : '-')}</span>
                   </div>
                   <div className="flex justify-between">
                     <span className="text-neutral-400">Location</span>
-                    <span className="text-neutral-100 font-mono text-xs">{selected.node?.fqdn || '-'}</span>
+                    <span className="text-neutral-100 font-mono text-xs">{selected.location?.name || selected.node?.fqdn || '-'}</span>
                   </div>
                 </div>
               </div>
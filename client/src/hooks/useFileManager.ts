@@ -1,7 +1,7 @@
 import { useState, useEffect, useCallback, useRef } from 'react';
 import { useNavigate, useSearchParams } from 'react-router-dom';
 import {
-  listFiles, FileEntry, searchFiles, SearchResult, deleteFile, moveFile, copyFile,
+  listFiles, FileEntry, searchFiles, SearchResult, searchFileContents, ContentMatch, deleteFile, moveFile, copyFile,
   compressFile, decompressFile, createFolder, writeFile, bulkDeleteFiles, bulkCopyFiles, bulkCompressFiles
 } from '../lib/api';
 import { notify } from '../components/feedback/Notification';
@@ -15,6 +15,10 @@ export function useFileManager(serverId: string | undefined, initialPath: string
   const [error, setError] = useState<string | null>(null);
   const [search, setSearch] = useState('');
   const [searchResults, setSearchResults] = useState<SearchResult[] | null>(null);
+  const [contentSearch, setContentSearch] = useState(false);
+  const [contentResults, setContentResults] = useState<ContentMatch[] | null>(null);
+  const [contentSearching, setContentSearching] = useState(false);
+  const [contentTruncated, setContentTruncated] = useState(false);
   const [selected, setSelected] = useState<Set<string>>(new Set());
   const [clipboard, setClipboard] = useState<string[]>([]);
   const [pasting, setPasting] = useState(false);
@@ -66,12 +70,29 @@ export function useFileManager(serverId: string | undefined, initialPath: string
   }, [serverId, currentPath]);
 
   useEffect(() => {
-    if (!serverId || !search.trim()) { setSearchResults(null); return; }
+    if (!serverId || contentSearch || !search.trim()) { setSearchResults(null); return; }
     const timer = setTimeout(() => {
       searchFiles(serverId, search).then(res => res.success && res.data && setSearchResults(res.data));
     }, 300);
     return () => clearTimeout(timer);
-  }, [serverId, search]);
+  }, [serverId, search, contentSearch]);
+
+  useEffect(() => {
+    if (!serverId || !contentSearch || !search.trim()) { setContentResults(null); setContentTruncated(false); return; }
+    const controller = new AbortController();
+    const timer = setTimeout(() => {
+      const matches: ContentMatch[] = [];
+      setContentResults([]);
+      setContentSearching(true);
+      searchFileContents(serverId, search, m => { matches.push(m); setContentResults([...matches]); }, controller.signal).then(res => {
+        if (controller.signal.aborted) return;
+        setContentSearching(false);
+        setContentTruncated(!!res.data?.truncated);
+        if (!res.success) notify('Search Failed', res.error || 'Search failed', 'error');
+      });
+    }, 500);
+    return () => { clearTimeout(timer); controller.abort(); setContentSearching(false); };
+  }, [serverId, search, contentSearch]);
 
   const goUp = () => {
     if (currentPath === '/') return;
@@ -94,6 +115,10 @@ export function useFileManager(serverId: string | undefined, initialPath: string
     else navigate(`/console/server/${serverId}/files/edit?path=${encodeURIComponent(result.path)}`);
   };
 
+  const navigateToContentMatch = (match: ContentMatch) => {
+    navigate(`/console/server/${serverId}/files/edit?path=${encodeURIComponent(match.path)}`);
+  };
+
   const checkPerm = (res: { success: boolean; error?: string }) => {
     if (res.error === 'Permission denied') {
       notify('Permission Denied', "You don't have permission to perform this action", 'error');
@@ -178,7 +203,8 @@ export function useFileManager(serverId: string | undefined, initialPath: string
 
   return {
     files: filteredFiles, loading, error, currentPath, setCurrentPath, search, setSearch, searchResults,
+    contentSearch, setContentSearch, contentResults, contentSearching, contentTruncated,
     selected, toggleSelect, allSelected, someSelected, toggleAll, clipboard, setClipboard, pasting, decompressing,
-    uploadInputRef, goUp, navigateTo, navigateToSearchResult, refreshFiles, getFilePath, actions,
+    uploadInputRef, goUp, navigateTo, navigateToSearchResult, navigateToContentMatch, refreshFiles, getFilePath, actions,
   };
 }
@@ -1,5 +1,5 @@
 import { useState, useCallback } from 'react';
-import { Package, PackagePort, PackageVariable, AddonSource } from '../lib/api';
+import { Package, PackagePort, PackageVariable, AddonSource, PackageBackupHooks, CrashPolicy } from '../lib/api';
 
 interface PackageFormData {
   name: string;
@@ -19,8 +19,39 @@ interface PackageFormData {
   ports: PackagePort[];
   variables: PackageVariable[];
   addonSources: AddonSource[];
+  backupTargetId: string | null;
+  backupIgnore: string;
+  backupHooks: PackageBackupHooks;
+  crashPolicy: CrashPolicyForm;
+  allowedLocations: string[];
 }
 
+interface CrashPolicyForm {
+  maxRestarts: string;
+  window: string;
+  backoff: string;
+}
+
+const toHooks = (hooks?: Partial<PackageBackupHooks>): PackageBackupHooks => ({
+  pre: hooks?.pre || [],
+  post: hooks?.post || [],
+});
+
+const toCrashForm = (policy?: CrashPolicy | null): CrashPolicyForm => ({
+  maxRestarts: policy ? String(policy.max_restarts) : '',
+  window: policy ? String(policy.window) : '',
+  backoff: policy ? String(policy.backoff) : '',
+});
+
+const toCrashPolicy = (form: CrashPolicyForm): CrashPolicy | null => {
+  if (!form.maxRestarts && !form.window && !form.backoff) return null;
+  return {
+    max_restarts: parseInt(form.maxRestarts) || 0,
+    window: parseInt(form.window) || 600,
+    backoff: parseInt(form.backoff) || 0,
+  };
+};
+
 const defaultData: PackageFormData = {
   name: '',
   version: '',
@@ -39,6 +70,11 @@ const defaultData: PackageFormData = {
   ports: [],
   variables: [],
   addonSources: [],
+  backupTargetId: null,
+  backupIgnore: '',
+  backupHooks: { pre: [], post: [] },
+  crashPolicy: toCrashForm(null),
+  allowedLocations: [],
 };
 
 export function usePackageForm(editPackage?: Package | null) {
@@ -62,6 +98,11 @@ export function usePackageForm(editPackage?: Package | null) {
         ports: editPackage.ports || [],
         variables: editPackage.variables || [],
         addonSources: editPackage.addon_sources || [],
+        backupTargetId: editPackage.backup_target_id || null,
+        backupIgnore: editPackage.backup_ignore || '',
+        backupHooks: toHooks(editPackage.backup_hooks),
+        crashPolicy: toCrashForm(editPackage.crash_policy),
+        allowedLocations: editPackage.allowed_locations || [],
       };
     }
     return defaultData;
@@ -87,6 +128,11 @@ export function usePackageForm(editPackage?: Package | null) {
         ports: pkg.ports || [],
         variables: pkg.variables || [],
         addonSources: pkg.addon_sources || [],
+        backupTargetId: pkg.backup_target_id || null,
+        backupIgnore: pkg.backup_ignore || '',
+        backupHooks: toHooks(pkg.backup_hooks),
+        crashPolicy: toCrashForm(pkg.crash_policy),
+        allowedLocations: pkg.allowed_locations || [],
       });
     } else {
       setData(defaultData);
@@ -115,6 +161,9 @@ export function usePackageForm(editPackage?: Package | null) {
     ports: data.ports,
     variables: data.variables,
     addon_sources: data.addonSources,
+    backup_ignore: data.backupIgnore,
+    backup_hooks: data.backupHooks,
+    crash_policy: toCrashPolicy(data.crashPolicy),
   }, null, 2);
 
   const fromJson = (json: string) => {
@@ -138,6 +187,11 @@ export function usePackageForm(editPackage?: Package | null) {
         ports: pkg.ports || [],
         variables: pkg.variables || [],
         addonSources: pkg.addon_sources || [],
+        backupTargetId: pkg.backup_target_id || null,
+        backupIgnore: pkg.backup_ignore || '',
+        backupHooks: toHooks(pkg.backup_hooks),
+        crashPolicy: toCrashForm(pkg.crash_policy),
+        allowedLocations: pkg.allowed_locations || [],
       });
     } catch { }
   };
@@ -161,6 +215,11 @@ export function usePackageForm(editPackage?: Package | null) {
     variables: data.variables,
     config_files: [],
     addon_sources: data.addonSources,
+    backup_target_id: data.backupTargetId,
+    backup_ignore: data.backupIgnore,
+    backup_hooks: data.backupHooks,
+    crash_policy: toCrashPolicy(data.crashPolicy),
+    allowed_locations: data.allowedLocations,
   });
 
   return { data, update, toJson, fromJson, toApiData, reset };
@@ -1,6 +1,6 @@
 import { api } from './client';
-import type { Server } from './servers';
-import type { Package } from './packages';
+import type { Server, MetricRange } from './servers';
+import type { Package, Location } from './packages';
 
 export interface PaginatedUsers {
   users: { id: string; username: string; email: string; is_admin: boolean; is_banned: boolean; is_root_admin: boolean; force_password_reset: boolean; totp_enabled: boolean; ram_limit: number | null; cpu_limit: number | null; disk_limit: number | null; server_limit: number | null; created_at: string }[];
@@ -8,13 +8,19 @@ export interface PaginatedUsers {
 }
 
 
-export interface Node {
-  id: string; name: string; fqdn: string; port: number; is_online: boolean; auth_error: boolean; last_heartbeat: string | null; icon?: string;
+export interface Node extends NodeLimits {
+  id: string; name: string; fqdn: string; port: number; scheme: 'http' | 'https'; tunnel: boolean; is_online: boolean; auth_error: boolean; last_heartbeat: string | null; icon?: string; location_id?: string | null;
   system_info: { hostname: string; os: { name: string; version: string; kernel: string; arch: string }; cpu: { cores: number; usage_percent: number }; memory: { total_bytes: number; used_bytes: number; available_bytes: number; usage_percent: number }; disk: { total_bytes: number; used_bytes: number; available_bytes: number; usage_percent: number }; uptime_seconds: number };
+  allocation?: NodeAllocation;
   created_at: string;
 }
 
+export interface NodeLimits { memory_limit: number; memory_overallocate: number; disk_limit: number; disk_overallocate: number; cpu_limit: number; cpu_overallocate: number; }
+export interface ResourceAllocation { allocated: number; limit: number; overallocate: number; max: number; }
+export interface NodeAllocation { servers: number; memory: ResourceAllocation; disk: ResourceAllocation; cpu: ResourceAllocation; }
+
 export interface NodeToken { token_id: string; token: string; }
+export interface PortAllocation { id: string; node_id: string; ip: string; port: number; alias: string; server_id: string | null; created_at: string; updated_at: string; }
 
 export const adminGetUsers = (page = 1, perPage = 20, search = '', filter = 'all') => {
   const params = new URLSearchParams({ page: String(page), per_page: String(perPage) });
@@ -37,8 +43,19 @@ export const adminGetNodes = () => api.get<Node[]>('/admin/nodes');
 export const adminRefreshNodes = () => api.post<Node[]>('/admin/nodes/refresh');
 export const adminCreateNode = (name: string, fqdn: string, port = 8443, icon?: string) => api.post<{ node: Node; token: NodeToken }>('/admin/nodes', { name, fqdn, port, icon });
 export const adminGetNode = (id: string) => api.get<Node>(`/admin/nodes/${id}`);
-export const adminUpdateNode = (id: string, data: { name?: string; icon?: string }) => api.patch<Node>(`/admin/nodes/${id}`, data);
+export const adminGetNodeMetrics = (id: string, range = '1h') => api.get<MetricRange>(`/admin/nodes/${id}/metrics?range=${encodeURIComponent(range)}`);
+export const adminUpdateNode = (id: string, data: { name?: string; icon?: string; location_id?: string } & Partial<NodeLimits>) => api.patch<Node>(`/admin/nodes/${id}`, data);
 export const adminDeleteNode = (id: string) => api.delete(`/admin/nodes/${id}`);
+export const adminGetNodeAllocations = (id: string) => api.get<PortAllocation[]>(`/admin/nodes/${id}/allocations`);
+export const adminCreateNodeAllocations = (id: string, data: { ip: string; ports: string[]; alias?: string }) => api.post<PortAllocation[]>(`/admin/nodes/${id}/allocations`, data);
+export const adminUpdateNodeAllocation = (id: string, allocationId: string, alias: string) => api.patch<PortAllocation>(`/admin/nodes/${id}/allocations/${allocationId}`, { alias });
+export const adminDeleteNodeAllocation = (id: string, allocationId: string) => api.delete(`/admin/nodes/${id}/allocations/${allocationId}`);
+
+export type LocationInput = Pick<Location, 'name' | 'description' | 'region' | 'tags'>;
+export const adminGetLocations = () => api.get<{ location: Location; nodes_count: number }[]>('/admin/locations');
+export const adminCreateLocation = (data: LocationInput) => api.post<Location>('/admin/locations', data);
+export const adminUpdateLocation = (id: string, data: LocationInput) => api.patch<Location>(`/admin/locations/${id}`, data);
+export const adminDeleteLocation = (id: string) => api.delete(`/admin/locations/${id}`);
 export const adminResetNodeToken = (id: string) => api.post<NodeToken>(`/admin/nodes/${id}/reset-token`);
 export const adminGetPairingCode = () => api.get<{ code: string }>('/admin/nodes/pairing-code');
 export const adminPairNode = (name: string, fqdn: string, port: number, code: string) => api.post<{ node: Node; token: NodeToken }>('/admin/nodes/pair', { name, fqdn, port, code });
@@ -29,6 +29,11 @@ export const getAPIKeys = () => api.get<APIKey[]>('/auth/api-keys');
 export const createAPIKey = (name: string, expiresIn?: number) => api.post<APIKeyCreated>('/auth/api-keys', { name, expires_in: expiresIn });
 export const deleteAPIKey = (id: string) => api.delete(`/auth/api-keys/${id}`);
 
+export interface SSHKey { id: string; name: string; fingerprint: string; public_key: string; last_used_at: string | null; created_at: string; }
+export const getSSHKeys = () => api.get<SSHKey[]>('/auth/ssh-keys');
+export const createSSHKey = (name: string, publicKey: string) => api.post<SSHKey>('/auth/ssh-keys', { name, public_key: publicKey });
+export const deleteSSHKey = (id: string) => api.delete(`/auth/ssh-keys/${id}`);
+
 export interface TwoFactorSetupData { secret: string; url: string; }
 export const setup2FA = () => api.post<TwoFactorSetupData>('/auth/2fa/setup');
 export const enable2FA = (code: string) => api.post<{ backup_codes: string[] }>('/auth/2fa/enable', { code });
@@ -1,15 +1,26 @@
 import { api, API_BASE } from './client';
+import type { FileEntry } from './files';
 
 export interface Backup {
   id: string;
   name: string;
   size: number;
+  checksum: string;
+  storage: string;
+  locked: boolean;
+  status: 'pending' | 'completed' | 'failed';
+  error?: string;
+  warnings?: string;
+  created_by: string | null;
   created_at: number;
   completed: boolean;
 }
 
 export const listBackups = (serverId: string) => api.get<Backup[]>(`/servers/${serverId}/backups`);
-export const createBackup = (serverId: string, name?: string) => api.post<Backup>(`/servers/${serverId}/backups`, { name });
+export const createBackup = (serverId: string, name?: string, locked?: boolean, ignore?: string[]) => api.post<Backup>(`/servers/${serverId}/backups`, { name, locked, ignore });
 export const deleteBackup = (serverId: string, backupId: string) => api.delete(`/servers/${serverId}/backups/${backupId}`);
-export const restoreBackup = (serverId: string, backupId: string) => api.post(`/servers/${serverId}/backups/${backupId}/restore`);
+export const lockBackup = (serverId: string, backupId: string) => api.post<Backup>(`/servers/${serverId}/backups/${backupId}/lock`);
+export const unlockBackup = (serverId: string, backupId: string) => api.post<Backup>(`/servers/${serverId}/backups/${backupId}/unlock`);
+export const restoreBackup = (serverId: string, backupId: string, paths?: string[]) => api.post(`/servers/${serverId}/backups/${backupId}/restore`, paths?.length ? { paths } : undefined);
+export const listBackupFiles = (serverId: string, backupId: string, path = '/') => api.get<FileEntry[]>(`/servers/${serverId}/backups/${backupId}/files?path=${encodeURIComponent(path)}`);
 export const getBackupDownloadUrl = (serverId: string, backupId: string) => `${API_BASE}/servers/${serverId}/backups/${backupId}/download`;
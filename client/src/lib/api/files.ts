@@ -4,6 +4,8 @@ import { eventBus } from '../eventBus';
 
 export interface FileEntry { name: string; size: number; is_dir: boolean; mod_time: number; mode: string; }
 export interface SearchResult { name: string; path: string; size: number; is_dir: boolean; mod_time: number; }
+export interface ContentMatch { path: string; line: number; text: string; }
+export interface ContentSearchSummary { files_scanned: number; files_skipped: number; matches: number; truncated: boolean; error?: string; }
 
 export const listFiles = (serverId: string, path = '/') => api.get<FileEntry[]>(`/servers/${serverId}/files?path=${encodeURIComponent(path)}`);
 export const readFile = (serverId: string, path: string) => api.get<string>(`/servers/${serverId}/files/read?path=${encodeURIComponent(path)}`);
@@ -50,31 +52,141 @@ export function getDownloadUrl(serverId: string, path: string): string {
   return `${API_BASE}/servers/${serverId}/files/download?path=${encodeURIComponent(path)}&token=${getAccessToken()}`;
 }
 
-export async function uploadFile(serverId: string, path: string, file: File, onProgress?: (loaded: number, total: number) => void, signal?: AbortSignal): Promise<ParsedResponse<void>> {
-  const formData = new FormData();
-  formData.append('file', file);
-  formData.append('path', path);
+export interface UploadSession { id: string; path: string; size: number; offset: number; chunk_size: number; }
+
+interface ChunkResult { status: number; offset: number | null; }
+
+const sleep = (ms: number) => new Promise(r => setTimeout(r, ms));
+
+async function chunkChecksum(chunk: Blob): Promise<string | null> {
+  if (!crypto?.subtle) return null;
+  const digest = await crypto.subtle.digest('SHA-256', await chunk.arrayBuffer());
+  return btoa(String.fromCharCode(...new Uint8Array(digest)));
+}
 
+function sendChunk(url: string, chunk: Blob, offset: number, checksum: string | null, onProgress: (loaded: number) => void, signal?: AbortSignal): Promise<ChunkResult> {
   return new Promise((resolve) => {
     const xhr = new XMLHttpRequest();
-    xhr.open('POST', `${API_BASE}/servers/${serverId}/files/upload`);
+    xhr.open('PATCH', url);
     xhr.setRequestHeader('Authorization', `Bearer ${getAccessToken()}`);
+    xhr.setRequestHeader('Content-Type', 'application/offset+octet-stream');
+    xhr.setRequestHeader('Upload-Offset', String(offset));
+    if (checksum) xhr.setRequestHeader('Upload-Checksum', `sha256 ${checksum}`);
     if (signal) signal.addEventListener('abort', () => xhr.abort());
-    xhr.upload.onprogress = (e) => { if (e.lengthComputable && onProgress) onProgress(e.loaded, e.total); };
+    xhr.upload.onprogress = (e) => { if (e.lengthComputable) onProgress(e.loaded); };
     xhr.onload = () => {
-      if (xhr.status >= 200 && xhr.status < 300) {
-        eventBus.emit('file:uploaded', { serverId, path: path + '/' + file.name });
-        resolve({ success: true });
-      } else {
-        resolve({ success: false, error: 'Upload failed' });
-      }
+      const header = xhr.getResponseHeader('Upload-Offset');
+      resolve({ status: xhr.status, offset: header ? Number(header) : null });
     };
-    xhr.onerror = () => resolve({ success: false, error: 'Upload failed' });
-    xhr.onabort = () => resolve({ success: false, error: 'Cancelled' });
-    xhr.send(formData);
+    xhr.onerror = () => resolve({ status: 0, offset: null });
+    xhr.onabort = () => resolve({ status: -1, offset: null });
+    xhr.send(chunk);
   });
 }
 
+async function uploadOffset(url: string): Promise<number | null> {
+  try {
+    const res = await fetch(url, { method: 'HEAD', headers: { Authorization: `Bearer ${getAccessToken()}` } });
+    const header = res.headers.get('Upload-Offset');
+    return res.ok && header ? Number(header) : null;
+  } catch {
+    return null;
+  }
+}
+
+// uploadFile sends a file in chunks through a resumable upload session. A
+// dropped chunk is retried from the offset the node reports, and the session
+// id is kept in localStorage so a reloaded page can pick up where it stopped.
+export async function uploadFile(serverId: string, path: string, file: File, onProgress?: (loaded: number, total: number) => void, signal?: AbortSignal): Promise<ParsedResponse<void>> {
+  const target = `${path === '/' ? '' : path}/${file.name}`;
+  const resumeKey = `upload:${serverId}:${target}:${file.size}:${file.lastModified}`;
+  const uploadsUrl = `${API_BASE}/servers/${serverId}/files/uploads`;
+
+  let session: Pick<UploadSession, 'id' | 'chunk_size'> | null = null;
+  let offset = 0;
+  const saved = localStorage.getItem(resumeKey);
+  if (saved) {
+    const { id, chunk_size } = JSON.parse(saved);
+    const current = await uploadOffset(`${uploadsUrl}/${id}`);
+    if (current !== null) { session = { id, chunk_size }; offset = current; }
+    else localStorage.removeItem(resumeKey);
+  }
+  if (!session) {
+    const created = await api.post<UploadSession>(`/servers/${serverId}/files/uploads`, { path: target, size: file.size });
+    if (!created.success || !created.data) return { success: false, error: created.error || 'Upload failed' };
+    session = { id: created.data.id, chunk_size: created.data.chunk_size };
+    localStorage.setItem(resumeKey, JSON.stringify(session));
+  }
+
+  const url = `${uploadsUrl}/${session.id}`;
+  let failures = 0;
+  onProgress?.(offset, file.size);
+  while (offset < file.size) {
+    const chunk = file.slice(offset, offset + session.chunk_size);
+    const start = offset;
+    const res = await sendChunk(url, chunk, start, await chunkChecksum(chunk), loaded => onProgress?.(start + loaded, file.size), signal);
+    if (res.status === -1 || signal?.aborted) {
+      localStorage.removeItem(resumeKey);
+      await api.delete(`/servers/${serverId}/files/uploads/${session.id}`);
+      return { success: false, error: 'Cancelled' };
+    }
+    if (res.status === 204 && res.offset !== null) {
+      offset = res.offset;
+      failures = 0;
+      continue;
+    }
+    if (res.status === 404) {
+      localStorage.removeItem(resumeKey);
+      return { success: false, error: 'Upload expired' };
+    }
+    if (++failures > 5) return { success: false, error: 'Upload failed' };
+    await sleep(1000 * failures);
+    offset = res.status === 409 && res.offset !== null ? res.offset : (await uploadOffset(url)) ?? offset;
+  }
+
+  const done = await api.post(`/servers/${serverId}/files/uploads/${session.id}/complete`);
+  localStorage.removeItem(resumeKey);
+  if (!done.success) return { success: false, error: done.error || 'Upload failed' };
+  eventBus.emit('file:uploaded', { serverId, path: target });
+  return { success: true };
+}
+
+export async function searchFileContents(serverId: string, query: string, onMatch: (match: ContentMatch) => void, signal?: AbortSignal): Promise<ParsedResponse<ContentSearchSummary>> {
+  try {
+    const res = await fetch(`${API_BASE}/servers/${serverId}/files/search?mode=content&q=${encodeURIComponent(query)}`, {
+      headers: { Authorization: `Bearer ${getAccessToken()}` },
+      signal,
+    });
+    if (!res.ok || !res.body) {
+      const json = await res.json().catch(() => null);
+      return { success: false, error: typeof json?.error === 'string' ? json.error : 'Search failed' };
+    }
+
+    const reader = res.body.getReader();
+    const decoder = new TextDecoder();
+    let buffer = '';
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (done) break;
+      buffer += decoder.decode(value, { stream: true });
+      const lines = buffer.split('\n');
+      buffer = lines.pop() || '';
+      for (const line of lines) {
+        if (!line.trim()) continue;
+        const { type, ...data } = JSON.parse(line);
+        if (type === 'match') onMatch(data as ContentMatch);
+        else if (type === 'done') {
+          const summary = data as ContentSearchSummary;
+          return summary.error ? { success: false, error: summary.error, data: summary } : { success: true, data: summary };
+        }
+      }
+    }
+    return { success: false, error: 'Search ended unexpectedly' };
+  } catch {
+    return { success: false, error: signal?.aborted ? 'Cancelled' : 'Search failed' };
+  }
+}
+
 export function connectServerLogs(serverId: string, onMessage: (msg: string) => void, onError?: (err: Event) => void): WebSocket {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
   const ws = new WebSocket(`${protocol}//${window.location.host}${API_BASE}/servers/${serverId}/logs?token=${getAccessToken()}`);
@@ -1,12 +1,12 @@
 export { api, request, API_BASE } from './client';
 export type { ParsedResponse } from './client';
 
-export { register, login, refresh, logout, getMe, getResources, updateProfile, sendEmailChangeCode, updatePassword, getSessions, revokeSession, revokeAllSessions, getAPIKeys, createAPIKey, deleteAPIKey, setup2FA, enable2FA, disable2FA, regenerateBackupCodes, verify2FA, requestPasswordReset, resetPassword, sendVerificationEmail, verifyEmail } from './auth';
-export type { Session, User, Resources, APIKey, APIKeyCreated, TwoFactorSetupData } from './auth';
+export { register, login, refresh, logout, getMe, getResources, updateProfile, sendEmailChangeCode, updatePassword, getSessions, revokeSession, revokeAllSessions, getAPIKeys, createAPIKey, deleteAPIKey, getSSHKeys, createSSHKey, deleteSSHKey, setup2FA, enable2FA, disable2FA, regenerateBackupCodes, verify2FA, requestPasswordReset, resetPassword, sendVerificationEmail, verifyEmail } from './auth';
+export type { Session, User, Resources, APIKey, APIKeyCreated, SSHKey, TwoFactorSetupData } from './auth';
 
-export { adminGetUsers, adminCreateUser, adminBanUsers, adminUnbanUsers, adminDeleteUsers, adminSetAdmin, adminRevokeAdmin, adminForcePasswordReset, adminDisable2FA, adminUpdateUser, adminGetNodes, adminRefreshNodes, adminCreateNode, adminGetNode, adminUpdateNode, adminDeleteNode, adminResetNodeToken, adminGetPairingCode, adminPairNode, adminGetServers, adminCreateServer, adminSuspendServers, adminUnsuspendServers, adminDeleteServers, adminUpdateServerResources, adminTransferServer, adminGetTransferStatus, adminGetAllTransfers, adminViewServer, adminGetPackages, adminCreatePackage, adminGetPackage, adminUpdatePackage, adminDeletePackage, adminGetRegistrationStatus, adminSetRegistrationStatus, adminGetServerCreationStatus, adminSetServerCreationStatus, adminGetUserAPIKeys, adminCreateUserAPIKey, adminDeleteUserAPIKey, adminGetEmailVerificationSettings, adminSetEmailVerificationSettings } from './admin';
+export { adminGetUsers, adminCreateUser, adminBanUsers, adminUnbanUsers, adminDeleteUsers, adminSetAdmin, adminRevokeAdmin, adminForcePasswordReset, adminDisable2FA, adminUpdateUser, adminGetNodes, adminRefreshNodes, adminCreateNode, adminGetNode, adminGetNodeMetrics, adminUpdateNode, adminDeleteNode, adminGetNodeAllocations, adminCreateNodeAllocations, adminUpdateNodeAllocation, adminDeleteNodeAllocation, adminGetLocations, adminCreateLocation, adminUpdateLocation, adminDeleteLocation, adminResetNodeToken, adminGetPairingCode, adminPairNode, adminGetServers, adminCreateServer, adminSuspendServers, adminUnsuspendServers, adminDeleteServers, adminUpdateServerResources, adminTransferServer, adminGetTransferStatus, adminGetAllTransfers, adminViewServer, adminGetPackages, adminCreatePackage, adminGetPackage, adminUpdatePackage, adminDeletePackage, adminGetRegistrationStatus, adminSetRegistrationStatus, adminGetServerCreationStatus, adminSetServerCreationStatus, adminGetUserAPIKeys, adminCreateUserAPIKey, adminDeleteUserAPIKey, adminGetEmailVerificationSettings, adminSetEmailVerificationSettings } from './admin';
 
-export type { PaginatedUsers, PaginatedServers, Node, NodeToken, TransferStatus } from './admin';
+export type { PaginatedUsers, PaginatedServers, Node, NodeToken, NodeLimits, PortAllocation, NodeAllocation, ResourceAllocation, LocationInput, TransferStatus } from './admin';
 
 export { adminGetLogs, getServerLogs } from './logs';
 export type { ActivityLog, PaginatedLogs } from './logs';
@@ -14,16 +14,16 @@ export type { ActivityLog, PaginatedLogs } from './logs';
 export { adminGetIPBans, adminCreateIPBan, adminDeleteIPBan } from './ipbans';
 export type { IPBan, PaginatedIPBans } from './ipbans';
 
-export { getAvailableNodes, getAvailablePackages } from './packages';
-export type { Package, PackagePort, PackageVariable, PackageConfigFile, AddonSource, AddonSourceMapping } from './packages';
+export { getAvailableNodes, getAvailableLocations, getAvailablePackages } from './packages';
+export type { Location, Package, PackagePort, PackageVariable, PackageConfigFile, PackageBackupHook, PackageBackupHooks, CrashPolicy, AddonSource, AddonSourceMapping } from './packages';
 
-export { getServers, getServer, getServerStatus, getServerPermissions, createServer, startServer, stopServer, restartServer, killServer, reinstallServer, deleteServer, addAllocation, setPrimaryAllocation, deleteAllocation, updateServerResources, updateServerName, updateServerVariables, getSFTPDetails, resetSFTPPassword, getServerMounts, mountServerMount, unmountServerMount } from './servers';
-export type { Server, ServerStatusResponse, SFTPDetails, SFTPPasswordReset, ServerMountResponse } from './servers';
+export { getServers, getServer, getServerStatus, getServerMetrics, getServerPermissions, createServer, startServer, stopServer, restartServer, killServer, reinstallServer, deleteServer, addAllocation, setPrimaryAllocation, deleteAllocation, updateServerResources, updateServerName, updateServerVariables, getServerCrashes, updateServerCrashPolicy, getSFTPDetails, getServerMounts, mountServerMount, unmountServerMount } from './servers';
+export type { Server, ServerStatusResponse, MetricSample, MetricRange, ServerCrash, ServerCrashHistory, SFTPDetails, ServerMountResponse } from './servers';
 
-export { listFiles, readFile, searchFiles, deleteFile, bulkDeleteFiles, bulkCopyFiles, bulkCompressFiles, moveFile, copyFile, compressFile, decompressFile, createFolder, writeFile, getDownloadUrl, uploadFile, connectServerLogs } from './files';
-export type { FileEntry, SearchResult } from './files';
+export { listFiles, readFile, searchFiles, searchFileContents, deleteFile, bulkDeleteFiles, bulkCopyFiles, bulkCompressFiles, moveFile, copyFile, compressFile, decompressFile, createFolder, writeFile, getDownloadUrl, uploadFile, connectServerLogs } from './files';
+export type { FileEntry, SearchResult, ContentMatch, ContentSearchSummary, UploadSession } from './files';
 
-export { listBackups, createBackup, deleteBackup, restoreBackup, getBackupDownloadUrl } from './backups';
+export { listBackups, createBackup, deleteBackup, lockBackup, unlockBackup, restoreBackup, listBackupFiles, getBackupDownloadUrl } from './backups';
 export type { Backup } from './backups';
 
 export { getSubusers, addSubuser, updateSubuser, removeSubuser } from './subusers';
@@ -4,6 +4,9 @@ import type { Node } from './admin';
 export interface PackagePort { name: string; default: number; protocol: string; primary?: boolean; }
 export interface PackageVariable { name: string; description: string; default: string; user_editable: boolean; rules?: string; }
 export interface PackageConfigFile { path: string; template: string; }
+export interface PackageBackupHook { command: string; wait_for?: string; timeout?: number; }
+export interface PackageBackupHooks { pre: PackageBackupHook[]; post: PackageBackupHook[]; }
+export interface CrashPolicy { max_restarts: number; window: number; backoff: number; }
 
 export interface AddonSourceMapping {
   results?: string;
@@ -33,6 +36,8 @@ export interface AddonSource {
   mapping: AddonSourceMapping;
 }
 
+export interface Location { id: string; name: string; description: string; region: string; tags: string[]; created_at: string; updated_at: string; }
+
 export interface Package {
   id: string; name: string; version: string; author: string; description: string; icon?: string;
   docker_image: string; install_image: string; startup: string; install_script: string;
@@ -40,8 +45,12 @@ export interface Package {
   startup_editable: boolean; docker_image_editable: boolean;
   ports: PackagePort[]; variables: PackageVariable[]; config_files: PackageConfigFile[];
   addon_sources?: AddonSource[];
+  backup_target_id?: string | null; backup_ignore?: string; backup_hooks?: Partial<PackageBackupHooks>;
+  crash_policy?: CrashPolicy | null;
+  allowed_locations?: string[];
   created_at: string; updated_at: string;
 }
 
 export const getAvailableNodes = () => api.get<Node[]>('/nodes');
+export const getAvailableLocations = () => api.get<Location[]>('/locations');
 export const getAvailablePackages = () => api.get<Package[]>('/packages');
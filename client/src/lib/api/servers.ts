@@ -1,13 +1,13 @@
 import { api } from './client';
 import { eventBus } from '../eventBus';
-import type { Package } from './packages';
+import type { Package, CrashPolicy } from './packages';
 
 export interface Server {
   id: string; name: string; description: string; user_id: string; node_id: string; package_id: string;
   status: 'installing' | 'running' | 'stopped' | 'suspended' | 'failed';
-  is_suspended: boolean; memory: number; cpu: number; disk: number;
+  is_suspended: boolean; health?: string; memory: number; cpu: number; disk: number;
   startup: string; docker_image: string;
-  ports: { port: number; primary?: boolean }[];
+  ports: { port: number; primary?: boolean; ip?: string; alias?: string }[];
   variables: Record<string, string>;
   created_at: string; updated_at: string;
   user?: { id: string; username: string; email: string };
@@ -27,11 +27,29 @@ export interface ServerStatusResponse {
   };
 }
 
+export interface MetricSample {
+  time: number;
+  cpu_percent: number;
+  memory_usage: number;
+  memory_limit: number;
+  disk_usage: number;
+  disk_limit: number;
+  net_rx: number;
+  net_tx: number;
+}
+
+export interface MetricRange {
+  range: number;
+  resolution: number;
+  samples: MetricSample[];
+}
+
 export const getServers = () => api.get<Server[]>('/servers/');
 export const getServer = (id: string) => api.get<Server>(`/servers/${id}`);
 export const getServerStatus = (id: string) => api.get<ServerStatusResponse>(`/servers/${id}/status`);
+export const getServerMetrics = (id: string, range = '1h') => api.get<MetricRange>(`/servers/${id}/metrics?range=${encodeURIComponent(range)}`);
 export const getServerPermissions = (id: string) => api.get<string[]>(`/servers/${id}/permissions`);
-export const createServer = (data: { name: string; description?: string; node_id: string; package_id: string; memory: number; cpu: number; disk: number; ports: { port: number; primary?: boolean }[]; variables: Record<string, string> }) => api.post<Server>('/servers/', data);
+export const createServer = (data: { name: string; description?: string; node_id?: string; location_id?: string; strategy?: string; package_id: string; memory: number; cpu: number; disk: number; ports: { port: number; primary?: boolean }[]; variables: Record<string, string> }) => api.post<Server>('/servers/', data);
 
 export const startServer = async (id: string) => {
   const result = await api.post(`/servers/${id}/start`);
@@ -72,12 +90,25 @@ export interface SFTPDetails {
   username: string;
 }
 
-export interface SFTPPasswordReset {
-  password: string;
+export interface ServerCrash {
+  id: string;
+  exit_code: number;
+  oom: boolean;
+  logs: string;
+  action: 'none' | 'restarted' | 'failed';
+  created_at: string;
+}
+
+export interface ServerCrashHistory {
+  policy: CrashPolicy;
+  custom: boolean;
+  crashes: ServerCrash[];
 }
 
+export const getServerCrashes = (serverId: string) => api.get<ServerCrashHistory>(`/servers/${serverId}/crashes`);
+export const updateServerCrashPolicy = (serverId: string, policy: CrashPolicy | null) => api.put(`/servers/${serverId}/crash-policy`, { policy });
+
 export const getSFTPDetails = (serverId: string) => api.get<SFTPDetails>(`/servers/${serverId}/sftp`);
-export const resetSFTPPassword = (serverId: string) => api.post<SFTPPasswordReset>(`/servers/${serverId}/sftp/password`);
 
 export interface ServerMountResponse {
   id: string;
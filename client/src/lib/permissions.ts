@@ -44,7 +44,6 @@ export const Permissions = {
   ACTIVITY_VIEW: 'activity.view',
 
   SFTP_VIEW: 'sftp.view',
-  SFTP_RESET_PASSWORD: 'sftp.reset_password',
 
   ADMIN: '*',
 } as const;
@@ -61,7 +60,7 @@ export const PermissionGroups = {
   settings: [Permissions.SETTINGS_VIEW, Permissions.SETTINGS_RENAME, Permissions.SETTINGS_RESOURCES],
   server: [Permissions.REINSTALL],
   activity: [Permissions.ACTIVITY_VIEW],
-  sftp: [Permissions.SFTP_VIEW, Permissions.SFTP_RESET_PASSWORD],
+  sftp: [Permissions.SFTP_VIEW],
 };
 
 export const PermissionLabels: Record<string, string> = {
@@ -101,7 +100,6 @@ export const PermissionLabels: Record<string, string> = {
   [Permissions.REINSTALL]: 'Reinstall Server',
   [Permissions.ACTIVITY_VIEW]: 'View Activity Log',
   [Permissions.SFTP_VIEW]: 'View SFTP Details',
-  [Permissions.SFTP_RESET_PASSWORD]: 'Reset SFTP Password',
 };
 
 export function hasPermission(permissions: string[], required: string): boolean {
@@ -1,7 +1,7 @@
 import { useEffect, useState } from 'react';
 import { Routes, Route } from 'react-router-dom';
 import { getUser, setUser } from '../../lib/auth';
-import { updateProfile, sendEmailChangeCode, updatePassword, getSessions, revokeSession, revokeAllSessions, getAPIKeys, createAPIKey, deleteAPIKey, setup2FA, enable2FA, disable2FA, regenerateBackupCodes, logout, type APIKey, type APIKeyCreated } from '../../lib/api';
+import { updateProfile, sendEmailChangeCode, updatePassword, getSessions, revokeSession, revokeAllSessions, getAPIKeys, createAPIKey, deleteAPIKey, getSSHKeys, createSSHKey, deleteSSHKey, setup2FA, enable2FA, disable2FA, regenerateBackupCodes, logout, type APIKey, type APIKeyCreated, type SSHKey } from '../../lib/api';
 import { formatDate, parseUserAgent } from '../../lib/utils';
 import { notify, Input, Button, Icons, Modal, SlidePanel } from '../../components';
 import { SubNavigation } from '../../components/layout/SubNavigation';
@@ -23,6 +23,7 @@ const settingsTabs = [
   { name: 'Security', path: '/security', icon: 'shield' },
   { name: 'Sessions', path: '/sessions', icon: 'monitor' },
   { name: 'API Keys', path: '/api-keys', icon: 'key' },
+  { name: 'SSH Keys', path: '/ssh-keys', icon: 'key' },
 ];
 
 function SectionCard({ title, description, children, footer }: {
@@ -633,6 +634,159 @@ function APIKeysTab() {
   );
 }
 
+function SSHKeysTab() {
+  const [sshKeys, setSshKeys] = useState<SSHKey[]>([]);
+  const [sshKeysLoading, setSshKeysLoading] = useState(true);
+  const [createPanel, setCreatePanel] = useState({ open: false, loading: false, name: '', publicKey: '' });
+  const [deleteModal, setDeleteModal] = useState<{ key: SSHKey; loading: boolean } | null>(null);
+
+  const loadSshKeys = async () => {
+    setSshKeysLoading(true);
+    const res = await getSSHKeys();
+    if (res.success && res.data) setSshKeys(res.data);
+    setSshKeysLoading(false);
+  };
+
+  useEffect(() => { loadSshKeys(); }, []);
+
+  const handleCreateSshKey = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setCreatePanel(m => ({ ...m, loading: true }));
+    const res = await createSSHKey(createPanel.name, createPanel.publicKey);
+    if (res.success) {
+      notify('Added', 'SSH key added', 'success');
+      setCreatePanel({ open: false, loading: false, name: '', publicKey: '' });
+      loadSshKeys();
+    } else {
+      notify('Error', res.error || 'Failed to add SSH key', 'error');
+      setCreatePanel(m => ({ ...m, loading: false }));
+    }
+  };
+
+  const handleDeleteSshKey = async () => {
+    if (!deleteModal) return;
+    setDeleteModal(m => m && { ...m, loading: true });
+    const res = await deleteSSHKey(deleteModal.key.id);
+    if (res.success) {
+      notify('Deleted', 'SSH key deleted', 'success');
+      setDeleteModal(null);
+      loadSshKeys();
+    } else {
+      notify('Error', res.error || 'Failed to delete', 'error');
+      setDeleteModal(m => m && { ...m, loading: false });
+    }
+  };
+
+  return (
+    <div className="max-w-3xl space-y-6">
+      <div className="flex items-center justify-between">
+        <div>
+          <h3 className="text-base font-semibold text-neutral-100">SSH Keys</h3>
+          <p className="text-sm text-neutral-400 mt-0.5">Public keys you can use to sign in to SFTP instead of a password.</p>
+        </div>
+        <Button onClick={() => setCreatePanel(m => ({ ...m, open: true }))}>
+          <Icons.plus className="w-4 h-4 mr-1.5" />Add Key
+        </Button>
+      </div>
+
+      {sshKeysLoading ? (
+        <div className="py-12 text-center text-sm text-neutral-500">Loading...</div>
+      ) : sshKeys.length === 0 ? (
+        <div className="rounded-xl border border-dashed border-neutral-800 py-12 text-center">
+          <div className="inline-flex items-center justify-center w-10 h-10 rounded-full bg-neutral-800/60 mb-3">
+            <Icons.key className="w-5 h-5 text-neutral-600" />
+          </div>
+          <p className="text-sm text-neutral-400">No SSH keys yet.</p>
+          <button
+            onClick={() => setCreatePanel(m => ({ ...m, open: true }))}
+            className="mt-2 inline-flex items-center gap-1 text-xs font-medium border border-neutral-800 rounded-lg px-2 py-1.5 text-neutral-400 hover:text-neutral-100 transition-colors"
+          >
+            <Icons.plus className="h-3.5 w-3.5" />
+            <span className="text-sm font-medium">Add your first key</span>
+          </button>
+        </div>
+      ) : (
+        <div className="space-y-3">
+          {sshKeys.map(k => (
+            <div key={k.id} className="flex items-center gap-4 p-4 rounded-xl border border-neutral-800 bg-neutral-900/30 hover:border-neutral-700 transition-colors">
+              <div className="flex items-center justify-center w-9 h-9 rounded-lg bg-neutral-800 shrink-0">
+                <Icons.key className="w-4 h-4 text-neutral-400" />
+              </div>
+              <div className="flex-1 min-w-0">
+                <div className="text-sm font-medium text-neutral-100">{k.name}</div>
+                <div className="mt-0.5 flex items-center gap-3 text-xs text-neutral-500">
+                  <span className="font-mono truncate">{k.fingerprint}</span>
+                  <span className="w-1 h-1 rounded-full bg-neutral-700" />
+                  <span>Added {new Date(k.created_at).toLocaleDateString()}</span>
+                  {k.last_used_at && (
+                    <>
+                      <span className="w-1 h-1 rounded-full bg-neutral-700" />
+                      <span>Last used {new Date(k.last_used_at).toLocaleString()}</span>
+                    </>
+                  )}
+                </div>
+              </div>
+              <button
+                onClick={() => setDeleteModal({ key: k, loading: false })}
+                className="shrink-0 rounded-lg px-3 py-1.5 text-xs font-medium text-red-400 hover:bg-red-500/10 transition-colors"
+              >
+                Delete
+              </button>
+            </div>
+          ))}
+        </div>
+      )}
+
+      <SlidePanel
+        open={createPanel.open}
+        onClose={() => !createPanel.loading && setCreatePanel(m => ({ ...m, open: false }))}
+        title="Add SSH Key"
+        description="Register a public key for SFTP access to your servers."
+        footer={
+          <div className="flex justify-end gap-3">
+            <Button variant="ghost" onClick={() => setCreatePanel(m => ({ ...m, open: false }))} disabled={createPanel.loading}>Cancel</Button>
+            <Button type="submit" form="sshKeyForm" loading={createPanel.loading} disabled={!createPanel.publicKey.trim()}>
+              <Icons.key className="w-4 h-4 mr-1.5" />Add Key
+            </Button>
+          </div>
+        }
+      >
+        <form id="sshKeyForm" onSubmit={handleCreateSshKey} className="space-y-6">
+          <div className="space-y-2">
+            <label className="text-sm font-medium text-neutral-200">Key Name</label>
+            <Input
+              placeholder="e.g. Laptop, Deploy Server..."
+              value={createPanel.name}
+              onChange={e => setCreatePanel(m => ({ ...m, name: e.target.value }))}
+            />
+            <p className="text-xs text-neutral-500">Defaults to the comment at the end of the key.</p>
+          </div>
+
+          <div className="space-y-2">
+            <label className="text-sm font-medium text-neutral-200">Public Key</label>
+            <textarea
+              placeholder="ssh-ed25519 AAAA... user@host"
+              value={createPanel.publicKey}
+              onChange={e => setCreatePanel(m => ({ ...m, publicKey: e.target.value }))}
+              rows={5}
+              className="w-full rounded-lg border border-neutral-800/60 bg-neutral-900/60 text-neutral-100 placeholder:text-neutral-500 transition hover:border-neutral-500 focus:outline-none focus:ring-2 focus:ring-neutral-100 focus:ring-offset-2 focus:ring-offset-neutral-950 px-3 py-2 text-sm font-mono resize-none"
+              spellCheck={false}
+            />
+            <p className="text-xs text-neutral-500">Paste the contents of your <span className="font-mono">.pub</span> file.</p>
+          </div>
+        </form>
+      </SlidePanel>
+
+      <Modal open={!!deleteModal} onClose={() => !deleteModal?.loading && setDeleteModal(null)} title="Delete SSH Key" description={`Delete "${deleteModal?.key.name}"? SFTP logins with this key will stop working.`}>
+        <div className="flex justify-end gap-3 pt-4">
+          <Button variant="ghost" onClick={() => setDeleteModal(null)} disabled={deleteModal?.loading}>Cancel</Button>
+          <Button onClick={handleDeleteSshKey} loading={deleteModal?.loading} variant="danger">Delete</Button>
+        </div>
+      </Modal>
+    </div>
+  );
+}
+
 export default function SettingsPage() {
   const basePath = '/console/settings';
 
@@ -659,6 +813,7 @@ export default function SettingsPage() {
         <Route path="security" element={<SecurityTab />} />
         <Route path="sessions" element={<SessionsTab />} />
         <Route path="api-keys" element={<APIKeysTab />} />
+        <Route path="ssh-keys" element={<SSHKeysTab />} />
         {pluginTabs.map(tab => (
           <Route
             key={tab.id}
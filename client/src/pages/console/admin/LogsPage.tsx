@@ -25,6 +25,7 @@ const actionLabels: Record<string, string> = {
   'server.name.update': 'Rename Server',
   'server.resources.update': 'Update Resources',
   'server.variables.update': 'Update Variables',
+  'server.crash_policy.update': 'Update Crash Policy',
   'server.allocation.add': 'Add Allocation',
   'server.allocation.set_primary': 'Set Primary Allocation',
   'server.allocation.delete': 'Delete Allocation',
@@ -39,6 +40,10 @@ const actionLabels: Record<string, string> = {
   'server.file.bulk_delete': 'Bulk Delete Files',
   'server.file.bulk_copy': 'Bulk Copy Files',
   'server.file.bulk_compress': 'Bulk Compress Files',
+  'server.sftp.write': 'Write File (SFTP)',
+  'server.sftp.delete': 'Delete File (SFTP)',
+  'server.sftp.rename': 'Rename File (SFTP)',
+  'server.sftp.create_folder': 'Create Folder (SFTP)',
   'server.backup.create': 'Create Backup',
   'server.backup.delete': 'Delete Backup',
   'server.subuser.add': 'Add Subuser',
@@ -103,7 +108,7 @@ const getActionIcon = (action: string) => {
   if (action.includes('stop') || action.includes('kill')) return <Icons.stopFilled className="w-4 h-4 text-red-400" />;
   if (action.includes('restart')) return <Icons.refresh className="w-4 h-4 text-amber-400" />;
   if (action.includes('reinstall')) return <Icons.refresh className="w-4 h-4 text-orange-400" />;
-  if (action.includes('file') || action.includes('folder')) return <Icons.folder className="w-4 h-4 text-amber-500" />;
+  if (action.includes('file') || action.includes('folder') || action.includes('sftp')) return <Icons.folder className="w-4 h-4 text-amber-500" />;
   if (action.includes('backup')) return <Icons.archive className="w-4 h-4 text-blue-400" />;
   if (action.includes('subuser')) return <Icons.users className="w-4 h-4 text-violet-400" />;
   if (action.includes('database')) return <Icons.database className="w-4 h-4 text-violet-400" />;
@@ -1,16 +1,28 @@
 import { useEffect, useState, useMemo } from 'react';
 import { startLoading, finishLoading } from '../../../lib/pageLoader';
-import { adminGetNodes, adminCreateNode, adminDeleteNode, adminResetNodeToken, adminRefreshNodes, adminGetPairingCode, adminPairNode, adminUpdateNode } from '../../../lib/api';
+import { adminGetNodes, adminCreateNode, adminDeleteNode, adminResetNodeToken, adminRefreshNodes, adminGetPairingCode, adminPairNode, adminUpdateNode, adminGetLocations, adminCreateLocation, adminDeleteLocation, adminGetNodeAllocations, adminCreateNodeAllocations, adminDeleteNodeAllocation, type Location, type PortAllocation, type NodeLimits, type NodeAllocation, type ResourceAllocation } from '../../../lib/api';
 import { notify, Button, Input, Modal, SlidePanel, Icons, ContextMenu, Table, Pagination } from '../../../components';
 
-interface Node {
-  id: string; name: string; fqdn: string; port: number; is_online: boolean; auth_error: boolean; last_heartbeat: string | null; icon?: string;
+interface Node extends NodeLimits {
+  id: string; name: string; fqdn: string; port: number; scheme: 'http' | 'https'; tunnel: boolean; is_online: boolean; auth_error: boolean; last_heartbeat: string | null; icon?: string; location_id?: string | null;
   system_info: { hostname: string; os: { name: string; version: string; kernel: string; arch: string }; cpu: { cores: number; usage_percent: number }; memory: { total_bytes: number; used_bytes: number; available_bytes: number; usage_percent: number }; disk: { total_bytes: number; used_bytes: number; available_bytes: number; usage_percent: number }; uptime_seconds: number };
+  allocation?: NodeAllocation;
   created_at: string;
 }
 
 interface NodeToken { token_id: string; token: string; }
 type Filter = 'all' | 'online' | 'offline';
+type LimitForm = Record<keyof NodeLimits, string>;
+
+const limitFields: { key: keyof NodeLimits; label: string }[] = [
+  { key: 'memory_limit', label: 'Memory Limit (MB)' }, { key: 'memory_overallocate', label: 'Memory Overallocation (%)' },
+  { key: 'disk_limit', label: 'Disk Limit (MB)' }, { key: 'disk_overallocate', label: 'Disk Overallocation (%)' },
+  { key: 'cpu_limit', label: 'CPU Limit (%)' }, { key: 'cpu_overallocate', label: 'CPU Overallocation (%)' },
+];
+const emptyLimits: LimitForm = { memory_limit: '0', memory_overallocate: '0', disk_limit: '0', disk_overallocate: '0', cpu_limit: '0', cpu_overallocate: '0' };
+const limitsOf = (node: Node): LimitForm => Object.fromEntries(limitFields.map(f => [f.key, String(node[f.key] ?? 0)])) as LimitForm;
+
+const formatAllocation = (r: ResourceAllocation, unit: string) => r.limit > 0 ? `${r.allocated} / ${r.limit} ${unit}` : `${r.allocated} ${unit}`;
 
 const formatTimeAgo = (date: string) => {
   const s = Math.floor((Date.now() - new Date(date).getTime()) / 1000);
@@ -40,7 +52,10 @@ export default function NodesPage() {
   const [pairModal, setPairModal] = useState({ open: false, loading: false, name: '', fqdn: '', port: '8443', code: '', stage: 'form' as 'form' | 'waiting', error: '', icon: '' });
   const [tokenModal, setTokenModal] = useState<{ node: Node; token: NodeToken } | null>(null);
   const [deleteModal, setDeleteModal] = useState<{ node: Node; loading: boolean } | null>(null);
-  const [editModal, setEditModal] = useState<{ open: boolean; loading: boolean; node: Node | null; name: string; icon: string }>({ open: false, loading: false, node: null, name: '', icon: '' });
+  const [editModal, setEditModal] = useState<{ open: boolean; loading: boolean; node: Node | null; name: string; icon: string; locationId: string; limits: LimitForm }>({ open: false, loading: false, node: null, name: '', icon: '', locationId: '', limits: emptyLimits });
+  const [locations, setLocations] = useState<{ location: Location; nodes_count: number }[]>([]);
+  const [locationModal, setLocationModal] = useState({ open: false, loading: false, name: '', region: '' });
+  const [allocationModal, setAllocationModal] = useState<{ node: Node; allocations: PortAllocation[]; loading: boolean; ip: string; ports: string; alias: string } | null>(null);
   const [expanded, setExpanded] = useState<Set<string>>(new Set());
 
   const filtered = useMemo(() => {
@@ -59,7 +74,61 @@ export default function NodesPage() {
 
   const totalPages = Math.ceil(filtered.length / ui.perPage) || 1;
 
-  useEffect(() => { startLoading(); loadNodes(true); }, []);
+  useEffect(() => { startLoading(); loadNodes(true); loadLocations(); }, []);
+
+  const loadLocations = async () => {
+    const res = await adminGetLocations();
+    if (res.success && res.data) setLocations(res.data);
+  };
+
+  const handleCreateLocation = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setLocationModal(s => ({ ...s, loading: true }));
+    const res = await adminCreateLocation({ name: locationModal.name, description: '', region: locationModal.region, tags: [] });
+    if (res.success) {
+      notify('Location created', `${locationModal.name} has been added`, 'success');
+      setLocationModal(s => ({ ...s, loading: false, name: '', region: '' }));
+      loadLocations();
+    } else {
+      notify('Failed', res.error || 'Could not create location', 'error');
+      setLocationModal(s => ({ ...s, loading: false }));
+    }
+  };
+
+  const openAllocations = async (node: Node) => {
+    setAllocationModal({ node, allocations: [], loading: true, ip: '0.0.0.0', ports: '', alias: '' });
+    const res = await adminGetNodeAllocations(node.id);
+    setAllocationModal(s => s && { ...s, loading: false, allocations: res.success && res.data ? res.data : [] });
+  };
+
+  const handleCreateAllocations = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!allocationModal) return;
+    const { node, ip, alias } = allocationModal;
+    const ports = allocationModal.ports.split(',').map(p => p.trim()).filter(Boolean);
+    setAllocationModal(s => s && { ...s, loading: true });
+    const res = await adminCreateNodeAllocations(node.id, { ip, ports, alias });
+    if (res.success) {
+      notify('Allocations added', `${res.data?.length ?? 0} ports added to ${node.name}`, 'success');
+      openAllocations(node);
+    } else {
+      notify('Failed', res.error || 'Could not add allocations', 'error');
+      setAllocationModal(s => s && { ...s, loading: false });
+    }
+  };
+
+  const handleDeleteAllocation = async (allocation: PortAllocation) => {
+    if (!allocationModal) return;
+    const res = await adminDeleteNodeAllocation(allocationModal.node.id, allocation.id);
+    if (res.success) setAllocationModal(s => s && { ...s, allocations: s.allocations.filter(a => a.id !== allocation.id) });
+    else notify('Failed', res.error || 'Could not delete allocation', 'error');
+  };
+
+  const handleDeleteLocation = async (location: Location) => {
+    const res = await adminDeleteLocation(location.id);
+    res.success ? notify('Location deleted', `${location.name} has been removed`, 'success') : notify('Failed', res.error || 'Could not delete location', 'error');
+    loadLocations();
+  };
 
   const loadNodes = async (initial = false) => {
     if (!initial) setUi(s => ({ ...s, refreshing: true }));
@@ -87,10 +156,12 @@ export default function NodesPage() {
     e.preventDefault();
     if (!editModal.node) return;
     setEditModal(s => ({ ...s, loading: true }));
-    const res = await adminUpdateNode(editModal.node.id, { name: editModal.name, icon: editModal.icon });
+    const limits = Object.fromEntries(limitFields.map(f => [f.key, parseInt(editModal.limits[f.key]) || 0])) as NodeLimits;
+    const res = await adminUpdateNode(editModal.node.id, { name: editModal.name, icon: editModal.icon, location_id: editModal.locationId, ...limits });
     if (res.success) {
       notify('Updated', 'Node updated successfully', 'success');
-      setEditModal({ open: false, loading: false, node: null, name: '', icon: '' });
+      setEditModal({ open: false, loading: false, node: null, name: '', icon: '', locationId: '', limits: emptyLimits });
+      loadLocations();
       loadNodes();
     } else {
       notify('Failed', res.error || 'Could not update node', 'error');
@@ -146,7 +217,8 @@ export default function NodesPage() {
   if (!ui.ready) return null;
 
   const getNodeActions = (node: Node) => [
-    { label: 'Edit', onClick: () => setEditModal({ open: true, loading: false, node, name: node.name, icon: node.icon || '' }) },
+    { label: 'Edit', onClick: () => setEditModal({ open: true, loading: false, node, name: node.name, icon: node.icon || '', locationId: node.location_id || '', limits: limitsOf(node) }) },
+    { label: 'Allocations', onClick: () => openAllocations(node) },
     { label: 'Reset Token', onClick: () => handleResetToken(node) },
     { label: 'Delete', onClick: () => setDeleteModal({ node, loading: false }), variant: 'danger' as const },
   ];
@@ -171,7 +243,7 @@ export default function NodesPage() {
           )}
           <div>
             <div className="text-sm font-medium text-neutral-100">{node.name}</div>
-            <div className="text-xs text-neutral-500">{node.fqdn}:{node.port}</div>
+            <div className="text-xs text-neutral-500">{node.tunnel ? `${node.fqdn} (tunnel)` : `${node.scheme}://${node.fqdn}:${node.port}`}</div>
           </div>
         </div>
       )
@@ -203,6 +275,14 @@ export default function NodesPage() {
         return hasInfo ? <UsageBar value={node.system_info.disk.usage_percent} color={node.system_info.disk.usage_percent > 80 ? 'bg-red-400' : 'bg-amber-400'} /> : <span className="text-xs text-neutral-600">{"\u2014"}</span>;
       }
     },
+    {
+      key: 'allocated', header: 'Allocated', render: (node: Node) => node.allocation ? (
+        <div className="text-xs text-neutral-400 leading-5">
+          <div>{node.allocation.servers} servers</div>
+          <div className={node.allocation.memory.limit > 0 && node.allocation.memory.allocated > node.allocation.memory.limit ? 'text-amber-400' : ''}>{formatAllocation(node.allocation.memory, 'MB')}</div>
+        </div>
+      ) : <span className="text-xs text-neutral-600">{"\u2014"}</span>
+    },
     { key: 'lastseen', header: 'Last Seen', render: (node: Node) => <span className="text-sm text-neutral-400">{node.last_heartbeat ? formatTimeAgo(node.last_heartbeat) : 'Never'}</span> },
     {
       key: 'actions', header: 'Actions', align: 'right' as const, render: (node: Node) => (
@@ -221,6 +301,7 @@ export default function NodesPage() {
         <div className="flex flex-col sm:flex-row sm:items-center justify-between gap-4">
           <div><h1 className="text-xl font-semibold text-neutral-100">Nodes</h1><p className="text-sm text-neutral-400">Manage server nodes running Birdactyl Axis.</p></div>
           <div className="flex items-center gap-2">
+            <Button variant="secondary" onClick={() => setLocationModal(s => ({ ...s, open: true }))} className="flex-1 sm:flex-none">Locations</Button>
             <Button variant="secondary" onClick={() => setPairModal(s => ({ ...s, open: true }))} className="flex-1 sm:flex-none">Pair Node</Button>
             <Button onClick={() => setCreateModal(s => ({ ...s, open: true }))} className="flex-1 sm:flex-none"><Icons.plus className="w-4 h-4" />Add Node</Button>
           </div>
@@ -298,7 +379,7 @@ export default function NodesPage() {
         </form>
       </Modal>
 
-      <Modal open={editModal.open} onClose={() => !editModal.loading && setEditModal(s => ({ ...s, open: false }))} title="Edit Node" description="Update node name and icon.">
+      <Modal open={editModal.open} onClose={() => !editModal.loading && setEditModal(s => ({ ...s, open: false }))} title="Edit Node" description="Update node name, icon and resource limits.">
         <form onSubmit={handleEdit} className="space-y-4">
           <Input label="Name" placeholder="us-east-1" value={editModal.name} onChange={e => setEditModal(s => ({ ...s, name: e.target.value }))} required />
           <Input label="Icon URL (optional)" placeholder="https://example.com/flag.png" value={editModal.icon} onChange={e => setEditModal(s => ({ ...s, icon: e.target.value }))} />
@@ -308,6 +389,29 @@ export default function NodesPage() {
               <img src={editModal.icon} alt="" className="w-8 h-8 rounded-lg object-cover" onError={e => (e.target as HTMLImageElement).style.display = 'none'} />
             </div>
           )}
+          <div>
+            <label className="block text-xs font-medium text-neutral-400 mb-1.5">Location</label>
+            <ContextMenu
+              align="start"
+              className="w-full"
+              trigger={
+                <button type="button" className="w-full flex items-center justify-between px-3 py-2 text-[13px] rounded-lg border border-neutral-800 bg-neutral-800/80 text-left hover:border-neutral-500 transition-colors">
+                  <span className="truncate text-neutral-100">{locations.find(l => l.location.id === editModal.locationId)?.location.name || 'No location'}</span>
+                  <Icons.selector className="w-4 h-4 text-neutral-500 flex-shrink-0 ml-2" />
+                </button>
+              }
+              items={[
+                { label: 'No location', onClick: () => setEditModal(s => ({ ...s, locationId: '' })) },
+                ...locations.map(({ location }) => ({ label: location.name, onClick: () => setEditModal(s => ({ ...s, locationId: location.id })) })),
+              ]}
+            />
+          </div>
+          <div className="grid grid-cols-2 gap-3">
+            {limitFields.map(f => (
+              <Input key={f.key} label={f.label} type="number" min={f.key.endsWith('overallocate') ? -1 : 0} value={editModal.limits[f.key]} onChange={e => setEditModal(s => ({ ...s, limits: { ...s.limits, [f.key]: e.target.value } }))} />
+            ))}
+          </div>
+          <p className="text-xs text-neutral-500">A limit of 0 disables placement checks. Overallocation of -1 only warns when a limit is exceeded.</p>
           <div className="flex justify-end gap-3 pt-4">
             <Button variant="ghost" onClick={() => setEditModal(s => ({ ...s, open: false }))} disabled={editModal.loading}>Cancel</Button>
             <Button type="submit" loading={editModal.loading}>Save</Button>
@@ -315,6 +419,61 @@ export default function NodesPage() {
         </form>
       </Modal>
 
+      <Modal open={locationModal.open} onClose={() => !locationModal.loading && setLocationModal(s => ({ ...s, open: false }))} title="Locations" description="Group nodes by location so servers can be placed automatically.">
+        <div className="space-y-4">
+          {locations.length === 0 ? <p className="text-sm text-neutral-500">No locations yet.</p> : (
+            <div className="divide-y divide-neutral-800 rounded-lg border border-neutral-800">
+              {locations.map(({ location, nodes_count }) => (
+                <div key={location.id} className="flex items-center justify-between px-3 py-2 text-sm">
+                  <div>
+                    <span className="text-neutral-100">{location.name}</span>
+                    {location.region && <span className="text-neutral-500 ml-2">{location.region}</span>}
+                    <span className="text-neutral-500 ml-2">{nodes_count} node{nodes_count === 1 ? '' : 's'}</span>
+                  </div>
+                  <Button variant="ghost" onClick={() => handleDeleteLocation(location)} disabled={nodes_count > 0}>Delete</Button>
+                </div>
+              ))}
+            </div>
+          )}
+          <form onSubmit={handleCreateLocation} className="grid grid-cols-2 gap-3">
+            <Input label="Name" placeholder="Frankfurt" value={locationModal.name} onChange={e => setLocationModal(s => ({ ...s, name: e.target.value }))} required />
+            <Input label="Region (optional)" placeholder="eu-central" value={locationModal.region} onChange={e => setLocationModal(s => ({ ...s, region: e.target.value }))} />
+            <div className="col-span-2 flex justify-end">
+              <Button type="submit" loading={locationModal.loading}>Add Location</Button>
+            </div>
+          </form>
+        </div>
+      </Modal>
+
+      <Modal open={!!allocationModal} onClose={() => setAllocationModal(null)} title="Allocations" description="IPs and ports servers on this node are bound to. Nodes without allocations use random ports on every interface.">
+        {allocationModal && (
+          <div className="space-y-4">
+            <form onSubmit={handleCreateAllocations} className="grid grid-cols-3 gap-3">
+              <Input label="IP" placeholder="0.0.0.0" value={allocationModal.ip} onChange={e => setAllocationModal(s => s && { ...s, ip: e.target.value })} required />
+              <Input label="Ports" placeholder="25565, 25600-25650" value={allocationModal.ports} onChange={e => setAllocationModal(s => s && { ...s, ports: e.target.value })} required />
+              <Input label="Alias (optional)" placeholder="play.example.com" value={allocationModal.alias} onChange={e => setAllocationModal(s => s && { ...s, alias: e.target.value })} />
+              <div className="col-span-3 flex justify-end">
+                <Button type="submit" loading={allocationModal.loading}>Add Allocations</Button>
+              </div>
+            </form>
+            {allocationModal.allocations.length === 0 ? <p className="text-sm text-neutral-500">No allocations yet.</p> : (
+              <div className="max-h-72 overflow-y-auto divide-y divide-neutral-800 rounded-lg border border-neutral-800">
+                {allocationModal.allocations.map(a => (
+                  <div key={a.id} className="flex items-center justify-between px-3 py-2 text-sm">
+                    <div>
+                      <span className="text-neutral-100 font-mono">{a.ip}:{a.port}</span>
+                      {a.alias && <span className="text-neutral-500 ml-2">{a.alias}</span>}
+                      {a.server_id && <span className="text-neutral-500 ml-2">assigned</span>}
+                    </div>
+                    <Button variant="ghost" onClick={() => handleDeleteAllocation(a)} disabled={!!a.server_id}>Delete</Button>
+                  </div>
+                ))}
+              </div>
+            )}
+          </div>
+        )}
+      </Modal>
+
       <Modal open={!!tokenModal} onClose={() => setTokenModal(null)} title="Node Token" description="Save this token - it won't be shown again.">
         <div className="space-y-4">
           <div className="rounded-lg bg-neutral-800 p-4">
@@ -40,6 +40,7 @@ const VerificationActionGroups: Record<string, { label: string; actions: { key:
       { key: 'server.name.update', label: 'Rename Server' },
       { key: 'server.resources.update', label: 'Update Resources' },
       { key: 'server.variables.update', label: 'Update Variables' },
+      { key: 'server.crash_policy.update', label: 'Update Crash Policy' },
     ],
   },
   allocations: {
@@ -1,7 +1,7 @@
 import { useState, useEffect, useMemo } from 'react';
 
 import { useParams } from 'react-router-dom';
-import { getServer, Server, listBackups, createBackup, deleteBackup, restoreBackup, getBackupDownloadUrl, Backup } from '../../../lib/api';
+import { getServer, Server, listBackups, createBackup, deleteBackup, lockBackup, unlockBackup, restoreBackup, listBackupFiles, getBackupDownloadUrl, Backup, FileEntry } from '../../../lib/api';
 import { formatBytes } from '../../../lib/utils';
 import { useServerPermissions } from '../../../hooks/useServerPermissions';
 import { Button, Icons, Modal, Input, Checkbox, PermissionDenied, ContextMenuZone, SlidePanel, FloatingBar } from '../../../components';
@@ -17,7 +17,7 @@ export default function BackupsPage() {
   const [selected, setSelected] = useState<Set<string>>(new Set());
   const [createPanel, setCreatePanel] = useState({ open: false, name: '', ignored: '', locked: false, loading: false });
   const [deleteModal, setDeleteModal] = useState<{ backup: Backup; loading: boolean } | null>(null);
-  const [restoreModal, setRestoreModal] = useState<{ backup: Backup; loading: boolean } | null>(null);
+  const [restoreModal, setRestoreModal] = useState<{ backup: Backup; loading: boolean; partial: boolean; path: string; files: FileEntry[]; paths: Set<string> } | null>(null);
   const { can, loading: permsLoading } = useServerPermissions(id);
 
   useEffect(() => {
@@ -27,7 +27,7 @@ export default function BackupsPage() {
   }, [id]);
 
   useEffect(() => {
-    const hasInProgress = backups.some(b => !b.completed);
+    const hasInProgress = backups.some(b => b.status === 'pending');
     if (!hasInProgress) return;
     const interval = setInterval(loadBackups, 2000);
     return () => clearInterval(interval);
@@ -47,7 +47,8 @@ export default function BackupsPage() {
   const handleCreate = async () => {
     if (!id) return;
     setCreatePanel(s => ({ ...s, loading: true }));
-    const res = await createBackup(id, createPanel.name || undefined);
+    const ignore = createPanel.ignored.split(',').map(s => s.trim()).filter(Boolean);
+    const res = await createBackup(id, createPanel.name || undefined, createPanel.locked, ignore);
     if (res.success) {
       notify('Backup started', 'Your backup is being created', 'success');
       setCreatePanel({ open: false, name: '', ignored: '', locked: false, loading: false });
@@ -81,10 +82,43 @@ export default function BackupsPage() {
     }
   };
 
+  const openRestore = (backup: Backup) => setRestoreModal({ backup, loading: false, partial: false, path: '/', files: [], paths: new Set() });
+
+  const browseBackup = async (path: string) => {
+    if (!restoreModal || !id) return;
+    const res = await listBackupFiles(id, restoreModal.backup.id, path);
+    if (res.success && res.data) {
+      const files = [...res.data].sort((a, b) => Number(b.is_dir) - Number(a.is_dir) || a.name.localeCompare(b.name));
+      setRestoreModal(s => s && { ...s, partial: true, path, files });
+    } else {
+      notify('Error', res.error || 'Failed to list backup files', 'error');
+    }
+  };
+
+  const togglePartial = () => {
+    if (!restoreModal) return;
+    if (restoreModal.partial) setRestoreModal(s => s && { ...s, partial: false, paths: new Set() });
+    else browseBackup('/');
+  };
+
+  const toggleRestorePath = (path: string) => setRestoreModal(s => {
+    if (!s) return s;
+    const paths = new Set(s.paths);
+    paths.has(path) ? paths.delete(path) : paths.add(path);
+    return { ...s, paths };
+  });
+
+  const joinBackupPath = (dir: string, name: string) => dir === '/' ? name : `${dir.replace(/^\//, '')}/${name}`;
+  const parentBackupPath = (dir: string) => '/' + dir.replace(/^\//, '').split('/').slice(0, -1).join('/');
+
   const handleRestore = async () => {
     if (!restoreModal || !id) return;
+    if (restoreModal.partial && restoreModal.paths.size === 0) {
+      notify('Error', 'Select at least one file or folder to restore', 'error');
+      return;
+    }
     setRestoreModal(s => s && { ...s, loading: true });
-    const res = await restoreBackup(id, restoreModal.backup.id);
+    const res = await restoreBackup(id, restoreModal.backup.id, restoreModal.partial ? [...restoreModal.paths] : undefined);
     if (res.success) {
       notify('Restored', 'Backup has been restored successfully', 'success');
       setRestoreModal(null);
@@ -94,21 +128,32 @@ export default function BackupsPage() {
     }
   };
 
+  const handleToggleLock = async (backup: Backup) => {
+    if (!id) return;
+    const res = backup.locked ? await unlockBackup(id, backup.id) : await lockBackup(id, backup.id);
+    if (res.success) {
+      setBackups(b => b.map(x => x.id === backup.id ? { ...x, locked: !backup.locked } : x));
+    } else {
+      notify('Error', res.error || 'Failed to update backup', 'error');
+    }
+  };
+
   const handleBulkDelete = async () => {
     if (!id) return;
-    const toDelete = [...selected];
+    const toDelete = backups.filter(b => selected.has(b.id) && !b.locked).map(b => b.id);
     for (const backupId of toDelete) {
       await deleteBackup(id, backupId);
     }
     notify('Deleted', `${toDelete.length} backup(s) deleted`, 'success');
-    setBackups(b => b.filter(x => !selected.has(x.id)));
+    setBackups(b => b.filter(x => !toDelete.includes(x.id)));
     setSelected(new Set());
   };
 
   const getBackupActions = (backup: Backup) => [
     { label: 'Download', onClick: () => handleDownload(backup), disabled: !backup.completed },
-    ...(can('backup.restore') ? [{ label: 'Restore', onClick: () => setRestoreModal({ backup, loading: false }), disabled: !backup.completed }] : []),
-    { label: 'Delete', onClick: () => setDeleteModal({ backup, loading: false }), variant: 'danger' as const },
+    ...(can('backup.restore') ? [{ label: 'Restore', onClick: () => openRestore(backup), disabled: !backup.completed }] : []),
+    ...(can('backup.delete') ? [{ label: backup.locked ? 'Unlock' : 'Lock', onClick: () => handleToggleLock(backup) }] : []),
+    { label: 'Delete', onClick: () => setDeleteModal({ backup, loading: false }), variant: 'danger' as const, disabled: backup.locked },
   ];
 
   const toggleSelect = (id: string) => setSelected(s => { const n = new Set(s); n.has(id) ? n.delete(id) : n.add(id); return n; });
@@ -185,13 +230,20 @@ export default function BackupsPage() {
                     <div className="flex items-center gap-3 text-sm">
                       <Icons.archive className="w-5 h-5 text-blue-500 shrink-0" />
                       <span className="text-neutral-100 truncate">{backup.name}</span>
+                      {backup.locked && <Icons.lock className="w-3.5 h-3.5 text-neutral-500 shrink-0" />}
                     </div>
                   </td>
                   <td className="pl-3 pr-6 py-3 text-sm text-neutral-400">{backup.completed ? formatBytes(backup.size) : '\u2014'}</td>
                   <td className="pl-3 pr-6 py-3 text-sm text-neutral-400">{formatDateTime(backup.created_at)}</td>
                   <td className="pl-3 pr-6 py-3">
-                    {backup.completed ? (
-                      <span className="inline-flex items-center px-2 py-0.5 rounded text-xs font-medium bg-emerald-500/20 text-emerald-400">Completed</span>
+                    {backup.status === 'completed' ? (
+                      backup.warnings ? (
+                        <span className="inline-flex items-center px-2 py-0.5 rounded text-xs font-medium bg-amber-500/20 text-amber-400" title={backup.warnings}>Completed with warnings</span>
+                      ) : (
+                        <span className="inline-flex items-center px-2 py-0.5 rounded text-xs font-medium bg-emerald-500/20 text-emerald-400">Completed</span>
+                      )
+                    ) : backup.status === 'failed' ? (
+                      <span className="inline-flex items-center px-2 py-0.5 rounded text-xs font-medium bg-red-500/20 text-red-400" title={backup.error}>Failed</span>
                     ) : (
                       <span className="inline-flex items-center gap-1.5 px-2 py-0.5 rounded text-xs font-medium bg-amber-500/20 text-amber-400">
                         <span className="inline-block w-3 h-3 border-2 border-current border-t-transparent rounded-full animate-spin" />
@@ -264,7 +316,7 @@ export default function BackupsPage() {
           <div className="rounded-lg border border-neutral-800/50 bg-blue-500/5 p-4 flex items-start gap-3">
             <Icons.errorCircle className="w-5 h-5 text-blue-400 shrink-0 mt-0.5" />
             <div className="text-xs text-neutral-400 leading-relaxed">
-              Backups are created as <span className="text-neutral-200 font-medium">.tar.gz</span> archives and stored on the node. The backup process runs in the background and may take a few minutes depending on server size.
+              Backups are downloaded as <span className="text-neutral-200 font-medium">.tar.gz</span> archives. The backup process runs in the background and may take a few minutes depending on server size. Files listed in <span className="text-neutral-200 font-medium">.birdactylignore</span> in the server root are skipped.
             </div>
           </div>
         </div>
@@ -277,7 +329,45 @@ export default function BackupsPage() {
         </div>
       </Modal>
 
-      <Modal open={!!restoreModal} onClose={() => !restoreModal?.loading && setRestoreModal(null)} title="Restore backup" description={`Are you sure you want to restore "${restoreModal?.backup.name}"? This will replace all current server files. The server must be stopped.`}>
+      <Modal open={!!restoreModal} onClose={() => !restoreModal?.loading && setRestoreModal(null)} title="Restore backup" description={restoreModal?.partial ? `Restore the selected files from "${restoreModal.backup.name}". They will replace the current versions, and everything else is left untouched. The server must be stopped.` : `Are you sure you want to restore "${restoreModal?.backup.name}"? This will replace all current server files. The server must be stopped.`}>
+        {restoreModal && (
+          <div className="pt-4 space-y-3">
+            <Checkbox checked={restoreModal.partial} onChange={togglePartial} label="Only restore selected files" />
+            {restoreModal.partial && (
+              <div className="rounded-lg border border-neutral-800 overflow-hidden">
+                <div className="flex items-center gap-2 px-3 py-2 text-xs text-neutral-400 border-b border-neutral-800">
+                  {restoreModal.path !== '/' && (
+                    <button type="button" className="hover:text-neutral-200" onClick={() => browseBackup(parentBackupPath(restoreModal.path))}><Icons.arrowUp className="w-3.5 h-3.5" /></button>
+                  )}
+                  <span className="font-mono truncate">{restoreModal.path}</span>
+                  <span className="ml-auto">{restoreModal.paths.size} selected</span>
+                </div>
+                <div className="max-h-64 overflow-y-auto divide-y divide-neutral-800/50">
+                  {restoreModal.files.length === 0 ? (
+                    <div className="px-3 py-4 text-center text-sm text-neutral-500">Empty directory</div>
+                  ) : restoreModal.files.map(file => {
+                    const path = joinBackupPath(restoreModal.path, file.name);
+                    return (
+                      <div key={path} className="flex items-center gap-2 px-3 py-1.5 text-sm hover:bg-neutral-800/50">
+                        <Checkbox checked={restoreModal.paths.has(path)} onChange={() => toggleRestorePath(path)} />
+                        {file.is_dir ? (
+                          <button type="button" className="flex items-center gap-2 text-neutral-200 truncate hover:underline" onClick={() => browseBackup('/' + path)}>
+                            <Icons.folder className="w-4 h-4 text-blue-500 shrink-0" />{file.name}
+                          </button>
+                        ) : (
+                          <span className="flex items-center gap-2 text-neutral-300 truncate">
+                            <Icons.file className="w-4 h-4 text-neutral-500 shrink-0" />{file.name}
+                          </span>
+                        )}
+                        {!file.is_dir && <span className="ml-auto text-xs text-neutral-500">{formatBytes(file.size)}</span>}
+                      </div>
+                    );
+                  })}
+                </div>
+              </div>
+            )}
+          </div>
+        )}
         <div className="flex justify-end gap-3 pt-4">
           <Button variant="ghost" onClick={() => setRestoreModal(null)} disabled={restoreModal?.loading}>Cancel</Button>
           <Button variant="danger" onClick={handleRestore} loading={restoreModal?.loading}>Restore</Button>
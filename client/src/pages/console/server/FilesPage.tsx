@@ -145,8 +145,9 @@ export default function FilesPage() {
         </div>
 
         <div className="flex flex-col gap-2 sm:flex-row sm:items-center">
-          <Input className="w-full sm:flex-1" placeholder="Search files..." value={fm.search} onChange={e => fm.setSearch(e.target.value)} />
+          <Input className="w-full sm:flex-1" placeholder={fm.contentSearch ? 'Search file contents...' : 'Search files...'} value={fm.search} onChange={e => fm.setSearch(e.target.value)} />
           <div className="flex items-center gap-3 overflow-x-auto scrollbar-hide px-1 py-1">
+            <Button variant={fm.contentSearch ? 'secondary' : 'ghost'} onClick={() => fm.setContentSearch(v => !v)} title="Search inside files" className="shrink-0"><Icons.fileText className="h-4 w-4 sm:mr-1.5" /><span className="hidden sm:inline">Contents</span></Button>
             {can('file.create') && <Button variant="ghost" onClick={() => setModals(m => ({ ...m, newFolder: true }))} className="shrink-0"><Icons.folderPlus className="h-4 w-4" /></Button>}
             {can('file.create') && <Button variant="ghost" onClick={() => setModals(m => ({ ...m, newFile: true }))} className="shrink-0"><Icons.filePlus className="h-4 w-4" /></Button>}
             <input ref={fm.uploadInputRef} type="file" multiple className="hidden" onChange={e => { if (e.target.files?.length) setModals(m => ({ ...m, upload: true, initialFiles: e.target.files })); }} />
@@ -171,6 +172,26 @@ export default function FilesPage() {
             <tbody className="divide-y divide-neutral-800">
               {fm.loading ? (
                 <tr><td colSpan={5} className="px-4 py-8 text-center text-sm text-neutral-500">Loading...</td></tr>
+              ) : fm.contentResults ? (
+                fm.contentResults.length === 0 ? (
+                  <tr><td colSpan={5} className="px-4 py-8 text-center text-sm text-neutral-500">{fm.contentSearching ? 'Searching...' : 'No matches found'}</td></tr>
+                ) : <>
+                  {fm.contentResults.map(match => (
+                    <tr key={`${match.path}:${match.line}`} className="hover:bg-neutral-800/50 cursor-pointer" onClick={() => fm.navigateToContentMatch(match)}>
+                      <td className="pl-4 py-3"></td>
+                      <td colSpan={4} className="px-3 py-3">
+                        <div className="flex items-center gap-3">
+                          <FileIcon name={match.path.substring(match.path.lastIndexOf('/') + 1)} is_dir={false} />
+                          <div className="min-w-0">
+                            <div className="text-xs text-neutral-500 truncate">{match.path}:{match.line}</div>
+                            <div className="text-sm text-neutral-100 font-mono truncate">{match.text}</div>
+                          </div>
+                        </div>
+                      </td>
+                    </tr>
+                  ))}
+                  {(fm.contentSearching || fm.contentTruncated) && <tr><td colSpan={5} className="px-4 py-3 text-center text-xs text-neutral-500">{fm.contentSearching ? 'Searching...' : 'Result limit reached, refine your search to see more'}</td></tr>}
+                </>
               ) : fm.searchResults ? (
                 fm.searchResults.length === 0 ? (
                   <tr><td colSpan={5} className="px-4 py-8 text-center text-sm text-neutral-500">No results found</td></tr>
@@ -235,6 +256,21 @@ export default function FilesPage() {
         <div className="md:hidden divide-y divide-neutral-800">
           {fm.loading ? (
             <div className="px-4 py-8 text-center text-sm text-neutral-500">Loading...</div>
+          ) : fm.contentResults ? (
+            fm.contentResults.length === 0 ? (
+              <div className="px-4 py-8 text-center text-sm text-neutral-500">{fm.contentSearching ? 'Searching...' : 'No matches found'}</div>
+            ) : <>
+              {fm.contentResults.map(match => (
+                <div key={`${match.path}:${match.line}`} className="flex items-center gap-3 px-4 py-3 hover:bg-neutral-800/50 active:bg-neutral-800/70" onClick={() => fm.navigateToContentMatch(match)}>
+                  <FileIcon name={match.path.substring(match.path.lastIndexOf('/') + 1)} is_dir={false} />
+                  <div className="flex-1 min-w-0">
+                    <div className="text-xs text-neutral-500 truncate">{match.path}:{match.line}</div>
+                    <div className="text-sm text-neutral-100 font-mono truncate">{match.text}</div>
+                  </div>
+                </div>
+              ))}
+              {(fm.contentSearching || fm.contentTruncated) && <div className="px-4 py-3 text-center text-xs text-neutral-500">{fm.contentSearching ? 'Searching...' : 'Result limit reached, refine your search to see more'}</div>}
+            </>
           ) : fm.searchResults ? (
             fm.searchResults.length === 0 ? (
               <div className="px-4 py-8 text-center text-sm text-neutral-500">No results found</div>
@@ -7,7 +7,7 @@ import Button from '../../../components/ui/Button';
 import { Icons } from '../../../components/Icons';
 import { PermissionDenied, ContextMenu, Table } from '../../../components';
 
-interface Allocation { port: number; primary?: boolean; }
+interface Allocation { port: number; primary?: boolean; ip?: string; alias?: string; }
 
 const parsePorts = (data: any) => typeof data.ports === 'string' ? JSON.parse(data.ports) : data.ports || [];
 
@@ -60,6 +60,7 @@ export default function NetworkPage() {
   if (!server) return <div className="text-neutral-400">Loading...</div>;
 
   const host = server.node?.display_ip || server.node?.fqdn || 'unknown';
+  const hostOf = (alloc: Allocation) => alloc.alias || (alloc.ip && alloc.ip !== '0.0.0.0' ? alloc.ip : host);
 
   const getAllocationActions = (alloc: Allocation) => [
     ...(can('allocation.set_primary') && !alloc.primary ? [{ label: 'Set primary', onClick: () => handleSetPrimary(alloc.port) }] : []),
@@ -93,7 +94,7 @@ export default function NetworkPage() {
               {
                 key: 'address', header: 'Address', render: (alloc: Allocation) => (
                   <div className="text-sm font-medium text-neutral-100 flex items-center gap-2">
-                    <span>{host}:{alloc.port}</span>
+                    <span>{hostOf(alloc)}:{alloc.port}</span>
                     {alloc.primary && (
                       <span className="rounded-full bg-emerald-500/20 px-2 py-0.5 text-[11px] font-semibold text-emerald-300">
                         PRIMARY
@@ -102,7 +103,7 @@ export default function NetworkPage() {
                   </div>
                 )
               },
-              { key: 'host', header: 'Host', render: () => <span className="text-sm text-neutral-300">{host}</span> },
+              { key: 'host', header: 'Host', render: (alloc: Allocation) => <span className="text-sm text-neutral-300">{hostOf(alloc)}</span> },
               { key: 'port', header: 'Port', render: (alloc: Allocation) => <span className="text-sm text-neutral-300 tabular-nums">{alloc.port}</span> },
               {
                 key: 'actions', header: '', align: 'right' as const, render: (alloc: Allocation) => (
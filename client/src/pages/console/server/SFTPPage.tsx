@@ -1,8 +1,8 @@
 import { useState, useEffect } from 'react';
 import { useParams } from 'react-router-dom';
-import { getServer, getSFTPDetails, resetSFTPPassword, Server, SFTPDetails } from '../../../lib/api';
+import { getServer, getSFTPDetails, Server, SFTPDetails } from '../../../lib/api';
 import { useServerPermissions } from '../../../hooks/useServerPermissions';
-import { notify, Button, Icons, PermissionDenied } from '../../../components';
+import { notify, Icons, PermissionDenied } from '../../../components';
 
 function SectionCard({ title, description, children, footer }: {
   title: string; description?: string; children: React.ReactNode; footer?: React.ReactNode;
@@ -51,8 +51,6 @@ export default function SFTPPage() {
   const [server, setServer] = useState<Server | null>(null);
   const [sftp, setSftp] = useState<SFTPDetails | null>(null);
   const [loading, setLoading] = useState(true);
-  const [resetting, setResetting] = useState(false);
-  const [newPassword, setNewPassword] = useState<string | null>(null);
   const { can, loading: permsLoading } = useServerPermissions(id);
 
   useEffect(() => {
@@ -63,19 +61,6 @@ export default function SFTPPage() {
     ]).finally(() => setLoading(false));
   }, [id]);
 
-  const handleResetPassword = async () => {
-    if (!id) return;
-    setResetting(true);
-    const res = await resetSFTPPassword(id);
-    if (res.success && res.data) {
-      setNewPassword(res.data.password);
-      notify('Password Reset', 'Your new SFTP password has been generated', 'success');
-    } else {
-      notify('Error', res.error || 'Failed to reset password', 'error');
-    }
-    setResetting(false);
-  };
-
   if (loading || permsLoading) return null;
   if (!can('sftp.view')) return <PermissionDenied message="You don't have permission to view SFTP details" />;
 
@@ -104,54 +89,24 @@ export default function SFTPPage() {
         <div className="mt-3 rounded-lg border border-neutral-800/50 bg-blue-500/5 p-3.5 flex items-start gap-3">
           <Icons.errorCircle className="w-4 h-4 text-blue-400 shrink-0 mt-0.5" />
           <p className="text-xs text-neutral-400 leading-relaxed">
-            You can also use GUI clients like <span className="text-neutral-200 font-medium">FileZilla</span>, <span className="text-neutral-200 font-medium">WinSCP</span>, or <span className="text-neutral-200 font-medium">Cyberduck</span>. Enter the host, port, and username above with your SFTP password.
+            You can also use GUI clients like <span className="text-neutral-200 font-medium">FileZilla</span>, <span className="text-neutral-200 font-medium">WinSCP</span>, or <span className="text-neutral-200 font-medium">Cyberduck</span>. Enter the host, port, and username above with your account password.
           </p>
         </div>
       </SectionCard>
 
       <SectionCard
-        title="SFTP Password"
-        description="Generate a dedicated password for SFTP access, separate from your account password."
-        footer={
-          !newPassword && can('sftp.reset_password')
-            ? <Button onClick={handleResetPassword} loading={resetting}><Icons.key className="w-4 h-4 mr-1.5" />Generate New Password</Button>
-            : undefined
-        }
+        title="Authentication"
+        description="SFTP uses your panel account, so every user who can access this server signs in with their own credentials."
       >
-        {newPassword ? (
-          <div className="space-y-4">
-            <div className="p-4 rounded-lg border border-emerald-500/20 bg-emerald-500/[0.03]">
-              <div className="flex items-start gap-3">
-                <Icons.shield className="w-4 h-4 text-emerald-400 shrink-0 mt-0.5" />
-                <div className="flex-1 min-w-0">
-                  <p className="text-xs text-emerald-400 font-medium mb-2">Your new SFTP password has been generated. Save it now -- it won't be shown again.</p>
-                  <div className="flex items-center gap-2">
-                    <code className="flex-1 text-sm font-mono text-emerald-300 bg-neutral-900/60 px-3 py-2 rounded-lg break-all border border-neutral-800">{newPassword}</code>
-                    <button
-                      onClick={() => { navigator.clipboard.writeText(newPassword); notify('Copied', 'Password copied', 'success'); }}
-                      className="shrink-0 p-2 rounded-lg text-emerald-400 hover:bg-emerald-500/10 transition-colors"
-                    >
-                      <Icons.clipboard className="w-4 h-4" />
-                    </button>
-                  </div>
-                </div>
-              </div>
-            </div>
-            <Button variant="ghost" onClick={() => setNewPassword(null)} className="w-full">Done</Button>
+        <div className="flex items-center gap-3 p-4 rounded-lg border border-neutral-800 bg-neutral-900/30">
+          <div className="flex items-center justify-center w-9 h-9 rounded-lg bg-neutral-800">
+            <Icons.key className="w-4 h-4 text-neutral-400" />
           </div>
-        ) : (
-          <div className="flex items-center justify-between p-4 rounded-lg border border-neutral-800 bg-neutral-900/30">
-            <div className="flex items-center gap-3">
-              <div className="flex items-center justify-center w-9 h-9 rounded-lg bg-neutral-800">
-                <Icons.key className="w-4 h-4 text-neutral-400" />
-              </div>
-              <div>
-                <div className="text-sm font-medium text-neutral-200">Dedicated SFTP Password</div>
-                <div className="text-xs text-neutral-500 mt-0.5">Generate a password specifically for SFTP access to this server.</div>
-              </div>
-            </div>
+          <div>
+            <div className="text-sm font-medium text-neutral-200">Account password, API key or SSH key</div>
+            <div className="text-xs text-neutral-500 mt-0.5">Accounts with two-factor authentication must use an API key or an SSH key from your account settings. Subusers can only list, read, change or delete files their permissions allow.</div>
           </div>
-        )}
+        </div>
       </SectionCard>
     </div>
   );
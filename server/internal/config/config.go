@@ -19,6 +19,8 @@ type Config struct {
 	Resources  ResourcesConfig       `yaml:"resources"`
 	Logging    LoggingConfig         `yaml:"logging"`
 	Plugins    PluginsConfig         `yaml:"plugins"`
+	Schedules  SchedulesConfig       `yaml:"schedules"`
+	Metrics    MetricsConfig         `yaml:"metrics"`
 	RootAdmins []string              `yaml:"root_admins"`
 	APIKeys    map[string]APIKeyConfig `yaml:"api_keys"`
 }
@@ -67,6 +69,16 @@ type PluginsConfig struct {
 	Container    ContainerConfig  `yaml:"container"`
 }
 
+type SchedulesConfig struct {
+	AllowPrivateWebhooks bool   `yaml:"allow_private_webhooks"`
+	MisfirePolicy        string `yaml:"misfire_policy"`
+	ReconcileInterval    int    `yaml:"reconcile_interval"`
+}
+
+type MetricsConfig struct {
+	Token string `yaml:"token"`
+}
+
 type ContainerConfig struct {
 	Enabled     bool   `yaml:"enabled"`
 	Image       string `yaml:"image"`
@@ -99,11 +111,13 @@ type AuthConfig struct {
 }
 
 type ResourcesConfig struct {
-	Enabled      bool `yaml:"enabled"`
-	DefaultRAM   int  `yaml:"default_ram"`
-	DefaultCPU   int  `yaml:"default_cpu"`
-	DefaultDisk  int  `yaml:"default_disk"`
-	MaxServers   int  `yaml:"max_servers"`
+	Enabled       bool `yaml:"enabled"`
+	DefaultRAM    int  `yaml:"default_ram"`
+	DefaultCPU    int  `yaml:"default_cpu"`
+	DefaultDisk   int  `yaml:"default_disk"`
+	MaxServers    int  `yaml:"max_servers"`
+	MaxBackups    int  `yaml:"max_backups"`
+	BackupStorage int  `yaml:"backup_storage"`
 }
 
 var (
@@ -203,6 +217,8 @@ resources:
   default_cpu: 200
   default_disk: 10240
   max_servers: 3
+  max_backups: 0
+  backup_storage: 0
 
 smtp:
   enabled: false
@@ -223,6 +239,14 @@ plugins:
     network_mode: "host"
     memory_limit: "512m"
     cpu_limit: "1.0"
+
+schedules:
+  allow_private_webhooks: false
+  misfire_policy: "run_once"
+  reconcile_interval: 30
+
+metrics:
+  token: ""
 `
 
 	return os.WriteFile(path, []byte(defaultConfig), 0644)
@@ -303,6 +327,12 @@ func (c *Config) setDefaults() {
 	if c.Plugins.LoadMode == "" {
 		c.Plugins.LoadMode = PluginLoadManual
 	}
+	if c.Schedules.MisfirePolicy == "" {
+		c.Schedules.MisfirePolicy = "run_once"
+	}
+	if c.Schedules.ReconcileInterval == 0 {
+		c.Schedules.ReconcileInterval = 30
+	}
 }
 
 func (c *Config) loadEnvOverrides() {
@@ -338,6 +368,9 @@ func (c *Config) loadEnvOverrides() {
 	if v := os.Getenv("JWT_SECRET"); v != "" {
 		c.Auth.JWTSecret = v
 	}
+	if v := os.Getenv("METRICS_TOKEN"); v != "" {
+		c.Metrics.Token = v
+	}
 }
 
 func (d *DatabaseConfig) DSN() string {
@@ -78,7 +78,9 @@ func Connect(cfg *config.DatabaseConfig) error {
 		&models.User{},
 		&models.Session{},
 		&models.IPRegistration{},
+		&models.Location{},
 		&models.Node{},
+		&models.Allocation{},
 		&models.Package{},
 		&models.Mount{},
 		&models.Server{},
@@ -89,7 +91,12 @@ func Connect(cfg *config.DatabaseConfig) error {
 		&models.DatabaseHost{},
 		&models.ServerDatabase{},
 		&models.Schedule{},
+		&models.ScheduleRun{},
 		&models.APIKey{},
+		&models.SSHKey{},
+		&models.BackupTarget{},
+		&models.Backup{},
+		&models.ServerCrash{},
 	); err != nil {
 		return err
 	}
@@ -41,6 +41,7 @@ const (
 	ActionServerNameUpdate      = "server.name.update"
 	ActionServerResourcesUpdate = "server.resources.update"
 	ActionServerVariablesUpdate = "server.variables.update"
+	ActionServerCrashPolicy     = "server.crash_policy.update"
 	ActionServerAllocationAdd   = "server.allocation.add"
 	ActionServerAllocationPri   = "server.allocation.set_primary"
 	ActionServerAllocationDel   = "server.allocation.delete"
@@ -57,9 +58,16 @@ const (
 	ActionFileBulkCopy      = "server.file.bulk_copy"
 	ActionFileBulkCompress  = "server.file.bulk_compress"
 
+	ActionSFTPWrite        = "server.sftp.write"
+	ActionSFTPDelete       = "server.sftp.delete"
+	ActionSFTPRename       = "server.sftp.rename"
+	ActionSFTPCreateFolder = "server.sftp.create_folder"
+
 	ActionBackupCreate  = "server.backup.create"
 	ActionBackupDelete  = "server.backup.delete"
 	ActionBackupRestore = "server.backup.restore"
+	ActionBackupLock    = "server.backup.lock"
+	ActionBackupUnlock  = "server.backup.unlock"
 
 	ActionSubuserAdd    = "server.subuser.add"
 	ActionSubuserUpdate = "server.subuser.update"
@@ -111,11 +119,21 @@ const (
 	ActionAdminDBHostDelete   = "admin.database_host.delete"
 	ActionAdminSettingsUpdate = "admin.settings.update"
 
+	ActionAdminBackupTargetCreate = "admin.backup_target.create"
+	ActionAdminBackupTargetUpdate = "admin.backup_target.update"
+	ActionAdminBackupTargetDelete = "admin.backup_target.delete"
+
+	ActionAdminLocationCreate = "admin.location.create"
+	ActionAdminLocationUpdate = "admin.location.update"
+	ActionAdminLocationDelete = "admin.location.delete"
+
+	ActionAdminAllocationCreate = "admin.allocation.create"
+	ActionAdminAllocationUpdate = "admin.allocation.update"
+	ActionAdminAllocationDelete = "admin.allocation.delete"
+
 	ActionAllocationAdd        = "server.allocation.add"
 	ActionAllocationDelete     = "server.allocation.delete"
 	ActionAllocationSetPrimary = "server.allocation.set_primary"
-
-	ActionSFTPPasswordReset = "server.sftp.password_reset"
 )
 
 func LogActivity(userID uuid.UUID, username, action, description, ip, userAgent string, isAdmin bool, metadata map[string]interface{}) {
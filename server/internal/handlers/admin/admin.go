@@ -355,13 +355,15 @@ func AdminRevokeAdmin(c *fiber.Ctx) error {
 }
 
 type AdminUpdateUserRequest struct {
-	Email       string `json:"email"`
-	Username    string `json:"username"`
-	Password    string `json:"password"`
-	RAMLimit    *int   `json:"ram_limit"`
-	CPULimit    *int   `json:"cpu_limit"`
-	DiskLimit   *int   `json:"disk_limit"`
-	ServerLimit *int   `json:"server_limit"`
+	Email              string `json:"email"`
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	RAMLimit           *int   `json:"ram_limit"`
+	CPULimit           *int   `json:"cpu_limit"`
+	DiskLimit          *int   `json:"disk_limit"`
+	ServerLimit        *int   `json:"server_limit"`
+	BackupLimit        *int   `json:"backup_limit"`
+	BackupStorageLimit *int   `json:"backup_storage_limit"`
 }
 
 func AdminUpdateUser(c *fiber.Ctx) error {
@@ -440,6 +442,20 @@ func AdminUpdateUser(c *fiber.Ctx) error {
 			updates["server_limit"] = *req.ServerLimit
 		}
 	}
+	if req.BackupLimit != nil {
+		if *req.BackupLimit == 0 {
+			updates["backup_limit"] = nil
+		} else {
+			updates["backup_limit"] = *req.BackupLimit
+		}
+	}
+	if req.BackupStorageLimit != nil {
+		if *req.BackupStorageLimit == 0 {
+			updates["backup_storage_limit"] = nil
+		} else {
+			updates["backup_storage_limit"] = *req.BackupStorageLimit
+		}
+	}
 
 	if len(updates) == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "No changes provided"})
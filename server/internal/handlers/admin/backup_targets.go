@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type backupTargetRequest struct {
+	Name       string                  `json:"name"`
+	Type       models.BackupTargetType `json:"type"`
+	Endpoint   string                  `json:"endpoint"`
+	Region     string                  `json:"region"`
+	Bucket     string                  `json:"bucket"`
+	Prefix     string                  `json:"prefix"`
+	AccessKey  string                  `json:"access_key"`
+	SecretKey  string                  `json:"secret_key"`
+	PathStyle  bool                    `json:"path_style"`
+	Host       string                  `json:"host"`
+	Port       int                     `json:"port"`
+	Username   string                  `json:"username"`
+	Password   string                  `json:"password"`
+	PrivateKey string                  `json:"private_key"`
+	HostKey    string                  `json:"host_key"`
+	Path       string                  `json:"path"`
+}
+
+func (r *backupTargetRequest) apply(t *models.BackupTarget) {
+	t.Name = r.Name
+	t.Type = r.Type
+	t.Endpoint = r.Endpoint
+	t.Region = r.Region
+	t.Bucket = r.Bucket
+	t.Prefix = r.Prefix
+	t.AccessKey = r.AccessKey
+	t.PathStyle = r.PathStyle
+	t.Host = r.Host
+	t.Port = r.Port
+	t.Username = r.Username
+	t.HostKey = r.HostKey
+	t.Path = r.Path
+	if r.SecretKey != "" {
+		t.SecretKey = r.SecretKey
+	}
+	if r.Password != "" {
+		t.Password = r.Password
+	}
+	if r.PrivateKey != "" {
+		t.PrivateKey = r.PrivateKey
+	}
+}
+
+func AdminGetBackupTargets(c *fiber.Ctx) error {
+	var targets []models.BackupTarget
+	database.DB.Order("name").Find(&targets)
+
+	result := make([]fiber.Map, len(targets))
+	for i, t := range targets {
+		var nodes, packages int64
+		database.DB.Model(&models.Node{}).Where("backup_target_id = ?", t.ID).Count(&nodes)
+		database.DB.Model(&models.Package{}).Where("backup_target_id = ?", t.ID).Count(&packages)
+		result[i] = fiber.Map{
+			"target":         t,
+			"nodes_count":    nodes,
+			"packages_count": packages,
+		}
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": result})
+}
+
+func AdminCreateBackupTarget(c *fiber.Ctx) error {
+	var req backupTargetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	var target models.BackupTarget
+	req.apply(&target)
+	if err := services.CreateBackupTarget(&target); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminBackupTargetCreate, "Created backup target: "+target.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"target_id": target.ID})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": target})
+}
+
+func AdminUpdateBackupTarget(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid backup target ID"})
+	}
+
+	target, err := services.GetBackupTarget(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	var req backupTargetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	req.apply(target)
+	if err := services.UpdateBackupTarget(target); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminBackupTargetUpdate, "Updated backup target: "+target.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"target_id": id})
+
+	return c.JSON(fiber.Map{"success": true, "data": target})
+}
+
+func AdminDeleteBackupTarget(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid backup target ID"})
+	}
+
+	target, err := services.GetBackupTarget(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	if err := services.DeleteBackupTarget(id); err != nil {
+		status := fiber.StatusInternalServerError
+		if err == services.ErrBackupTargetInUse {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminBackupTargetDelete, "Deleted backup target: "+target.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"target_id": id})
+
+	return c.JSON(fiber.Map{"success": true, "message": "Backup target deleted"})
+}
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type locationRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Region      string   `json:"region"`
+	Tags        []string `json:"tags"`
+}
+
+func (r *locationRequest) apply(l *models.Location) {
+	if r.Tags == nil {
+		r.Tags = []string{}
+	}
+	tagsJSON, _ := datatypes.NewJSONType(r.Tags).MarshalJSON()
+	l.Name = r.Name
+	l.Description = r.Description
+	l.Region = r.Region
+	l.Tags = tagsJSON
+}
+
+func locationErrorStatus(err error) int {
+	switch err {
+	case services.ErrLocationNotFound:
+		return fiber.StatusNotFound
+	case services.ErrLocationNameTaken, services.ErrLocationInUse:
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+func AdminGetLocations(c *fiber.Ctx) error {
+	locations, err := services.GetLocations()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	result := make([]fiber.Map, len(locations))
+	for i, l := range locations {
+		var nodes int64
+		database.DB.Model(&models.Node{}).Where("location_id = ?", l.ID).Count(&nodes)
+		result[i] = fiber.Map{
+			"location":    l,
+			"nodes_count": nodes,
+		}
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": result})
+}
+
+func AdminCreateLocation(c *fiber.Ctx) error {
+	var req locationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	var location models.Location
+	req.apply(&location)
+	if err := services.CreateLocation(&location); err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminLocationCreate, "Created location: "+location.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"location_id": location.ID})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": location})
+}
+
+func AdminUpdateLocation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid location ID"})
+	}
+
+	location, err := services.GetLocation(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	var req locationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	req.apply(location)
+	if err := services.UpdateLocation(location); err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminLocationUpdate, "Updated location: "+location.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"location_id": id})
+
+	return c.JSON(fiber.Map{"success": true, "data": location})
+}
+
+func AdminDeleteLocation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid location ID"})
+	}
+
+	location, err := services.GetLocation(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	if err := services.DeleteLocation(id); err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminLocationDelete, "Deleted location: "+location.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"location_id": id})
+
+	return c.JSON(fiber.Map{"success": true, "message": "Location deleted"})
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// authenticateSFTPUser checks a panel username against a password or API key.
+// Accounts with two-factor authentication can only use API keys.
+func authenticateSFTPUser(username, secret string) (*models.User, bool) {
+	if strings.HasPrefix(secret, apiKeyPrefix) {
+		user, err := ValidateAPIKey(secret)
+		if err != nil || user == nil || user.Username != username {
+			return nil, false
+		}
+		return user, true
+	}
+
+	var user models.User
+	if err := database.DB.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, false
+	}
+	if user.TOTPEnabled || user.PasswordHash == "" {
+		return nil, false
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(secret)); err != nil {
+		return nil, false
+	}
+	return &user, true
+}
+
+// authenticateSFTPKey checks a panel username against the fingerprint of one
+// of their registered SSH keys. Axis has already verified the key signature.
+func authenticateSFTPKey(username, fingerprint string) (*models.User, bool) {
+	var user models.User
+	if err := database.DB.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, false
+	}
+
+	var key models.SSHKey
+	if err := database.DB.Where("user_id = ? AND fingerprint = ?", user.ID, fingerprint).First(&key).Error; err != nil {
+		return nil, false
+	}
+
+	database.DB.Model(&key).Update("last_used_at", time.Now())
+	return &user, true
+}
+
+func ValidateSFTPAuth(c *fiber.Ctx) error {
+	var req struct {
+		ServerID    string `json:"server_id"`
+		Username    string `json:"username"`
+		Password    string `json:"password"`
+		Fingerprint string `json:"fingerprint"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	serverID, err := uuid.Parse(req.ServerID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid server ID"})
+	}
+
+	var server models.Server
+	if err := database.DB.First(&server, "id = ?", serverID).Error; err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"success": false, "error": "Authentication failed"})
+	}
+
+	node := c.Locals("node").(*models.Node)
+	if server.NodeID != node.ID {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"success": false, "error": "Authentication failed"})
+	}
+
+	var user *models.User
+	var ok bool
+	if req.Fingerprint != "" {
+		user, ok = authenticateSFTPKey(req.Username, req.Fingerprint)
+	} else {
+		user, ok = authenticateSFTPUser(req.Username, req.Password)
+	}
+	if !ok || user.IsBanned {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"success": false, "error": "Authentication failed"})
+	}
+
+	permissions := []string{models.PermAdmin}
+	if !user.IsAdmin {
+		if server.IsSuspended {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": "Server is suspended"})
+		}
+		permissions, err = services.GetUserServerPermissions(user.ID, serverID)
+		if err != nil || !models.HasPermission(permissions, models.PermSFTPView) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": "Permission denied"})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"user_id":     user.ID,
+			"username":    user.Username,
+			"permissions": permissions,
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+func GetSSHKeys(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var keys []models.SSHKey
+	database.DB.Where("user_id = ?", user.ID).Order("created_at DESC").Find(&keys)
+
+	return c.JSON(fiber.Map{"success": true, "data": keys})
+}
+
+type CreateSSHKeyRequest struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+}
+
+func CreateSSHKey(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var req CreateSSHKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	publicKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(req.PublicKey)))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid SSH public key"})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		req.Name = strings.TrimSpace(comment)
+	}
+	if req.Name == "" {
+		req.Name = "SSH Key"
+	}
+	if utf8.RuneCountInString(req.Name) > 255 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Name must be at most 255 characters"})
+	}
+
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+
+	var count int64
+	database.DB.Model(&models.SSHKey{}).Where("user_id = ? AND fingerprint = ?", user.ID, fingerprint).Count(&count)
+	if count > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"success": false, "error": "SSH key already added"})
+	}
+
+	key := models.SSHKey{
+		UserID:      user.ID,
+		Name:        req.Name,
+		Fingerprint: fingerprint,
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+	}
+
+	if err := database.DB.Create(&key).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": "Failed to add SSH key"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": key})
+}
+
+func DeleteSSHKey(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	keyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid key ID"})
+	}
+
+	result := database.DB.Where("id = ? AND user_id = ?", keyID, user.ID).Delete(&models.SSHKey{})
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": "SSH key not found"})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": "SSH key deleted"})
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"bytes"
+
+	"birdactyl-panel-backend/internal/metrics"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func init() {
+	metrics.NewGaugeFunc("birdactyl_nodes", "Registered nodes by connection state.", []string{"state"}, func(set func(float64, ...string)) {
+		nodes, err := services.GetNodes()
+		if err != nil {
+			return
+		}
+		var online, offline float64
+		for _, n := range nodes {
+			if n.IsOnline {
+				online++
+			} else {
+				offline++
+			}
+		}
+		set(online, "online")
+		set(offline, "offline")
+	})
+}
+
+func Metrics(c *fiber.Ctx) error {
+	var buf bytes.Buffer
+	metrics.Write(&buf)
+	c.Set(fiber.HeaderContentType, metrics.ContentType)
+	return c.Send(buf.Bytes())
+}
@@ -5,6 +5,7 @@ import (
 	"birdactyl-panel-backend/internal/plugins"
 	"birdactyl-panel-backend/internal/services"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -17,8 +18,17 @@ type CreateNodeRequest struct {
 }
 
 type UpdateNodeRequest struct {
-	Name string `json:"name"`
-	Icon string `json:"icon"`
+	Name           string  `json:"name"`
+	Icon           string  `json:"icon"`
+	BackupTargetID *string `json:"backup_target_id"`
+	LocationID     *string `json:"location_id"`
+
+	MemoryLimit        *int `json:"memory_limit"`
+	MemoryOverallocate *int `json:"memory_overallocate"`
+	DiskLimit          *int `json:"disk_limit"`
+	DiskOverallocate   *int `json:"disk_overallocate"`
+	CPULimit           *int `json:"cpu_limit"`
+	CPUOverallocate    *int `json:"cpu_overallocate"`
 }
 
 type PairNodeRequest struct {
@@ -31,6 +41,9 @@ type PairNodeRequest struct {
 
 func AdminGetNodes(c *fiber.Ctx) error {
 	nodes, err := services.GetNodes()
+	if err == nil {
+		err = services.AttachNodeAllocations(nodes)
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -53,6 +66,9 @@ func AdminGetNodes(c *fiber.Ctx) error {
 
 func AdminRefreshNodes(c *fiber.Ctx) error {
 	nodes, err := services.RefreshNodes()
+	if err == nil {
+		err = services.AttachNodeAllocations(nodes)
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -146,6 +162,11 @@ func AdminGetNode(c *fiber.Ctx) error {
 		})
 	}
 
+	nodes := []models.Node{*node}
+	if err := services.AttachNodeAllocations(nodes); err == nil {
+		node.Allocation = nodes[0].Allocation
+	}
+
 	result, _ := plugins.ExecuteMixin(string(plugins.MixinNodeGet), map[string]interface{}{"node_id": id.String(), "node": node}, func(input map[string]interface{}) (interface{}, error) {
 		return input["node"], nil
 	})
@@ -184,6 +205,63 @@ func AdminUpdateNode(c *fiber.Ctx) error {
 		})
 	}
 
+	if req.BackupTargetID != nil {
+		var targetID *uuid.UUID
+		if *req.BackupTargetID != "" {
+			parsed, err := uuid.Parse(*req.BackupTargetID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Invalid backup target ID",
+				})
+			}
+			targetID = &parsed
+		}
+		if node, err = services.SetNodeBackupTarget(id, targetID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	if req.LocationID != nil {
+		var locationID *uuid.UUID
+		if *req.LocationID != "" {
+			parsed, err := uuid.Parse(*req.LocationID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Invalid location ID",
+				})
+			}
+			locationID = &parsed
+		}
+		if node, err = services.SetNodeLocation(id, locationID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	limits := services.NodeLimits{
+		MemoryLimit:        req.MemoryLimit,
+		MemoryOverallocate: req.MemoryOverallocate,
+		DiskLimit:          req.DiskLimit,
+		DiskOverallocate:   req.DiskOverallocate,
+		CPULimit:           req.CPULimit,
+		CPUOverallocate:    req.CPUOverallocate,
+	}
+	if !limits.IsEmpty() {
+		if node, err = services.SetNodeLimits(id, limits); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+	}
+
 	admin := c.Locals("user").(*models.User)
 	LogActivity(admin.ID, admin.Username, ActionAdminNodeUpdate, "Updated node: "+node.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"node_id": id.String()})
 
@@ -257,6 +335,32 @@ func AdminResetNodeToken(c *fiber.Ctx) error {
 	})
 }
 
+func AdminGetNodeMetrics(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid node ID",
+		})
+	}
+
+	resp, err := services.GetNodeMetrics(id, c.Query("range", "1h"))
+	if err == services.ErrNodeNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(resp.StatusCode).Send(resp.Body)
+}
+
 type NodeHeartbeatRequest struct {
 	System    models.SystemInfo `json:"system"`
 	DisplayIP string            `json:"display_ip"`
@@ -285,6 +389,84 @@ func NodeHeartbeat(c *fiber.Ctx) error {
 	})
 }
 
+func NodeTunnel(c *websocket.Conn) {
+	node := c.Locals("node").(*models.Node)
+	services.ServeNodeTunnel(node, services.NewTunnelConn(c.Conn))
+}
+
+func NodeServerState(c *fiber.Ctx) error {
+	node := c.Locals("node").(*models.Node)
+
+	var req services.ServerStateReport
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if err := services.HandleServerStateReport(node.ID, req); err != nil {
+		status := fiber.StatusBadRequest
+		if err == services.ErrServerNotFound {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+func NodeSFTPActivity(c *fiber.Ctx) error {
+	node := c.Locals("node").(*models.Node)
+
+	var req services.SFTPActivityReport
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid request body",
+		})
+	}
+
+	recorded := HandleSFTPActivityReport(node.ID, req)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    fiber.Map{"recorded": recorded},
+	})
+}
+
+func NodeBackupReport(c *fiber.Ctx) error {
+	node := c.Locals("node").(*models.Node)
+
+	var req services.BackupReport
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid request body",
+		})
+	}
+
+	if err := services.HandleBackupReport(node.ID, req); err != nil {
+		status := fiber.StatusBadRequest
+		if err == services.ErrServerNotFound || err == services.ErrBackupNotFound {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+	})
+}
+
 func GetAvailableNodes(c *fiber.Ctx) error {
 	nodes, err := services.GetOnlineNodes()
 	if err != nil {
@@ -295,6 +477,16 @@ func GetAvailableNodes(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"success": true, "data": nodes})
 }
 
+func GetAvailableLocations(c *fiber.Ctx) error {
+	locations, err := services.GetLocations()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false, "error": "Failed to fetch locations",
+		})
+	}
+	return c.JSON(fiber.Map{"success": true, "data": locations})
+}
+
 func AdminGeneratePairingCode(c *fiber.Ctx) error {
 	code := services.GeneratePairingCode()
 	return c.JSON(fiber.Map{
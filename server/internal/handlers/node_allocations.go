@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func allocationErrorStatus(err error) int {
+	switch err {
+	case services.ErrNodeNotFound, services.ErrAllocationNotFound:
+		return fiber.StatusNotFound
+	case services.ErrAllocationInUse:
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+func AdminGetNodeAllocations(c *fiber.Ctx) error {
+	nodeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid node ID"})
+	}
+
+	allocations, err := services.GetNodeAllocations(nodeID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": allocations})
+}
+
+func AdminCreateNodeAllocations(c *fiber.Ctx) error {
+	nodeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid node ID"})
+	}
+
+	var req struct {
+		IP    string   `json:"ip"`
+		Ports []string `json:"ports"`
+		Alias string   `json:"alias"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	allocations, err := services.CreateAllocations(nodeID, req.IP, req.Alias, req.Ports)
+	if err != nil {
+		return c.Status(allocationErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	LogActivity(admin.ID, admin.Username, ActionAdminAllocationCreate, "Created node allocations", c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"node_id": nodeID, "ip": req.IP, "ports": req.Ports, "created": len(allocations)})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": allocations})
+}
+
+func AdminUpdateNodeAllocation(c *fiber.Ctx) error {
+	nodeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid node ID"})
+	}
+	allocationID, err := uuid.Parse(c.Params("allocationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid allocation ID"})
+	}
+
+	var req struct {
+		Alias string `json:"alias"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	allocation, err := services.UpdateAllocationAlias(nodeID, allocationID, req.Alias)
+	if err != nil {
+		return c.Status(allocationErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	LogActivity(admin.ID, admin.Username, ActionAdminAllocationUpdate, "Updated node allocation", c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"node_id": nodeID, "allocation_id": allocationID, "alias": req.Alias})
+
+	return c.JSON(fiber.Map{"success": true, "data": allocation})
+}
+
+func AdminDeleteNodeAllocation(c *fiber.Ctx) error {
+	nodeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid node ID"})
+	}
+	allocationID, err := uuid.Parse(c.Params("allocationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid allocation ID"})
+	}
+
+	if err := services.DeleteNodeAllocation(nodeID, allocationID); err != nil {
+		return c.Status(allocationErrorStatus(err)).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	admin := c.Locals("user").(*models.User)
+	LogActivity(admin.ID, admin.Username, ActionAdminAllocationDelete, "Deleted node allocation", c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"node_id": nodeID, "allocation_id": allocationID})
+
+	return c.JSON(fiber.Map{"success": true, "message": "Allocation deleted"})
+}
@@ -29,6 +29,11 @@ type CreatePackageRequest struct {
 	Variables           []models.PackageVariable   `json:"variables"`
 	ConfigFiles         []models.PackageConfigFile `json:"config_files"`
 	AddonSources        []models.AddonSource       `json:"addon_sources"`
+	BackupTargetID      *uuid.UUID                 `json:"backup_target_id"`
+	BackupIgnore        string                     `json:"backup_ignore"`
+	BackupHooks         models.PackageBackupHooks  `json:"backup_hooks"`
+	CrashPolicy         *models.CrashPolicy        `json:"crash_policy"`
+	AllowedLocations    []uuid.UUID                `json:"allowed_locations"`
 }
 
 func AdminGetPackages(c *fiber.Ctx) error {
@@ -69,6 +74,37 @@ func AdminCreatePackage(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := services.CheckBackupTargetID(req.BackupTargetID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	if err := services.ValidateBackupHooks(&req.BackupHooks); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	if err := services.ValidateCrashPolicy(req.CrashPolicy); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	if err := services.CheckLocationIDs(req.AllowedLocations); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+	if req.AllowedLocations == nil {
+		req.AllowedLocations = []uuid.UUID{}
+	}
+
 	if req.StopSignal == "" {
 		req.StopSignal = "SIGTERM"
 	}
@@ -85,6 +121,9 @@ func AdminCreatePackage(c *fiber.Ctx) error {
 	varsJSON, _ := datatypes.NewJSONType(req.Variables).MarshalJSON()
 	configJSON, _ := datatypes.NewJSONType(req.ConfigFiles).MarshalJSON()
 	addonJSON, _ := datatypes.NewJSONType(req.AddonSources).MarshalJSON()
+	hooksJSON, _ := datatypes.NewJSONType(req.BackupHooks).MarshalJSON()
+	crashJSON, _ := datatypes.NewJSONType(req.CrashPolicy).MarshalJSON()
+	locationsJSON, _ := datatypes.NewJSONType(req.AllowedLocations).MarshalJSON()
 
 	pkg := &models.Package{
 		Name:                req.Name,
@@ -105,6 +144,11 @@ func AdminCreatePackage(c *fiber.Ctx) error {
 		Variables:           varsJSON,
 		ConfigFiles:         configJSON,
 		AddonSources:        addonJSON,
+		BackupTargetID:      req.BackupTargetID,
+		BackupIgnore:        req.BackupIgnore,
+		BackupHooks:         hooksJSON,
+		CrashPolicy:         crashJSON,
+		AllowedLocations:    locationsJSON,
 	}
 
 	_, err := plugins.ExecuteMixin(string(plugins.MixinPackageCreate), mixinInput, func(input map[string]interface{}) (interface{}, error) {
@@ -187,6 +231,46 @@ func AdminUpdatePackage(c *fiber.Ctx) error {
 	varsJSON, _ := datatypes.NewJSONType(req.Variables).MarshalJSON()
 	configJSON, _ := datatypes.NewJSONType(req.ConfigFiles).MarshalJSON()
 	addonJSON, _ := datatypes.NewJSONType(req.AddonSources).MarshalJSON()
+	hooksJSON, _ := datatypes.NewJSONType(req.BackupHooks).MarshalJSON()
+	crashJSON, _ := datatypes.NewJSONType(req.CrashPolicy).MarshalJSON()
+
+	if err := services.CheckBackupTargetID(req.BackupTargetID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	if err := services.ValidateBackupHooks(&req.BackupHooks); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	if err := services.ValidateCrashPolicy(req.CrashPolicy); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	if err := services.CheckLocationIDs(req.AllowedLocations); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+	if req.AllowedLocations == nil {
+		req.AllowedLocations = []uuid.UUID{}
+	}
+
+	locationsJSON, _ := datatypes.NewJSONType(req.AllowedLocations).MarshalJSON()
+
+	var previousTarget *uuid.UUID
+	if existing, err := services.GetPackageByID(id); err == nil {
+		previousTarget = existing.BackupTargetID
+	}
 
 	updates := map[string]interface{}{
 		"name":                  req.Name,
@@ -207,6 +291,11 @@ func AdminUpdatePackage(c *fiber.Ctx) error {
 		"variables":             varsJSON,
 		"config_files":          configJSON,
 		"addon_sources":         addonJSON,
+		"backup_target_id":      req.BackupTargetID,
+		"backup_ignore":         req.BackupIgnore,
+		"backup_hooks":          hooksJSON,
+		"crash_policy":          crashJSON,
+		"allowed_locations":     locationsJSON,
 	}
 
 	mixinInput := map[string]interface{}{
@@ -235,6 +324,10 @@ func AdminUpdatePackage(c *fiber.Ctx) error {
 	LogActivity(admin.ID, admin.Username, ActionAdminPackageUpdate, "Updated package: "+req.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"package_id": id})
 
 	plugins.Emit(plugins.EventPackageUpdated, map[string]string{"package_id": id.String(), "name": req.Name})
+	if (previousTarget == nil) != (req.BackupTargetID == nil) ||
+		(previousTarget != nil && *previousTarget != *req.BackupTargetID) {
+		go services.SyncPackageBackupStorage(id)
+	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -80,11 +80,26 @@ func GetSchedule(c *fiber.Ctx) error {
 type CreateScheduleRequest struct {
 	Name           string                `json:"name"`
 	CronExpression string                `json:"cron_expression"`
+	Timezone       string                `json:"timezone"`
 	IsActive       bool                  `json:"is_active"`
 	OnlyWhenOnline bool                  `json:"only_when_online"`
+	MisfirePolicy  models.MisfirePolicy  `json:"misfire_policy"`
 	Tasks          []models.ScheduleTask `json:"tasks"`
 }
 
+func validateScheduleRequest(req *CreateScheduleRequest) error {
+	if err := services.ValidateCronExpression(req.CronExpression); err != nil {
+		return err
+	}
+	if err := services.ValidateTimezone(req.Timezone); err != nil {
+		return err
+	}
+	if err := services.ValidateMisfirePolicy(req.MisfirePolicy); err != nil {
+		return err
+	}
+	return services.ValidateScheduleTasks(req.Tasks)
+}
+
 func CreateSchedule(c *fiber.Ctx) error {
 	serverID, err := checkSchedulePerm(c, models.PermScheduleCreate)
 	if err != nil {
@@ -100,14 +115,20 @@ func CreateSchedule(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Name and cron_expression are required"})
 	}
 
+	if err := validateScheduleRequest(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
 	tasksJSON, _ := json.Marshal(req.Tasks)
 
 	schedule := &models.Schedule{
 		ServerID:       serverID,
 		Name:           req.Name,
 		CronExpression: req.CronExpression,
+		Timezone:       req.Timezone,
 		IsActive:       req.IsActive,
 		OnlyWhenOnline: req.OnlyWhenOnline,
+		MisfirePolicy:  req.MisfirePolicy,
 		Tasks:          tasksJSON,
 	}
 
@@ -143,13 +164,19 @@ func UpdateSchedule(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request body"})
 	}
 
+	if err := validateScheduleRequest(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
 	tasksJSON, _ := json.Marshal(req.Tasks)
 
 	updates := map[string]interface{}{
 		"name":             req.Name,
 		"cron_expression":  req.CronExpression,
+		"timezone":         req.Timezone,
 		"is_active":        req.IsActive,
 		"only_when_online": req.OnlyWhenOnline,
+		"misfire_policy":   req.MisfirePolicy,
 		"tasks":            tasksJSON,
 	}
 
@@ -214,3 +241,31 @@ func RunScheduleNow(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{"success": true, "message": "Schedule execution started"})
 }
+
+func GetScheduleRuns(c *fiber.Ctx) error {
+	serverID, err := checkSchedulePerm(c, models.PermScheduleList)
+	if err != nil {
+		return nil
+	}
+
+	scheduleID, err := uuid.Parse(c.Params("scheduleId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid schedule ID"})
+	}
+
+	existing, err := services.GetScheduleByID(scheduleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": "Schedule not found"})
+	}
+
+	if existing.ServerID != serverID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": "Access denied"})
+	}
+
+	runs, err := services.GetScheduleRuns(scheduleID, c.QueryInt("limit", 0))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": runs})
+}
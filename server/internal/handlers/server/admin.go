@@ -71,20 +71,22 @@ func AdminCreateServer(c *fiber.Ctx) error {
 	admin := c.Locals("user").(*models.User)
 
 	var req struct {
-		Name      string    `json:"name"`
-		NodeID    uuid.UUID `json:"node_id"`
-		PackageID uuid.UUID `json:"package_id"`
-		Memory    int       `json:"memory"`
-		CPU       int       `json:"cpu"`
-		Disk      int       `json:"disk"`
-		UserID    string    `json:"user_id"`
+		Name       string     `json:"name"`
+		NodeID     uuid.UUID  `json:"node_id"`
+		LocationID *uuid.UUID `json:"location_id"`
+		Strategy   string     `json:"strategy"`
+		PackageID  uuid.UUID  `json:"package_id"`
+		Memory     int        `json:"memory"`
+		CPU        int        `json:"cpu"`
+		Disk       int        `json:"disk"`
+		UserID     string     `json:"user_id"`
 	}
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request body"})
 	}
 
-	if req.Name == "" || req.NodeID == uuid.Nil || req.PackageID == uuid.Nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Name, node_id, and package_id are required"})
+	if req.Name == "" || (req.NodeID == uuid.Nil && req.LocationID == nil) || req.PackageID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Name, node_id or location_id, and package_id are required"})
 	}
 
 	ownerID := admin.ID
@@ -127,22 +129,32 @@ func AdminCreateServer(c *fiber.Ctx) error {
 	}
 
 	createReq := services.CreateServerRequest{
-		Name:      req.Name,
-		NodeID:    req.NodeID,
-		PackageID: req.PackageID,
-		Memory:    req.Memory,
-		CPU:       req.CPU,
-		Disk:      req.Disk,
+		Name:       req.Name,
+		NodeID:     req.NodeID,
+		LocationID: req.LocationID,
+		Strategy:   req.Strategy,
+		PackageID:  req.PackageID,
+		Memory:     req.Memory,
+		CPU:        req.CPU,
+		Disk:       req.Disk,
 	}
 
 	server, err := services.CreateServer(ownerID, createReq)
 	if err != nil {
+		if mixinErr, ok := err.(*plugins.MixinError); ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
+		}
 		status := fiber.StatusInternalServerError
 		switch err {
-		case services.ErrNodeNotFound, services.ErrPackageNotFound:
+		case services.ErrNodeNotFound, services.ErrPackageNotFound, services.ErrLocationNotFound, services.ErrLocationNotAllowed, services.ErrUnknownPlacementStrategy:
 			status = fiber.StatusBadRequest
 		case services.ErrNodeOffline:
 			status = fiber.StatusServiceUnavailable
+		case services.ErrNoNodeAvailable, services.ErrNoFreePorts:
+			status = fiber.StatusConflict
+		}
+		if services.IsNodeCapacityError(err) {
+			status = fiber.StatusConflict
 		}
 		return c.Status(status).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
@@ -342,11 +354,12 @@ func AdminUpdateServerResources(c *fiber.Ctx) error {
 	}
 
 	var req struct {
-		Name   string `json:"name"`
-		UserID string `json:"user_id"`
-		Memory int    `json:"memory"`
-		CPU    int    `json:"cpu"`
-		Disk   int    `json:"disk"`
+		Name        string `json:"name"`
+		UserID      string `json:"user_id"`
+		Memory      int    `json:"memory"`
+		CPU         int    `json:"cpu"`
+		Disk        int    `json:"disk"`
+		BackupLimit *int   `json:"backup_limit"`
 	}
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request body"})
@@ -396,6 +409,13 @@ func AdminUpdateServerResources(c *fiber.Ctx) error {
 	if req.Disk > 0 && req.Disk != server.Disk {
 		updates["disk"] = req.Disk
 	}
+	if req.BackupLimit != nil {
+		if *req.BackupLimit <= 0 {
+			updates["backup_limit"] = nil
+		} else {
+			updates["backup_limit"] = *req.BackupLimit
+		}
+	}
 
 	if len(updates) == 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "No changes provided"})
@@ -407,8 +427,23 @@ func AdminUpdateServerResources(c *fiber.Ctx) error {
 		"updates":   updates,
 	}
 
+	var warnings []string
 	_, err = plugins.ExecuteMixin(string(plugins.MixinServerUpdate), mixinInput, func(input map[string]interface{}) (interface{}, error) {
-		database.DB.Model(&server).Updates(updates)
+		other := map[string]interface{}{}
+		for k, v := range updates {
+			if k != "memory" && k != "cpu" && k != "disk" {
+				other[k] = v
+			}
+		}
+		if len(other) < len(updates) {
+			var err error
+			if _, warnings, err = services.UpdateServerResourcesAdmin(serverID, req.Memory, req.CPU, req.Disk); err != nil {
+				return nil, err
+			}
+		}
+		if len(other) > 0 {
+			database.DB.Model(&server).Updates(other)
+		}
 		return nil, nil
 	})
 
@@ -416,6 +451,7 @@ func AdminUpdateServerResources(c *fiber.Ctx) error {
 		if mixinErr, ok := err.(*plugins.MixinError); ok {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
 		}
+		return resourceCheckError(c, err)
 	}
 
 	database.DB.Preload("User").Preload("Node").Preload("Package").Where("id = ?", serverID).First(&server)
@@ -423,7 +459,7 @@ func AdminUpdateServerResources(c *fiber.Ctx) error {
 	admin := c.Locals("user").(*models.User)
 	handlers.LogActivity(admin.ID, admin.Username, handlers.ActionAdminServerResources, "Updated server: "+server.Name, c.IP(), c.Get("User-Agent"), true, map[string]interface{}{"server_id": serverID, "updates": updates})
 
-	return c.JSON(fiber.Map{"success": true, "data": server})
+	return c.JSON(fiber.Map{"success": true, "data": server, "warnings": warnings})
 }
 
 func AdminTransferServer(c *fiber.Ctx) error {
@@ -461,7 +497,11 @@ func AdminTransferServer(c *fiber.Ctx) error {
 		if mixinErr, ok := err.(*plugins.MixinError); ok {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
+		status := fiber.StatusBadRequest
+		if services.IsNodeCapacityError(err) || err == services.ErrNoFreePorts {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 
 	transferID := result.(string)
@@ -60,6 +60,9 @@ func AddAllocation(c *fiber.Ctx) error {
 		if mixinErr, ok := err.(*plugins.MixinError); ok {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
 		}
+		if err == services.ErrNoFreePorts {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"success": false, "error": err.Error()})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"encoding/json"
 	"errors"
+	"net/url"
+	"strings"
 
 	"birdactyl-panel-backend/internal/database"
 	"birdactyl-panel-backend/internal/handlers"
@@ -41,17 +44,53 @@ func checkBackupPerm(c *fiber.Ctx, perm string) (*models.Server, error) {
 	return &server, nil
 }
 
+func backupResponse(b models.Backup) fiber.Map {
+	return fiber.Map{
+		"id":         b.NodeBackupID,
+		"name":       b.Name,
+		"size":       b.Size,
+		"checksum":   b.Checksum,
+		"storage":    b.Storage,
+		"locked":     b.Locked,
+		"status":     b.Status,
+		"error":      b.Error,
+		"warnings":   b.Warnings,
+		"created_by": b.CreatedBy,
+		"completed":  b.Status == models.BackupCompleted,
+		"created_at": b.CreatedAt.Unix(),
+	}
+}
+
+func getBackup(c *fiber.Ctx, server *models.Server, requireCompleted bool) (*models.Backup, error) {
+	backup, err := services.GetServerBackup(server.ID, c.Params("backupId"))
+	if err != nil {
+		c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "error": "Backup not found"})
+		return nil, errBackupHandled
+	}
+	if requireCompleted && backup.Status != models.BackupCompleted {
+		c.Status(fiber.StatusConflict).JSON(fiber.Map{"success": false, "error": services.ErrBackupNotCompleted.Error()})
+		return nil, errBackupHandled
+	}
+	return backup, nil
+}
+
 func ListBackups(c *fiber.Ctx) error {
 	server, err := checkBackupPerm(c, models.PermBackupList)
 	if err != nil {
 		return nil
 	}
 
-	data, err := services.ProxyGetToNode(server, "/backups")
+	backups, err := services.ListServerBackups(server.ID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 
+	result := make([]fiber.Map, len(backups))
+	for i, b := range backups {
+		result[i] = backupResponse(b)
+	}
+	data := fiber.Map{"success": true, "data": result}
+
 	plugins.ExecuteMixin(string(plugins.MixinBackupList), map[string]interface{}{"server_id": server.ID.String(), "data": data}, func(input map[string]interface{}) (interface{}, error) {
 		return input["data"], nil
 	})
@@ -66,6 +105,24 @@ func CreateBackup(c *fiber.Ctx) error {
 	}
 	user := c.Locals("user").(*models.User)
 
+	var req struct {
+		Name   string   `json:"name"`
+		Ignore []string `json:"ignore"`
+		Locked bool     `json:"locked"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request body"})
+		}
+	}
+	if req.Locked && !user.IsAdmin && server.UserID != user.ID && !services.HasServerPermission(user.ID, server.ID, false, models.PermBackupDelete) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": "Permission denied"})
+	}
+
+	if err := services.CheckBackupLimits(server); err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
 	if allow, msg := plugins.Emit(plugins.EventBackupCreating, map[string]string{"server_id": server.ID.String(), "server_name": server.Name, "user_id": user.ID.String()}); !allow {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": msg})
 	}
@@ -74,26 +131,31 @@ func CreateBackup(c *fiber.Ctx) error {
 		"server_id":   server.ID.String(),
 		"server_name": server.Name,
 		"user_id":     user.ID.String(),
+		"name":        req.Name,
+		"locked":      req.Locked,
 	}
 
-	var data interface{}
+	var backup *models.Backup
 	_, err = plugins.ExecuteMixin(string(plugins.MixinBackupCreate), mixinInput, func(input map[string]interface{}) (interface{}, error) {
-		var proxyErr error
-		data, proxyErr = services.ProxyPostToNode(server, "/backups", c.Body())
-		return data, proxyErr
+		var createErr error
+		backup, createErr = services.CreateServerBackup(server, req.Name, req.Ignore, req.Locked, &user.ID)
+		return backup, createErr
 	})
 
 	if err != nil {
 		if mixinErr, ok := err.(*plugins.MixinError); ok {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
 		}
+		if services.IsBackupLimitError(err) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 
-	handlers.Log(c, user, handlers.ActionBackupCreate, "Created backup for server: "+server.Name, map[string]interface{}{"server_id": server.ID})
-	plugins.Emit(plugins.EventBackupCreated, map[string]string{"server_id": server.ID.String(), "server_name": server.Name})
+	handlers.Log(c, user, handlers.ActionBackupCreate, "Created backup for server: "+server.Name, map[string]interface{}{"server_id": server.ID, "backup_id": backup.NodeBackupID, "name": backup.Name})
+	plugins.Emit(plugins.EventBackupCreated, map[string]string{"server_id": server.ID.String(), "server_name": server.Name, "backup_id": backup.NodeBackupID})
 
-	return c.Status(fiber.StatusCreated).JSON(data)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": backupResponse(*backup)})
 }
 
 func DeleteBackup(c *fiber.Ctx) error {
@@ -101,8 +163,16 @@ func DeleteBackup(c *fiber.Ctx) error {
 	if err != nil {
 		return nil
 	}
+	backup, err := getBackup(c, server, false)
+	if err != nil {
+		return nil
+	}
 	user := c.Locals("user").(*models.User)
-	backupID := c.Params("backupId")
+	backupID := backup.NodeBackupID
+
+	if backup.Locked {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"success": false, "error": "Backup is locked"})
+	}
 
 	if allow, msg := plugins.Emit(plugins.EventBackupDeleting, map[string]string{"server_id": server.ID.String(), "backup_id": backupID}); !allow {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": msg})
@@ -113,11 +183,8 @@ func DeleteBackup(c *fiber.Ctx) error {
 		"backup_id": backupID,
 	}
 
-	var data interface{}
 	_, err = plugins.ExecuteMixin(string(plugins.MixinBackupDelete), mixinInput, func(input map[string]interface{}) (interface{}, error) {
-		var proxyErr error
-		data, proxyErr = services.ProxyDeleteToNode(server, "/backups/"+backupID)
-		return data, proxyErr
+		return nil, services.DeleteServerBackup(backup)
 	})
 
 	if err != nil {
@@ -130,7 +197,39 @@ func DeleteBackup(c *fiber.Ctx) error {
 	handlers.Log(c, user, handlers.ActionBackupDelete, "Deleted backup", map[string]interface{}{"server_id": server.ID, "backup_id": backupID})
 	plugins.Emit(plugins.EventBackupDeleted, map[string]string{"server_id": server.ID.String(), "backup_id": backupID})
 
-	return c.JSON(data)
+	return c.JSON(fiber.Map{"success": true, "message": "Backup deleted"})
+}
+
+func LockBackup(c *fiber.Ctx) error {
+	return setBackupLocked(c, true)
+}
+
+func UnlockBackup(c *fiber.Ctx) error {
+	return setBackupLocked(c, false)
+}
+
+func setBackupLocked(c *fiber.Ctx, locked bool) error {
+	server, err := checkBackupPerm(c, models.PermBackupDelete)
+	if err != nil {
+		return nil
+	}
+	backup, err := getBackup(c, server, false)
+	if err != nil {
+		return nil
+	}
+	user := c.Locals("user").(*models.User)
+
+	if err := services.SetBackupLocked(backup, locked); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	action, desc := handlers.ActionBackupLock, "Locked backup"
+	if !locked {
+		action, desc = handlers.ActionBackupUnlock, "Unlocked backup"
+	}
+	handlers.Log(c, user, action, desc, map[string]interface{}{"server_id": server.ID, "backup_id": backup.NodeBackupID})
+
+	return c.JSON(fiber.Map{"success": true, "data": backupResponse(*backup)})
 }
 
 func DownloadBackup(c *fiber.Ctx) error {
@@ -139,13 +238,29 @@ func DownloadBackup(c *fiber.Ctx) error {
 		return nil
 	}
 
-	backupID := c.Params("backupId")
-	url, err := services.GetNodeProxyURL(server, "/backups/"+backupID+"/download")
+	backup, err := getBackup(c, server, true)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+		return nil
+	}
+
+	return proxyDownload(c, server, "/api/servers/"+server.ID.String()+"/backups/"+url.PathEscape(backup.NodeBackupID)+"/download")
+}
+
+func ListBackupFiles(c *fiber.Ctx) error {
+	server, err := checkBackupPerm(c, models.PermBackupRestore)
+	if err != nil {
+		return nil
+	}
+	backup, err := getBackup(c, server, true)
+	if err != nil {
+		return nil
 	}
 
-	return c.Redirect(url)
+	data, err := services.ProxyGetToNode(server, "/backups/"+backup.NodeBackupID+"/files?path="+url.QueryEscape(c.Query("path", "/")))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return c.JSON(data)
 }
 
 func RestoreBackup(c *fiber.Ctx) error {
@@ -153,22 +268,41 @@ func RestoreBackup(c *fiber.Ctx) error {
 	if err != nil {
 		return nil
 	}
+	backup, err := getBackup(c, server, true)
+	if err != nil {
+		return nil
+	}
 	user := c.Locals("user").(*models.User)
-	backupID := c.Params("backupId")
+	backupID := backup.NodeBackupID
+
+	var req struct {
+		Paths []string `json:"paths"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request body"})
+		}
+	}
+	paths, err := services.ValidateRestorePaths(req.Paths)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
 
-	if allow, msg := plugins.Emit(plugins.EventBackupRestoring, map[string]string{"server_id": server.ID.String(), "backup_id": backupID}); !allow {
+	if allow, msg := plugins.Emit(plugins.EventBackupRestoring, map[string]string{"server_id": server.ID.String(), "backup_id": backupID, "paths": strings.Join(paths, ",")}); !allow {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": msg})
 	}
 
 	mixinInput := map[string]interface{}{
 		"server_id": server.ID.String(),
 		"backup_id": backupID,
+		"paths":     paths,
 	}
 
 	var data interface{}
 	_, err = plugins.ExecuteMixin(string(plugins.MixinBackupRestore), mixinInput, func(input map[string]interface{}) (interface{}, error) {
+		body, _ := json.Marshal(fiber.Map{"paths": paths})
 		var proxyErr error
-		data, proxyErr = services.ProxyPostToNode(server, "/backups/"+backupID+"/restore", nil)
+		data, proxyErr = services.ProxyPostToNode(server, "/backups/"+backupID+"/restore", body)
 		return data, proxyErr
 	})
 
@@ -179,7 +313,7 @@ func RestoreBackup(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
 
-	handlers.Log(c, user, handlers.ActionBackupRestore, "Restored backup", map[string]interface{}{"server_id": server.ID, "backup_id": backupID})
+	handlers.Log(c, user, handlers.ActionBackupRestore, "Restored backup", map[string]interface{}{"server_id": server.ID, "backup_id": backupID, "paths": paths})
 	plugins.Emit(plugins.EventBackupRestored, map[string]string{"server_id": server.ID.String(), "backup_id": backupID})
 
 	return c.JSON(data)
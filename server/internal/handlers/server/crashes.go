@@ -0,0 +1,63 @@
+package server
+
+import (
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func GetServerCrashes(c *fiber.Ctx) error {
+	serverID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid server ID"})
+	}
+
+	server, err := checkServerPerm(c, serverID, models.PermConsoleRead)
+	if err != nil {
+		return nil
+	}
+
+	crashes, err := services.GetServerCrashes(serverID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": "Failed to fetch crashes"})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": fiber.Map{
+		"policy":  services.ServerCrashPolicy(server),
+		"custom":  len(server.CrashPolicy) > 0 && string(server.CrashPolicy) != "null",
+		"crashes": crashes,
+	}})
+}
+
+func UpdateServerCrashPolicy(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+	serverID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid server ID"})
+	}
+
+	if _, err := checkServerPerm(c, serverID, models.PermStartupUpdate); err != nil {
+		return nil
+	}
+
+	var req struct {
+		Policy *models.CrashPolicy `json:"policy"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid request"})
+	}
+
+	if err := services.SetServerCrashPolicy(serverID, req.Policy); err != nil {
+		status := fiber.StatusInternalServerError
+		if err == services.ErrInvalidCrashPolicy {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+
+	handlers.Log(c, user, handlers.ActionServerCrashPolicy, "Updated server crash policy", map[string]interface{}{"server_id": serverID, "policy": req.Policy})
+	return c.JSON(fiber.Map{"success": true, "message": "Crash policy updated"})
+}
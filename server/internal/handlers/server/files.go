@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"net/http"
 	"net/url"
 
 	"birdactyl-panel-backend/internal/database"
@@ -115,6 +116,9 @@ func ReadFile(c *fiber.Ctx) error {
 }
 
 func SearchFiles(c *fiber.Ctx) error {
+	if c.Query("mode") == "content" {
+		return searchFileContents(c)
+	}
 	server, err := getServerWithFilePerm(c, models.PermFileList)
 	if err != nil {
 		return nil
@@ -126,6 +130,56 @@ func SearchFiles(c *fiber.Ctx) error {
 	return proxyGetWithQuery(c, server, "/files/search", "q", q)
 }
 
+func searchFileContents(c *fiber.Ctx) error {
+	server, err := getServerWithFilePerm(c, models.PermFileRead)
+	if err != nil {
+		return nil
+	}
+	q := c.Query("q")
+	if q == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "query required"})
+	}
+
+	resp, err := services.StreamFileGrep(server, services.FileGrepOptions{
+		Pattern:       q,
+		Regex:         c.QueryBool("regex", false),
+		CaseSensitive: c.QueryBool("case_sensitive", false),
+		Path:          c.Query("path", "/"),
+		Include:       c.Query("include"),
+		MaxFileSize:   int64(c.QueryInt("max_size", 0)),
+		Limit:         c.QueryInt("limit", 0),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return c.Status(resp.StatusCode).Send(body)
+	}
+
+	c.Set("Content-Type", "application/x-ndjson")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer resp.Body.Close()
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := resp.Body.Read(buf)
+			if n > 0 {
+				if _, werr := w.Write(buf[:n]); werr != nil {
+					return
+				}
+				if w.Flush() != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
 func CreateFolder(c *fiber.Ctx) error {
 	server, err := getServerWithFilePerm(c, models.PermFileCreate)
 	if err != nil {
@@ -404,23 +458,43 @@ func DownloadFile(c *fiber.Ctx) error {
 	if path == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "path required"})
 	}
-	resp, err := services.StreamDownloadFromNode(server, "/api/servers/"+server.ID.String()+"/files/download?path="+url.QueryEscape(path))
+	return proxyDownload(c, server, "/api/servers/"+server.ID.String()+"/files/download?path="+url.QueryEscape(path))
+}
+
+// proxyDownload streams a download from the node, forwarding Range and
+// If-Range so an interrupted download can resume where it stopped.
+func proxyDownload(c *fiber.Ctx, server *models.Server, path string) error {
+	header := http.Header{}
+	for _, name := range []string{fiber.HeaderRange, fiber.HeaderIfRange} {
+		if v := c.Get(name); v != "" {
+			header.Set(name, v)
+		}
+	}
+
+	resp, err := services.StreamDownloadFromNode(server, path, header)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
+	if resp.StatusCode == fiber.StatusRequestedRangeNotSatisfiable {
+		defer resp.Body.Close()
+		c.Set(fiber.HeaderContentRange, resp.Header.Get(fiber.HeaderContentRange))
+		return c.Status(resp.StatusCode).JSON(fiber.Map{"success": false, "error": "range not satisfiable"})
+	}
 	if resp.StatusCode >= 400 {
-        defer resp.Body.Close()
+		defer resp.Body.Close()
 		return c.Status(resp.StatusCode).JSON(fiber.Map{"success": false, "error": "download failed"})
 	}
-	c.Set("Content-Disposition", resp.Header.Get("Content-Disposition"))
-	c.Set("Content-Type", resp.Header.Get("Content-Type"))
-	if cl := resp.Header.Get("Content-Length"); cl != "" {
-		c.Set("Content-Length", cl)
+
+	for _, name := range []string{
+		fiber.HeaderContentDisposition, fiber.HeaderContentType, fiber.HeaderContentRange,
+		fiber.HeaderAcceptRanges, fiber.HeaderLastModified, fiber.HeaderETag,
+	} {
+		if v := resp.Header.Get(name); v != "" {
+			c.Set(name, v)
+		}
 	}
-	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
-        defer resp.Body.Close()
-		io.Copy(w, resp.Body)
-	})
+	c.Status(resp.StatusCode)
+	c.Response().SetBodyStream(resp.Body, int(resp.ContentLength))
 	return nil
 }
 
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func GetServerMetrics(c *fiber.Ctx) error {
+	serverID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Invalid server ID"})
+	}
+
+	server, err := checkServerPerm(c, serverID, models.PermConsoleRead)
+	if err != nil {
+		return nil
+	}
+
+	resp, err := services.GetServerMetrics(server, c.Query("range", "1h"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return c.Status(resp.StatusCode).Send(resp.Body)
+}
@@ -100,9 +100,9 @@ func CreateServer(c *fiber.Ctx) error {
 		})
 	}
 
-	if req.Name == "" || req.NodeID == uuid.Nil || req.PackageID == uuid.Nil {
+	if req.Name == "" || (req.NodeID == uuid.Nil && req.LocationID == nil) || req.PackageID == uuid.Nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false, "error": "Name, node_id, and package_id are required",
+			"success": false, "error": "Name, node_id or location_id, and package_id are required",
 		})
 	}
 
@@ -176,6 +176,9 @@ func CreateServer(c *fiber.Ctx) error {
 		"cpu":        req.CPU,
 		"disk":       req.Disk,
 	}
+	if req.LocationID != nil {
+		mixinInput["location_id"] = req.LocationID.String()
+	}
 
 	result, err := plugins.ExecuteMixin(string(plugins.MixinServerCreate), mixinInput, func(input map[string]interface{}) (interface{}, error) {
 		if name, ok := input["name"].(string); ok {
@@ -200,10 +203,15 @@ func CreateServer(c *fiber.Ctx) error {
 		}
 		status := fiber.StatusInternalServerError
 		switch err {
-		case services.ErrNodeNotFound, services.ErrPackageNotFound:
+		case services.ErrNodeNotFound, services.ErrPackageNotFound, services.ErrLocationNotFound, services.ErrLocationNotAllowed, services.ErrUnknownPlacementStrategy:
 			status = fiber.StatusBadRequest
 		case services.ErrNodeOffline:
 			status = fiber.StatusServiceUnavailable
+		case services.ErrNoNodeAvailable, services.ErrNoFreePorts:
+			status = fiber.StatusConflict
+		}
+		if services.IsNodeCapacityError(err) {
+			status = fiber.StatusConflict
 		}
 		return c.Status(status).JSON(fiber.Map{"success": false, "error": err.Error()})
 	}
@@ -516,21 +524,25 @@ func UpdateServerResources(c *fiber.Ctx) error {
 		"updates":   updates,
 	}
 
+	var warnings []string
 	_, err = plugins.ExecuteMixin(string(plugins.MixinServerUpdate), mixinInput, func(input map[string]interface{}) (interface{}, error) {
-		return nil, database.DB.Model(&models.Server{}).Where("id = ?", serverID).Updates(updates).Error
+		var err error
+		_, warnings, err = services.UpdateServerResources(serverID, user.ID, req.Memory, req.CPU, req.Disk, user.IsAdmin)
+		return nil, err
 	})
 
 	if err != nil {
 		if mixinErr, ok := err.(*plugins.MixinError); ok {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
 		}
+		return resourceCheckError(c, err)
 	}
 
 	handlers.Log(c, user, handlers.ActionServerResourcesUpdate, "Updated server resources", map[string]interface{}{"server_id": serverID, "updates": updates})
 
 	plugins.Emit(plugins.EventServerUpdated, map[string]string{"server_id": serverID.String(), "update_type": "resources"})
 
-	return c.JSON(fiber.Map{"success": true, "message": "Resources updated"})
+	return c.JSON(fiber.Map{"success": true, "message": "Resources updated", "warnings": warnings})
 }
 
 func UpdateServerName(c *fiber.Ctx) error {
@@ -732,3 +744,11 @@ func GetConsoleLogs(c *fiber.Ctx) error {
 		},
 	})
 }
+
+func resourceCheckError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	if services.IsNodeCapacityError(err) {
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(fiber.Map{"success": false, "error": err.Error()})
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/plugins"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UploadChunkSize is the largest chunk the panel accepts per PATCH. It is
+// bound by the panel's request body limit, not by the node.
+const UploadChunkSize = fiber.DefaultBodyLimit
+
+func uploadPath(server *models.Server, uploadID string) string {
+	path := "/api/servers/" + server.ID.String() + "/files/uploads"
+	if uploadID != "" {
+		path += "/" + url.PathEscape(uploadID)
+	}
+	return path
+}
+
+// sendUploadResponse relays a node response along with the upload offset
+// headers clients use to resume.
+func sendUploadResponse(c *fiber.Ctx, resp *services.NodeResponse) error {
+	for _, name := range []string{"Upload-Offset", "Upload-Length"} {
+		if v := resp.Header.Get(name); v != "" {
+			c.Set(name, v)
+		}
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	if len(resp.Body) > 0 {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	return c.Status(resp.StatusCode).Send(resp.Body)
+}
+
+func CreateUpload(c *fiber.Ctx) error {
+	server, err := getServerWithFilePerm(c, models.PermFileUpload)
+	if err != nil {
+		return nil
+	}
+	var body struct {
+		Path     string `json:"path"`
+		Size     int64  `json:"size"`
+		Checksum string `json:"checksum"`
+	}
+	if err := c.BodyParser(&body); err != nil || body.Path == "" || body.Size < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "path and size required"})
+	}
+	user := c.Locals("user").(*models.User)
+	if allow, msg := plugins.Emit(plugins.EventFileUploading, map[string]string{"server_id": server.ID.String(), "path": body.Path, "user_id": user.ID.String()}); !allow {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": msg})
+	}
+
+	mixinInput := map[string]interface{}{
+		"server_id": server.ID.String(),
+		"path":      body.Path,
+		"user_id":   user.ID.String(),
+	}
+
+	_, err = plugins.ExecuteMixin(string(plugins.MixinFileUpload), mixinInput, func(input map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	if err != nil {
+		if mixinErr, ok := err.(*plugins.MixinError); ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "error": mixinErr.Message})
+		}
+	}
+
+	resp, err := services.ProxyToNode(server, "POST", uploadPath(server, ""), body)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		return sendUploadResponse(c, resp)
+	}
+
+	var result map[string]interface{}
+	if json.Unmarshal(resp.Body, &result) == nil {
+		if data, ok := result["data"].(map[string]interface{}); ok {
+			data["chunk_size"] = UploadChunkSize
+			resp.Body, _ = json.Marshal(result)
+		}
+	}
+	return sendUploadResponse(c, resp)
+}
+
+// GetUpload also answers HEAD requests, since fiber routes those to GET
+// handlers.
+func GetUpload(c *fiber.Ctx) error {
+	server, err := getServerWithFilePerm(c, models.PermFileUpload)
+	if err != nil {
+		return nil
+	}
+	resp, err := services.ProxyToNode(server, "GET", uploadPath(server, c.Params("uploadId")), nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return sendUploadResponse(c, resp)
+}
+
+func PatchUpload(c *fiber.Ctx) error {
+	server, err := getServerWithFilePerm(c, models.PermFileUpload)
+	if err != nil {
+		return nil
+	}
+	header := http.Header{}
+	for _, name := range []string{fiber.HeaderContentType, "Upload-Offset", "Upload-Checksum"} {
+		if v := c.Get(name); v != "" {
+			header.Set(name, v)
+		}
+	}
+	resp, err := services.ProxyRawToNode(server, "PATCH", uploadPath(server, c.Params("uploadId")), bytes.NewReader(c.Body()), header)
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return sendUploadResponse(c, resp)
+}
+
+func CompleteUpload(c *fiber.Ctx) error {
+	server, err := getServerWithFilePerm(c, models.PermFileUpload)
+	if err != nil {
+		return nil
+	}
+	resp, err := services.ProxyToNode(server, "POST", uploadPath(server, c.Params("uploadId"))+"/complete", nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	if resp.StatusCode == fiber.StatusOK {
+		var result struct {
+			Data struct {
+				Path string `json:"path"`
+				Size int64  `json:"size"`
+			} `json:"data"`
+		}
+		json.Unmarshal(resp.Body, &result)
+		user := c.Locals("user").(*models.User)
+		handlers.Log(c, user, handlers.ActionFileUpload, "Uploaded file", map[string]interface{}{"server_id": server.ID, "path": result.Data.Path, "size": result.Data.Size})
+		plugins.Emit(plugins.EventFileUploaded, map[string]string{"server_id": server.ID.String(), "path": result.Data.Path})
+	}
+	return sendUploadResponse(c, resp)
+}
+
+func CancelUpload(c *fiber.Ctx) error {
+	server, err := getServerWithFilePerm(c, models.PermFileUpload)
+	if err != nil {
+		return nil
+	}
+	resp, err := services.ProxyToNode(server, "DELETE", uploadPath(server, c.Params("uploadId")), nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return sendUploadResponse(c, resp)
+}
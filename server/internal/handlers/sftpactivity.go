@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// HandleSFTPActivityReport records file events from a node's SFTP server in
+// the activity log. Events for servers the node does not host, unknown users
+// or unknown actions are dropped so that one bad event cannot wedge the
+// node's retry queue.
+func HandleSFTPActivityReport(nodeID uuid.UUID, report services.SFTPActivityReport) int {
+	servers := map[uuid.UUID]*models.Server{}
+	users := map[uuid.UUID]*models.User{}
+	recorded := 0
+
+	for _, ev := range report.Events {
+		serverID, err := uuid.Parse(ev.ServerID)
+		if err != nil {
+			continue
+		}
+		userID, err := uuid.Parse(ev.UserID)
+		if err != nil {
+			continue
+		}
+
+		server, ok := servers[serverID]
+		if !ok {
+			var s models.Server
+			if database.DB.Where("id = ? AND node_id = ?", serverID, nodeID).First(&s).Error == nil {
+				server = &s
+			}
+			servers[serverID] = server
+		}
+		user, ok := users[userID]
+		if !ok {
+			var u models.User
+			if database.DB.Where("id = ?", userID).First(&u).Error == nil {
+				user = &u
+			}
+			users[userID] = user
+		}
+		if server == nil || user == nil {
+			continue
+		}
+
+		action, description := describeSFTPEvent(ev)
+		if action == "" {
+			continue
+		}
+
+		metadata := map[string]interface{}{
+			"server_id":   server.ID,
+			"server_name": server.Name,
+			"source":      "sftp",
+			"path":        ev.Path,
+		}
+		if ev.Target != "" {
+			metadata["target"] = ev.Target
+		}
+		if ev.Action == "write" {
+			metadata["bytes"] = ev.Bytes
+		}
+		meta, _ := json.Marshal(metadata)
+
+		entry := models.ActivityLog{
+			UserID:      user.ID,
+			Username:    user.Username,
+			Action:      action,
+			Description: description,
+			IP:          ev.IP,
+			UserAgent:   ev.Client,
+			IsAdmin:     user.IsAdmin,
+			Metadata:    string(meta),
+		}
+		if !ev.Time.IsZero() {
+			entry.CreatedAt = ev.Time
+		}
+		if database.DB.Create(&entry).Error == nil {
+			recorded++
+		}
+	}
+
+	return recorded
+}
+
+func describeSFTPEvent(ev services.SFTPEvent) (string, string) {
+	switch ev.Action {
+	case "write":
+		return ActionSFTPWrite, fmt.Sprintf("Wrote %s (%d bytes) over SFTP", ev.Path, ev.Bytes)
+	case "delete":
+		return ActionSFTPDelete, fmt.Sprintf("Deleted %s over SFTP", ev.Path)
+	case "rename":
+		return ActionSFTPRename, fmt.Sprintf("Renamed %s to %s over SFTP", ev.Path, ev.Target)
+	case "create_folder":
+		return ActionSFTPCreateFolder, fmt.Sprintf("Created folder %s over SFTP", ev.Path)
+	}
+	return "", ""
+}
@@ -2,8 +2,6 @@ package handlers
 
 import (
 	"encoding/json"
-	"fmt"
-	"net/url"
 	"sync"
 	"time"
 
@@ -59,14 +57,7 @@ func ServerLogsWS(c *websocket.Conn) {
 		return
 	}
 
-	nodeURL := fmt.Sprintf("ws://%s:%d/api/servers/%s/ws?token=%s",
-		server.Node.FQDN, server.Node.Port, server.ID.String(),
-		url.QueryEscape(server.Node.DaemonToken))
-
-	dialer := gorilla.Dialer{
-		HandshakeTimeout: 10 * time.Second,
-	}
-	nodeConn, _, err := dialer.Dial(nodeURL, nil)
+	nodeConn, err := services.DialNodeWebSocket(server.Node, "/api/servers/"+server.ID.String()+"/ws")
 	if err != nil {
 		c.WriteJSON(map[string]string{"error": "Failed to connect to node: " + err.Error()})
 		return
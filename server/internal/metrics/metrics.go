@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type kind string
+
+const (
+	kindCounter   kind = "counter"
+	kindGauge     kind = "gauge"
+	kindHistogram kind = "histogram"
+)
+
+type series struct {
+	values []string
+	value  float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    kind
+	labels  []string
+	buckets []float64
+	collect func(set func(value float64, labels ...string))
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+var (
+	registry   []*family
+	registryMu sync.Mutex
+)
+
+func register(f *family) *family {
+	f.series = make(map[string]*series)
+	registryMu.Lock()
+	registry = append(registry, f)
+	registryMu.Unlock()
+	return f
+}
+
+func (f *family) get(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d labels, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: append([]string(nil), values...)}
+		if f.kind == kindHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+type Counter struct{ f *family }
+
+func NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{register(&family{name: name, help: help, kind: kindCounter, labels: labels})}
+}
+
+func (c *Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+func (c *Counter) Add(v float64, labels ...string) {
+	c.f.mu.Lock()
+	c.f.get(labels).value += v
+	c.f.mu.Unlock()
+}
+
+type Histogram struct{ f *family }
+
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{register(&family{name: name, help: help, kind: kindHistogram, labels: labels, buckets: buckets})}
+}
+
+func (h *Histogram) Observe(v float64, labels ...string) {
+	h.f.mu.Lock()
+	s := h.f.get(labels)
+	for i, upper := range h.f.buckets {
+		if v <= upper {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+	h.f.mu.Unlock()
+}
+
+func (h *Histogram) ObserveSince(start time.Time, labels ...string) {
+	h.Observe(time.Since(start).Seconds(), labels...)
+}
+
+// NewGaugeFunc registers a gauge whose values are produced by collect on every
+// scrape, for state that already lives elsewhere such as node counts.
+func NewGaugeFunc(name, help string, labels []string, collect func(set func(value float64, labels ...string))) {
+	register(&family{name: name, help: help, kind: kindGauge, labels: labels, collect: collect})
+}
+
+func Write(w io.Writer) {
+	registryMu.Lock()
+	families := append([]*family(nil), registry...)
+	registryMu.Unlock()
+
+	for _, f := range families {
+		f.write(w)
+	}
+	io.WriteString(w, "# EOF\n")
+}
+
+func (f *family) write(w io.Writer) {
+	var list []*series
+	if f.collect != nil {
+		collected := make(map[string]*series)
+		f.collect(func(value float64, labels ...string) {
+			key := strings.Join(labels, "\xff")
+			collected[key] = &series{values: labels, value: value}
+		})
+		for _, s := range collected {
+			list = append(list, s)
+		}
+	} else {
+		f.mu.Lock()
+		for _, s := range f.series {
+			copied := *s
+			copied.counts = append([]uint64(nil), s.counts...)
+			list = append(list, &copied)
+		}
+		f.mu.Unlock()
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.Join(list[i].values, "\xff") < strings.Join(list[j].values, "\xff")
+	})
+
+	fmt.Fprintf(w, "# TYPE %s %s\n# HELP %s %s\n", f.name, f.kind, f.name, f.help)
+	for _, s := range list {
+		labels := formatLabels(f.labels, s.values)
+		switch f.kind {
+		case kindCounter:
+			fmt.Fprintf(w, "%s_total%s %s\n", f.name, labels, formatValue(s.value))
+		case kindGauge:
+			fmt.Fprintf(w, "%s%s %s\n", f.name, labels, formatValue(s.value))
+		case kindHistogram:
+			for i, upper := range f.buckets {
+				fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, withLabel(labels, "le", formatValue(upper)), s.counts[i])
+			}
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, withLabel(labels, "le", "+Inf"), s.count)
+			fmt.Fprintf(w, "%s_sum%s %s\n", f.name, labels, formatValue(s.sum))
+			fmt.Fprintf(w, "%s_count%s %d\n", f.name, labels, s.count)
+		}
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + `="` + labelEscaper.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func withLabel(labels, name, value string) string {
+	pair := name + `="` + value + `"`
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+	return strings.TrimSuffix(labels, "}") + "," + pair + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,10 @@
+package metrics
+
+var (
+	HTTPRequestDuration = NewHistogram("birdactyl_http_request_duration_seconds", "Time taken to serve API requests by route.", DefaultBuckets, "method", "route", "status")
+	RateLimitRejections = NewCounter("birdactyl_rate_limit_rejections", "Requests rejected by the ThousandTHR rate limiter by route.", "route")
+	PluginEventDuration = NewHistogram("birdactyl_plugin_event_duration_seconds", "Time taken by a plugin to handle an event.", DefaultBuckets, "event", "plugin", "mode")
+	PluginEventFailures = NewCounter("birdactyl_plugin_event_failures", "Plugin event deliveries that failed.", "event", "plugin")
+	ScheduleRuns        = NewCounter("birdactyl_schedule_runs", "Finished schedule runs by status and trigger.", "status", "trigger")
+	ScheduleRunDuration = NewHistogram("birdactyl_schedule_run_duration_seconds", "Time taken by schedule runs.", []float64{.1, .5, 1, 5, 15, 30, 60, 300, 900}, "status")
+)
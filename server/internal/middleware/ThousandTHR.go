@@ -9,6 +9,8 @@ import (
 	"sync"
 	"time"
 
+	"birdactyl-panel-backend/internal/metrics"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -97,6 +99,7 @@ func ThousandTHR(config ThousandTHRConfig) fiber.Handler {
 		c.Set("X-RateLimit-Reset", fmt.Sprintf("%d", resetIn))
 
 		if !allowed {
+			metrics.RateLimitRejections.Inc(c.Route().Path)
 			c.Set("Retry-After", fmt.Sprintf("%d", resetIn))
 			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
 				"success": false,
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"birdactyl-panel-backend/internal/config"
+	"birdactyl-panel-backend/internal/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RequestMetrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			status = fiberErr.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+		metrics.HTTPRequestDuration.ObserveSince(start, c.Method(), c.Route().Path, strconv.Itoa(status))
+		return err
+	}
+}
+
+func RequireMetricsToken() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		cfg := config.Get()
+		if cfg == nil || cfg.Metrics.Token == "" {
+			return fiber.ErrNotFound
+		}
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Metrics.Token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"error":   "Invalid metrics token",
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"birdactyl-panel-backend/internal/metrics"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	sftpUserLimit = ThousandTHRConfig{RequestsPerMinute: 5, BurstLimit: 10}
+	sftpIPLimit   = ThousandTHRConfig{RequestsPerMinute: 10, BurstLimit: 20}
+)
+
+// SFTPAuthLimit throttles SFTP password attempts per username and per client
+// address. Successful logins give their tokens back, so only failures count
+// toward the limit. Key logins are not throttled since axis has already
+// verified the key signature.
+func SFTPAuthLimit() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req struct {
+			Username    string `json:"username"`
+			Fingerprint string `json:"fingerprint"`
+			RemoteIP    string `json:"remote_ip"`
+		}
+		if err := c.BodyParser(&req); err != nil || req.Fingerprint != "" {
+			return c.Next()
+		}
+
+		node := c.Locals("node").(*models.Node)
+		keys := []string{
+			"sftp:user:" + strings.ToLower(req.Username) + ":" + generateConfigHash(sftpUserLimit),
+			"sftp:ip:" + node.ID.String() + ":" + req.RemoteIP + ":" + generateConfigHash(sftpIPLimit),
+		}
+		limits := []ThousandTHRConfig{sftpUserLimit, sftpIPLimit}
+		for i, key := range keys {
+			allowed, _, resetIn := checkRateLimit(key, limits[i], float64(limits[i].RequestsPerMinute)/60.0)
+			if !allowed {
+				metrics.RateLimitRejections.Inc(c.Route().Path)
+				c.Set("Retry-After", fmt.Sprintf("%d", resetIn))
+				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"success": false, "error": "Too many failed attempts"})
+			}
+		}
+
+		err := c.Next()
+
+		if c.Response().StatusCode() == fiber.StatusOK {
+			for _, key := range keys {
+				refundToken(key)
+			}
+		}
+		return err
+	}
+}
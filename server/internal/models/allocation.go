@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Allocation struct {
+	ID        uuid.UUID  `json:"id" gorm:"primaryKey"`
+	NodeID    uuid.UUID  `json:"node_id" gorm:"not null;uniqueIndex:idx_allocation_node_ip_port"`
+	IP        string     `json:"ip" gorm:"type:varchar(45);not null;uniqueIndex:idx_allocation_node_ip_port"`
+	Port      int        `json:"port" gorm:"not null;uniqueIndex:idx_allocation_node_ip_port"`
+	Alias     string     `json:"alias" gorm:"type:varchar(255)"`
+	ServerID  *uuid.UUID `json:"server_id" gorm:"index"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (a *Allocation) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupStatus string
+
+const (
+	BackupPending   BackupStatus = "pending"
+	BackupCompleted BackupStatus = "completed"
+	BackupFailed    BackupStatus = "failed"
+)
+
+type Backup struct {
+	ID           uuid.UUID    `json:"id" gorm:"primaryKey"`
+	ServerID     uuid.UUID    `json:"server_id" gorm:"not null;index"`
+	NodeBackupID string       `json:"node_backup_id" gorm:"type:varchar(255);not null;index"`
+	Name         string       `json:"name" gorm:"type:varchar(255);not null"`
+	Size         int64        `json:"size"`
+	Checksum     string       `json:"checksum" gorm:"type:varchar(64)"`
+	Storage      string       `json:"storage" gorm:"type:varchar(20)"`
+	Locked       bool         `json:"locked" gorm:"default:false"`
+	Status       BackupStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Error        string       `json:"error,omitempty" gorm:"type:text"`
+	Warnings     string       `json:"warnings,omitempty" gorm:"type:text"`
+	CreatedBy    *uuid.UUID   `json:"created_by"`
+	CreatedAt    time.Time    `json:"created_at"`
+	CompletedAt  *time.Time   `json:"completed_at"`
+}
+
+func (b *Backup) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	if b.NodeBackupID == "" {
+		b.NodeBackupID = b.ID.String()
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupTargetType string
+
+const (
+	BackupTargetS3   BackupTargetType = "s3"
+	BackupTargetSFTP BackupTargetType = "sftp"
+)
+
+type BackupTarget struct {
+	ID         uuid.UUID        `json:"id" gorm:"primaryKey"`
+	Name       string           `json:"name" gorm:"type:varchar(255);not null"`
+	Type       BackupTargetType `json:"type" gorm:"type:varchar(20);not null"`
+	Endpoint   string           `json:"endpoint" gorm:"type:varchar(500)"`
+	Region     string           `json:"region" gorm:"type:varchar(100)"`
+	Bucket     string           `json:"bucket" gorm:"type:varchar(255)"`
+	Prefix     string           `json:"prefix" gorm:"type:varchar(500)"`
+	AccessKey  string           `json:"access_key" gorm:"type:varchar(255)"`
+	SecretKey  string           `json:"-" gorm:"type:varchar(500)"`
+	PathStyle  bool             `json:"path_style" gorm:"default:false"`
+	Host       string           `json:"host" gorm:"type:varchar(255)"`
+	Port       int              `json:"port"`
+	Username   string           `json:"username" gorm:"type:varchar(255)"`
+	Password   string           `json:"-" gorm:"type:varchar(255)"`
+	PrivateKey string           `json:"-" gorm:"type:text"`
+	HostKey    string           `json:"host_key" gorm:"type:varchar(255)"`
+	Path       string           `json:"path" gorm:"type:varchar(500)"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+func (t *BackupTarget) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type Location struct {
+	ID          uuid.UUID      `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"type:varchar(255);uniqueIndex;not null"`
+	Description string         `json:"description" gorm:"type:text"`
+	Region      string         `json:"region" gorm:"type:varchar(100)"`
+	Tags        datatypes.JSON `json:"tags" gorm:"type:json"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+func (l *Location) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	if l.Tags == nil {
+		l.Tags = []byte("[]")
+	}
+	return nil
+}
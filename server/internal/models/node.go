@@ -10,28 +10,67 @@ import (
 )
 
 type Node struct {
-	ID            uuid.UUID      `gorm:"primaryKey" json:"id"`
-	Name          string         `gorm:"type:varchar(255);not null" json:"name"`
-	Icon          string         `gorm:"type:varchar(500)" json:"icon"`
-	FQDN          string         `gorm:"type:varchar(255);not null" json:"fqdn"`
-	Port          int            `gorm:"not null;default:8443" json:"port"`
-	TokenID       string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"-"`
-	TokenHash     string         `gorm:"type:varchar(255);not null" json:"-"`
-	DaemonToken   string         `gorm:"type:varchar(255);not null" json:"-"`
-	IsOnline      bool           `gorm:"default:false" json:"is_online"`
-	AuthError     bool           `gorm:"default:false" json:"auth_error"`
-	LastHeartbeat *time.Time     `json:"last_heartbeat"`
-	SystemInfo    SystemInfo     `gorm:"type:json" json:"system_info"`
-	DisplayIP     string         `gorm:"type:varchar(255)" json:"display_ip"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uuid.UUID      `gorm:"primaryKey" json:"id"`
+	Name            string         `gorm:"type:varchar(255);not null" json:"name"`
+	Icon            string         `gorm:"type:varchar(500)" json:"icon"`
+	FQDN            string         `gorm:"type:varchar(255);not null" json:"fqdn"`
+	Port            int            `gorm:"not null;default:8443" json:"port"`
+	Scheme          string         `gorm:"type:varchar(10);not null;default:'http'" json:"scheme"`
+	CertFingerprint string         `gorm:"type:varchar(64)" json:"cert_fingerprint"`
+	Tunnel          bool           `gorm:"default:false" json:"tunnel"`
+	TokenID         string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"-"`
+	TokenHash       string         `gorm:"type:varchar(255);not null" json:"-"`
+	DaemonToken     string         `gorm:"type:varchar(255);not null" json:"-"`
+	IsOnline        bool           `gorm:"default:false" json:"is_online"`
+	AuthError       bool           `gorm:"default:false" json:"auth_error"`
+	LastHeartbeat   *time.Time     `json:"last_heartbeat"`
+	SystemInfo      SystemInfo     `gorm:"type:json" json:"system_info"`
+	DisplayIP       string         `gorm:"type:varchar(255)" json:"display_ip"`
+	BackupTargetID  *uuid.UUID     `gorm:"index" json:"backup_target_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	LocationID *uuid.UUID `gorm:"index" json:"location_id"`
+
+	MemoryLimit        int `gorm:"default:0" json:"memory_limit"`
+	MemoryOverallocate int `gorm:"default:0" json:"memory_overallocate"`
+	DiskLimit          int `gorm:"default:0" json:"disk_limit"`
+	DiskOverallocate   int `gorm:"default:0" json:"disk_overallocate"`
+	CPULimit           int `gorm:"default:0" json:"cpu_limit"`
+	CPUOverallocate    int `gorm:"default:0" json:"cpu_overallocate"`
+
+	Allocation *NodeAllocation `gorm:"-" json:"allocation,omitempty"`
+}
+
+// NodeAllocation summarises what the servers on a node have been given against
+// the node's limits. Max is the ceiling after overallocation, 0 when unenforced.
+type NodeAllocation struct {
+	Servers int                `json:"servers"`
+	Memory  ResourceAllocation `json:"memory"`
+	Disk    ResourceAllocation `json:"disk"`
+	CPU     ResourceAllocation `json:"cpu"`
 }
 
+type ResourceAllocation struct {
+	Allocated    int `json:"allocated"`
+	Limit        int `json:"limit"`
+	Overallocate int `json:"overallocate"`
+	Max          int `json:"max"`
+}
+
+const (
+	NodeSchemeHTTP  = "http"
+	NodeSchemeHTTPS = "https"
+)
+
 func (n *Node) BeforeCreate(tx *gorm.DB) error {
 	if n.ID == uuid.Nil {
 		n.ID = uuid.New()
 	}
+	if n.Scheme == "" {
+		n.Scheme = NodeSchemeHTTP
+	}
 	return nil
 }
 
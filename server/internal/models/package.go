@@ -28,6 +28,23 @@ type PackageConfigFile struct {
 	Template string `json:"template"`
 }
 
+type PackageBackupHook struct {
+	Command string `json:"command"`
+	WaitFor string `json:"wait_for,omitempty"`
+	Timeout int    `json:"timeout,omitempty"`
+}
+
+type PackageBackupHooks struct {
+	Pre  []PackageBackupHook `json:"pre"`
+	Post []PackageBackupHook `json:"post"`
+}
+
+type CrashPolicy struct {
+	MaxRestarts int `json:"max_restarts"`
+	Window      int `json:"window"`
+	Backoff     int `json:"backoff"`
+}
+
 type AddonSourceMapping struct {
 	Results     string `json:"results"`
 	ID          string `json:"id"`
@@ -77,6 +94,11 @@ type Package struct {
 	Variables           datatypes.JSON `json:"variables" gorm:"type:json"`
 	ConfigFiles         datatypes.JSON `json:"config_files" gorm:"type:json"`
 	AddonSources        datatypes.JSON `json:"addon_sources" gorm:"type:json"`
+	BackupTargetID      *uuid.UUID     `json:"backup_target_id" gorm:"index"`
+	BackupIgnore        string         `json:"backup_ignore" gorm:"type:text"`
+	BackupHooks         datatypes.JSON `json:"backup_hooks" gorm:"type:json"`
+	CrashPolicy         datatypes.JSON `json:"crash_policy" gorm:"type:json"`
+	AllowedLocations    datatypes.JSON `json:"allowed_locations" gorm:"type:json"`
 	CreatedAt           time.Time      `json:"created_at"`
 	UpdatedAt           time.Time      `json:"updated_at"`
 }
@@ -97,5 +119,11 @@ func (p *Package) BeforeCreate(tx *gorm.DB) error {
 	if p.AddonSources == nil {
 		p.AddonSources = []byte("[]")
 	}
+	if p.BackupHooks == nil {
+		p.BackupHooks = []byte("{}")
+	}
+	if p.AllowedLocations == nil {
+		p.AllowedLocations = []byte("[]")
+	}
 	return nil
 }
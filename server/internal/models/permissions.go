@@ -54,8 +54,7 @@ const (
 
 	PermActivityView = "activity.view"
 
-	PermSFTPView          = "sftp.view"
-	PermSFTPResetPassword = "sftp.reset_password"
+	PermSFTPView = "sftp.view"
 
 	PermMountRead   = "mount.read"
 	PermMountUpdate = "mount.update"
@@ -77,7 +76,7 @@ var AllPermissions = []string{
 	PermStartupView, PermStartupUpdate,
 	PermReinstall,
 	PermActivityView,
-	PermSFTPView, PermSFTPResetPassword,
+	PermSFTPView,
 	PermMountRead, PermMountUpdate,
 }
 
@@ -93,7 +92,7 @@ var PermissionGroups = map[string][]string{
 	"startup":    {PermStartupView, PermStartupUpdate},
 	"server":     {PermReinstall},
 	"activity":   {PermActivityView},
-	"sftp":       {PermSFTPView, PermSFTPResetPassword},
+	"sftp":       {PermSFTPView},
 	"mount":      {PermMountRead, PermMountUpdate},
 }
 
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,19 +14,108 @@ type Schedule struct {
 	ServerID       uuid.UUID      `json:"server_id" gorm:"index;not null"`
 	Name           string         `json:"name" gorm:"type:varchar(255);not null"`
 	CronExpression string         `json:"cron_expression" gorm:"type:varchar(100);not null"`
+	Timezone       string         `json:"timezone" gorm:"type:varchar(64)"`
 	IsActive       bool           `json:"is_active" gorm:"default:true"`
 	OnlyWhenOnline bool           `json:"only_when_online" gorm:"default:false"`
 	LastRunAt      *time.Time     `json:"last_run_at"`
 	NextRunAt      *time.Time     `json:"next_run_at"`
+	MisfirePolicy  MisfirePolicy  `json:"misfire_policy" gorm:"type:varchar(20)"`
+	LeaseOwner     string         `json:"-" gorm:"type:varchar(64)"`
+	LeaseExpiresAt *time.Time     `json:"-"`
 	Tasks          datatypes.JSON `json:"tasks" gorm:"type:json"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 }
 
+type MisfirePolicy string
+
+const (
+	MisfireSkip    MisfirePolicy = "skip"
+	MisfireRunOnce MisfirePolicy = "run_once"
+	MisfireRunAll  MisfirePolicy = "run_all"
+)
+
 type ScheduleTask struct {
-	Sequence int    `json:"sequence"`
-	Action   string `json:"action"`
-	Payload  string `json:"payload"`
+	Sequence          int    `json:"sequence"`
+	Action            string `json:"action"`
+	Payload           string `json:"payload"`
+	ContinueOnFailure bool   `json:"continue_on_failure"`
+}
+
+// UnmarshalJSON treats a missing continue_on_failure as true, so tasks saved
+// before the field existed keep running past failures as they always did.
+func (t *ScheduleTask) UnmarshalJSON(data []byte) error {
+	type plain ScheduleTask
+	task := plain{ContinueOnFailure: true}
+	if err := json.Unmarshal(data, &task); err != nil {
+		return err
+	}
+	*t = ScheduleTask(task)
+	return nil
+}
+
+type ScheduleTrigger string
+
+const (
+	ScheduleTriggerCron    ScheduleTrigger = "cron"
+	ScheduleTriggerManual  ScheduleTrigger = "manual"
+	ScheduleTriggerCatchUp ScheduleTrigger = "catch_up"
+)
+
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunRunning ScheduleRunStatus = "running"
+	ScheduleRunSuccess ScheduleRunStatus = "success"
+	ScheduleRunFailed  ScheduleRunStatus = "failed"
+	ScheduleRunSkipped ScheduleRunStatus = "skipped"
+)
+
+type ScheduleRun struct {
+	ID         uuid.UUID         `json:"id" gorm:"primaryKey"`
+	ScheduleID uuid.UUID         `json:"schedule_id" gorm:"index;not null"`
+	ServerID   uuid.UUID         `json:"server_id" gorm:"index;not null"`
+	Trigger    ScheduleTrigger   `json:"trigger" gorm:"type:varchar(20);not null"`
+	Status     ScheduleRunStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Error      string            `json:"error,omitempty" gorm:"type:text"`
+	Results    datatypes.JSON    `json:"results" gorm:"type:json"`
+	StartedAt  time.Time         `json:"started_at" gorm:"index"`
+	FinishedAt *time.Time        `json:"finished_at"`
+}
+
+type ScheduleTaskResult struct {
+	Sequence   int       `json:"sequence"`
+	Action     string    `json:"action"`
+	Payload    string    `json:"payload"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Halted     bool      `json:"halted,omitempty"`
+	Skipped    bool      `json:"skipped,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+func (s *Schedule) Location() *time.Location {
+	if s.Timezone != "" {
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+func (s *Schedule) AfterFind(tx *gorm.DB) error {
+	loc := s.Location()
+	if s.NextRunAt != nil {
+		t := s.NextRunAt.In(loc)
+		s.NextRunAt = &t
+	}
+	if s.LastRunAt != nil {
+		t := s.LastRunAt.In(loc)
+		s.LastRunAt = &t
+	}
+	return nil
 }
 
 func (s *Schedule) BeforeCreate(tx *gorm.DB) error {
@@ -37,3 +127,13 @@ func (s *Schedule) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+func (r *ScheduleRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Results == nil {
+		r.Results = []byte("[]")
+	}
+	return nil
+}
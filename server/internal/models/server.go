@@ -19,25 +19,28 @@ const (
 )
 
 type Server struct {
-	ID           uuid.UUID      `json:"id" gorm:"primaryKey"`
-	Name         string         `json:"name" gorm:"type:varchar(255);not null"`
-	Description  string         `json:"description" gorm:"type:varchar(500)"`
-	UserID       uuid.UUID      `json:"user_id" gorm:"not null;index"`
-	NodeID       uuid.UUID      `json:"node_id" gorm:"not null;index"`
-	PackageID    uuid.UUID      `json:"package_id" gorm:"not null"`
-	Status       ServerStatus   `json:"status" gorm:"type:varchar(20);default:'installing'"`
-	IsSuspended  bool           `json:"is_suspended" gorm:"default:false"`
-	ContainerID  string         `json:"container_id,omitempty" gorm:"type:varchar(64)"`
-	Memory       int            `json:"memory" gorm:"not null"`
-	CPU          int            `json:"cpu" gorm:"not null"`
-	Disk         int            `json:"disk" gorm:"not null"`
-	Startup      string         `json:"startup" gorm:"type:text"`
-	DockerImage  string         `json:"docker_image" gorm:"type:varchar(500)"`
-	Ports        datatypes.JSON `json:"ports" gorm:"type:json"`
-	Variables    datatypes.JSON `json:"variables" gorm:"type:json"`
-	SFTPPassword string         `json:"-" gorm:"type:varchar(255)"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
+	ID          uuid.UUID      `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"type:varchar(255);not null"`
+	Description string         `json:"description" gorm:"type:varchar(500)"`
+	UserID      uuid.UUID      `json:"user_id" gorm:"not null;index"`
+	NodeID      uuid.UUID      `json:"node_id" gorm:"not null;index"`
+	PackageID   uuid.UUID      `json:"package_id" gorm:"not null"`
+	Status      ServerStatus   `json:"status" gorm:"type:varchar(20);default:'installing'"`
+	IsSuspended bool           `json:"is_suspended" gorm:"default:false"`
+	ContainerID string         `json:"container_id,omitempty" gorm:"type:varchar(64)"`
+	Memory      int            `json:"memory" gorm:"not null"`
+	CPU         int            `json:"cpu" gorm:"not null"`
+	Disk        int            `json:"disk" gorm:"not null"`
+	Startup     string         `json:"startup" gorm:"type:text"`
+	DockerImage string         `json:"docker_image" gorm:"type:varchar(500)"`
+	Ports       datatypes.JSON `json:"ports" gorm:"type:json"`
+	Variables   datatypes.JSON `json:"variables" gorm:"type:json"`
+	BackupLimit *int           `json:"backup_limit" gorm:"default:null"`
+	Health      string         `json:"health,omitempty" gorm:"type:varchar(20)"`
+	StateAt     *time.Time     `json:"state_at,omitempty"`
+	CrashPolicy datatypes.JSON `json:"crash_policy" gorm:"type:json"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
 
 	User    *User    `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Node    *Node    `json:"node,omitempty" gorm:"foreignKey:NodeID"`
@@ -46,8 +49,10 @@ type Server struct {
 }
 
 type ServerPort struct {
-	Port    int  `json:"port"`
-	Primary bool `json:"primary,omitempty"`
+	Port    int    `json:"port"`
+	Primary bool   `json:"primary,omitempty"`
+	IP      string `json:"ip,omitempty"`
+	Alias   string `json:"alias,omitempty"`
 }
 
 func (s *Server) BeforeCreate(tx *gorm.DB) error {
@@ -62,3 +67,28 @@ func (s *Server) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+type CrashAction string
+
+const (
+	CrashActionNone      CrashAction = "none"
+	CrashActionRestarted CrashAction = "restarted"
+	CrashActionFailed    CrashAction = "failed"
+)
+
+type ServerCrash struct {
+	ID        uuid.UUID   `json:"id" gorm:"primaryKey"`
+	ServerID  uuid.UUID   `json:"server_id" gorm:"not null;index"`
+	ExitCode  int         `json:"exit_code"`
+	OOM       bool        `json:"oom"`
+	Logs      string      `json:"logs" gorm:"type:text"`
+	Action    CrashAction `json:"action" gorm:"type:varchar(20)"`
+	CreatedAt time.Time   `json:"created_at" gorm:"index"`
+}
+
+func (c *ServerCrash) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SSHKey struct {
+	ID          uuid.UUID  `gorm:"primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"uniqueIndex:idx_ssh_key_user_fingerprint;not null" json:"user_id"`
+	Name        string     `gorm:"type:varchar(255);not null" json:"name"`
+	Fingerprint string     `gorm:"uniqueIndex:idx_ssh_key_user_fingerprint;type:varchar(64);not null" json:"fingerprint"`
+	PublicKey   string     `gorm:"type:text;not null" json:"public_key"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+func (k *SSHKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
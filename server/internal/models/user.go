@@ -24,6 +24,8 @@ type User struct {
 	CPULimit           *int           `gorm:"default:null" json:"cpu_limit"`
 	DiskLimit          *int           `gorm:"default:null" json:"disk_limit"`
 	ServerLimit        *int           `gorm:"default:null" json:"server_limit"`
+	BackupLimit        *int           `gorm:"default:null" json:"backup_limit"`
+	BackupStorageLimit *int           `gorm:"default:null" json:"backup_storage_limit"`
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
 	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"birdactyl-panel-backend/internal/metrics"
 	pb "birdactyl-panel-backend/internal/plugins/proto"
 )
 
@@ -41,7 +42,9 @@ func emitSync(legacyPlugins []*Plugin, streamPlugins []*PluginStream, ev *pb.Eve
 	defer cancel()
 
 	for _, p := range legacyPlugins {
+		start := time.Now()
 		resp, err := p.Client.OnEvent(ctx, ev)
+		observeEvent(ev, p.Config.ID, start, err)
 		if err != nil {
 			log.Printf("[plugins] sync event %s to %s failed: %v", ev.Type, p.Config.ID, err)
 			GetRegistry().SetOnline(p.Config.ID, false)
@@ -53,7 +56,9 @@ func emitSync(legacyPlugins []*Plugin, streamPlugins []*PluginStream, ev *pb.Eve
 	}
 
 	for _, ps := range streamPlugins {
+		start := time.Now()
 		resp, err := ps.SendEvent(ev)
+		observeEvent(ev, ps.ID, start, err)
 		if err != nil {
 			log.Printf("[plugins] sync event %s to %s failed: %v", ev.Type, ps.ID, err)
 			continue
@@ -71,7 +76,9 @@ func emitAsync(legacyPlugins []*Plugin, streamPlugins []*PluginStream, ev *pb.Ev
 	defer cancel()
 
 	for _, p := range legacyPlugins {
+		start := time.Now()
 		_, err := p.Client.OnEvent(ctx, ev)
+		observeEvent(ev, p.Config.ID, start, err)
 		if err != nil {
 			log.Printf("[plugins] async event %s to %s failed: %v", ev.Type, p.Config.ID, err)
 			GetRegistry().SetOnline(p.Config.ID, false)
@@ -79,9 +86,22 @@ func emitAsync(legacyPlugins []*Plugin, streamPlugins []*PluginStream, ev *pb.Ev
 	}
 
 	for _, ps := range streamPlugins {
+		start := time.Now()
 		_, err := ps.SendEvent(ev)
+		observeEvent(ev, ps.ID, start, err)
 		if err != nil {
 			log.Printf("[plugins] async event %s to %s failed: %v", ev.Type, ps.ID, err)
 		}
 	}
 }
+
+func observeEvent(ev *pb.Event, pluginID string, start time.Time, err error) {
+	mode := "async"
+	if ev.Sync {
+		mode = "sync"
+	}
+	metrics.PluginEventDuration.ObserveSince(start, ev.Type, pluginID, mode)
+	if err != nil {
+		metrics.PluginEventFailures.Inc(ev.Type, pluginID)
+	}
+}
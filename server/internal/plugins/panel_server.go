@@ -94,6 +94,9 @@ func (s *PanelServer) UpdateServer(ctx context.Context, req *pb.UpdateServerRequ
 	if err := database.DB.First(&server, "id = ?", req.Id).Error; err != nil {
 		return nil, status.Error(codes.NotFound, "server not found")
 	}
+	if _, err := services.CheckServerResize(&server, int(req.Memory), int(req.Cpu), int(req.Disk)); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
 	if req.Name != "" {
 		server.Name = req.Name
 	}
@@ -699,25 +702,50 @@ func (s *PanelServer) DecompressFile(ctx context.Context, req *pb.FilePathReques
 	return &pb.Empty{}, nil
 }
 
+func (s *PanelServer) SearchFileContents(ctx context.Context, req *pb.SearchFileContentsRequest) (*pb.SearchFileContentsResponse, error) {
+	var server models.Server
+	if err := database.DB.Preload("Node").First(&server, "id = ?", req.ServerId).Error; err != nil {
+		return nil, status.Error(codes.NotFound, "server not found")
+	}
+	if req.Pattern == "" {
+		return nil, status.Error(codes.InvalidArgument, "pattern required")
+	}
+	matches, summary, err := services.SearchFileContents(&server, services.FileGrepOptions{
+		Pattern:       req.Pattern,
+		Regex:         req.Regex,
+		CaseSensitive: req.CaseSensitive,
+		Path:          req.Path,
+		Include:       strings.Join(req.Include, ","),
+		MaxFileSize:   req.MaxFileSize,
+		Limit:         int(req.Limit),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result := make([]*pb.FileContentMatch, len(matches))
+	for i, m := range matches {
+		result[i] = &pb.FileContentMatch{Path: m.Path, Line: int32(m.Line), Text: m.Text}
+	}
+	return &pb.SearchFileContentsResponse{
+		Matches:      result,
+		FilesScanned: int32(summary.FilesScanned),
+		FilesSkipped: int32(summary.FilesSkipped),
+		Truncated:    summary.Truncated,
+	}, nil
+}
+
 func (s *PanelServer) ListBackups(ctx context.Context, req *pb.IDRequest) (*pb.ListBackupsResponse, error) {
 	var server models.Server
 	if err := database.DB.Preload("Node").First(&server, "id = ?", req.Id).Error; err != nil {
 		return nil, status.Error(codes.NotFound, "server not found")
 	}
-	data, _ := services.ProxyGetToNode(&server, "/backups")
-	var resp struct {
-		Data []struct {
-			ID        string `json:"id"`
-			Name      string `json:"name"`
-			Size      int64  `json:"size"`
-			CreatedAt string `json:"created_at"`
-		} `json:"data"`
+	backups, err := services.ListServerBackups(server.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
-	b, _ := json.Marshal(data)
-	json.Unmarshal(b, &resp)
-	result := make([]*pb.Backup, len(resp.Data))
-	for i, bk := range resp.Data {
-		result[i] = &pb.Backup{Id: bk.ID, Name: bk.Name, Size: bk.Size, CreatedAt: bk.CreatedAt}
+	result := make([]*pb.Backup, len(backups))
+	for i, bk := range backups {
+		result[i] = &pb.Backup{Id: bk.NodeBackupID, Name: bk.Name, Size: bk.Size, CreatedAt: bk.CreatedAt.String()}
 	}
 	return &pb.ListBackupsResponse{Backups: result}, nil
 }
@@ -727,8 +755,15 @@ func (s *PanelServer) CreateBackup(ctx context.Context, req *pb.CreateBackupRequ
 	if err := database.DB.Preload("Node").First(&server, "id = ?", req.ServerId).Error; err != nil {
 		return nil, status.Error(codes.NotFound, "server not found")
 	}
-	body, _ := json.Marshal(map[string]string{"name": req.Name})
-	services.ProxyPostToNode(&server, "/backups", body)
+	if err := services.CheckBackupLimits(&server); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if _, err := services.CreateServerBackup(&server, req.Name, nil, false, nil); err != nil {
+		if services.IsBackupLimitError(err) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	return &pb.Empty{}, nil
 }
 
@@ -737,10 +772,35 @@ func (s *PanelServer) DeleteBackup(ctx context.Context, req *pb.DeleteBackupRequ
 	if err := database.DB.Preload("Node").First(&server, "id = ?", req.ServerId).Error; err != nil {
 		return nil, status.Error(codes.NotFound, "server not found")
 	}
-	services.ProxyDeleteToNode(&server, "/backups/"+req.BackupId)
+	backup, err := services.GetServerBackup(server.ID, req.BackupId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err := services.DeleteServerBackup(backup); err != nil {
+		if err == services.ErrBackupLocked {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	return &pb.Empty{}, nil
 }
 
+func (s *PanelServer) ListScheduleRuns(ctx context.Context, req *pb.ListScheduleRunsRequest) (*pb.ListScheduleRunsResponse, error) {
+	scheduleID, err := uuid.Parse(req.ScheduleId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid schedule id")
+	}
+	runs, err := services.GetScheduleRuns(scheduleID, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result := make([]*pb.ScheduleRun, len(runs))
+	for i, r := range runs {
+		result[i] = scheduleRunToProto(&r)
+	}
+	return &pb.ListScheduleRunsResponse{Runs: result}, nil
+}
+
 func (s *PanelServer) ListNodes(ctx context.Context, req *pb.Empty) (*pb.ListNodesResponse, error) {
 	var nodes []models.Node
 	database.DB.Find(&nodes)
@@ -1004,6 +1064,27 @@ func serverToProto(s *models.Server) *pb.Server {
 	}
 }
 
+func scheduleRunToProto(r *models.ScheduleRun) *pb.ScheduleRun {
+	run := &pb.ScheduleRun{
+		Id: r.ID.String(), ScheduleId: r.ScheduleID.String(), ServerId: r.ServerID.String(),
+		Trigger: string(r.Trigger), Status: string(r.Status), Error: r.Error,
+		StartedAt: r.StartedAt.Format(time.RFC3339),
+	}
+	if r.FinishedAt != nil {
+		run.FinishedAt = r.FinishedAt.Format(time.RFC3339)
+	}
+	var results []models.ScheduleTaskResult
+	json.Unmarshal(r.Results, &results)
+	for _, tr := range results {
+		run.Results = append(run.Results, &pb.ScheduleTaskResult{
+			Sequence: int32(tr.Sequence), Action: tr.Action, Payload: tr.Payload,
+			Success: tr.Success, Error: tr.Error, Message: tr.Message, Halted: tr.Halted, Skipped: tr.Skipped,
+			StartedAt: tr.StartedAt.Format(time.RFC3339), FinishedAt: tr.FinishedAt.Format(time.RFC3339),
+		})
+	}
+	return run
+}
+
 func userToProto(u *models.User) *pb.User {
 	user := &pb.User{
 		Id: u.ID.String(), Username: u.Username, Email: u.Email,
@@ -0,0 +1,54 @@
+package plugins
+
+import (
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// PlaceNode exposes server placement to plugins through the node.place mixin.
+// A plugin returns {"node_id": "..."} to choose one of the candidates; calling
+// next leaves the choice to the built-in strategy named in the input.
+func PlaceNode(req services.PlacementRequest, candidates []services.PlacementCandidate) (uuid.UUID, error) {
+	nodes := make([]map[string]interface{}, len(candidates))
+	for i, c := range candidates {
+		nodes[i] = map[string]interface{}{
+			"node_id":          c.Node.ID.String(),
+			"name":             c.Node.Name,
+			"servers":          c.Allocation.Servers,
+			"memory_allocated": c.Allocation.Memory.Allocated,
+			"memory_limit":     c.Allocation.Memory.Limit,
+			"disk_allocated":   c.Allocation.Disk.Allocated,
+			"disk_limit":       c.Allocation.Disk.Limit,
+			"cpu_allocated":    c.Allocation.CPU.Allocated,
+			"cpu_limit":        c.Allocation.CPU.Limit,
+			"free_ports":       c.FreePorts,
+		}
+	}
+
+	input := map[string]interface{}{
+		"location_id": req.LocationID.String(),
+		"package_id":  req.Package.ID.String(),
+		"memory":      req.Memory,
+		"cpu":         req.CPU,
+		"disk":        req.Disk,
+		"ports":       req.Ports,
+		"strategy":    req.Strategy,
+		"candidates":  nodes,
+	}
+
+	result, err := ExecuteMixin(string(MixinNodePlace), input, func(map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if output, ok := result.(map[string]interface{}); ok {
+		if id, ok := output["node_id"].(string); ok {
+			if nodeID, err := uuid.Parse(id); err == nil {
+				return nodeID, nil
+			}
+		}
+	}
+	return uuid.Nil, nil
+}
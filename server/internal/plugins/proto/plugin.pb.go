@@ -128,7 +128,7 @@ func (x AddonInstallAction_ActionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AddonInstallAction_ActionType.Descriptor instead.
 func (AddonInstallAction_ActionType) EnumDescriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{116, 0}
+	return file_plugin_proto_rawDescGZIP(), []int{123, 0}
 }
 
 type PluginMessage struct {
@@ -5018,6 +5018,234 @@ func (x *MoveFileRequest) GetTo() string {
 	return ""
 }
 
+type SearchFileContentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Pattern       string                 `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Regex         bool                   `protobuf:"varint,3,opt,name=regex,proto3" json:"regex,omitempty"`
+	CaseSensitive bool                   `protobuf:"varint,4,opt,name=case_sensitive,json=caseSensitive,proto3" json:"case_sensitive,omitempty"`
+	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Include       []string               `protobuf:"bytes,6,rep,name=include,proto3" json:"include,omitempty"`
+	MaxFileSize   int64                  `protobuf:"varint,7,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFileContentsRequest) Reset() {
+	*x = SearchFileContentsRequest{}
+	mi := &file_plugin_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFileContentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFileContentsRequest) ProtoMessage() {}
+
+func (x *SearchFileContentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFileContentsRequest.ProtoReflect.Descriptor instead.
+func (*SearchFileContentsRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SearchFileContentsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *SearchFileContentsRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *SearchFileContentsRequest) GetRegex() bool {
+	if x != nil {
+		return x.Regex
+	}
+	return false
+}
+
+func (x *SearchFileContentsRequest) GetCaseSensitive() bool {
+	if x != nil {
+		return x.CaseSensitive
+	}
+	return false
+}
+
+func (x *SearchFileContentsRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SearchFileContentsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *SearchFileContentsRequest) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *SearchFileContentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FileContentMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileContentMatch) Reset() {
+	*x = FileContentMatch{}
+	mi := &file_plugin_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileContentMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileContentMatch) ProtoMessage() {}
+
+func (x *FileContentMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileContentMatch.ProtoReflect.Descriptor instead.
+func (*FileContentMatch) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *FileContentMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileContentMatch) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *FileContentMatch) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type SearchFileContentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*FileContentMatch    `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	FilesScanned  int32                  `protobuf:"varint,2,opt,name=files_scanned,json=filesScanned,proto3" json:"files_scanned,omitempty"`
+	FilesSkipped  int32                  `protobuf:"varint,3,opt,name=files_skipped,json=filesSkipped,proto3" json:"files_skipped,omitempty"`
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFileContentsResponse) Reset() {
+	*x = SearchFileContentsResponse{}
+	mi := &file_plugin_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFileContentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFileContentsResponse) ProtoMessage() {}
+
+func (x *SearchFileContentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFileContentsResponse.ProtoReflect.Descriptor instead.
+func (*SearchFileContentsResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SearchFileContentsResponse) GetMatches() []*FileContentMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SearchFileContentsResponse) GetFilesScanned() int32 {
+	if x != nil {
+		return x.FilesScanned
+	}
+	return 0
+}
+
+func (x *SearchFileContentsResponse) GetFilesSkipped() int32 {
+	if x != nil {
+		return x.FilesSkipped
+	}
+	return 0
+}
+
+func (x *SearchFileContentsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
 // Backups
 type Backup struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -5031,7 +5259,7 @@ type Backup struct {
 
 func (x *Backup) Reset() {
 	*x = Backup{}
-	mi := &file_plugin_proto_msgTypes[74]
+	mi := &file_plugin_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5043,7 +5271,7 @@ func (x *Backup) String() string {
 func (*Backup) ProtoMessage() {}
 
 func (x *Backup) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[74]
+	mi := &file_plugin_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5056,7 +5284,7 @@ func (x *Backup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Backup.ProtoReflect.Descriptor instead.
 func (*Backup) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{74}
+	return file_plugin_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *Backup) GetId() string {
@@ -5096,7 +5324,7 @@ type ListBackupsResponse struct {
 
 func (x *ListBackupsResponse) Reset() {
 	*x = ListBackupsResponse{}
-	mi := &file_plugin_proto_msgTypes[75]
+	mi := &file_plugin_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5108,7 +5336,7 @@ func (x *ListBackupsResponse) String() string {
 func (*ListBackupsResponse) ProtoMessage() {}
 
 func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[75]
+	mi := &file_plugin_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5121,7 +5349,7 @@ func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
 func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{75}
+	return file_plugin_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *ListBackupsResponse) GetBackups() []*Backup {
@@ -5141,7 +5369,7 @@ type CreateBackupRequest struct {
 
 func (x *CreateBackupRequest) Reset() {
 	*x = CreateBackupRequest{}
-	mi := &file_plugin_proto_msgTypes[76]
+	mi := &file_plugin_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5153,7 +5381,7 @@ func (x *CreateBackupRequest) String() string {
 func (*CreateBackupRequest) ProtoMessage() {}
 
 func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[76]
+	mi := &file_plugin_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5166,46 +5394,374 @@ func (x *CreateBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBackupRequest.ProtoReflect.Descriptor instead.
 func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{76}
+	return file_plugin_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CreateBackupRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateBackupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	BackupId      string                 `protobuf:"bytes,2,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBackupRequest) Reset() {
+	*x = DeleteBackupRequest{}
+	mi := &file_plugin_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupRequest) ProtoMessage() {}
+
+func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DeleteBackupRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DeleteBackupRequest) GetBackupId() string {
+	if x != nil {
+		return x.BackupId
+	}
+	return ""
+}
+
+// Schedules
+type ScheduleTaskResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      int32                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	Halted        bool                   `protobuf:"varint,9,opt,name=halted,proto3" json:"halted,omitempty"`
+	Skipped       bool                   `protobuf:"varint,10,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleTaskResult) Reset() {
+	*x = ScheduleTaskResult{}
+	mi := &file_plugin_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleTaskResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTaskResult) ProtoMessage() {}
+
+func (x *ScheduleTaskResult) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTaskResult.ProtoReflect.Descriptor instead.
+func (*ScheduleTaskResult) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ScheduleTaskResult) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ScheduleTaskResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResult) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScheduleTaskResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResult) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResult) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResult) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+func (x *ScheduleTaskResult) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type ScheduleRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Trigger       string                 `protobuf:"bytes,4,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Results       []*ScheduleTaskResult  `protobuf:"bytes,9,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleRun) Reset() {
+	*x = ScheduleRun{}
+	mi := &file_plugin_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRun) ProtoMessage() {}
+
+func (x *ScheduleRun) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRun.ProtoReflect.Descriptor instead.
+func (*ScheduleRun) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ScheduleRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *ScheduleRun) GetResults() []*ScheduleTaskResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ListScheduleRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduleRunsRequest) Reset() {
+	*x = ListScheduleRunsRequest{}
+	mi := &file_plugin_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduleRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduleRunsRequest) ProtoMessage() {}
+
+func (x *ListScheduleRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *CreateBackupRequest) GetServerId() string {
+// Deprecated: Use ListScheduleRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduleRunsRequest) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ListScheduleRunsRequest) GetScheduleId() string {
 	if x != nil {
-		return x.ServerId
+		return x.ScheduleId
 	}
 	return ""
 }
 
-func (x *CreateBackupRequest) GetName() string {
+func (x *ListScheduleRunsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Name
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type DeleteBackupRequest struct {
+type ListScheduleRunsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	BackupId      string                 `protobuf:"bytes,2,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Runs          []*ScheduleRun         `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBackupRequest) Reset() {
-	*x = DeleteBackupRequest{}
-	mi := &file_plugin_proto_msgTypes[77]
+func (x *ListScheduleRunsResponse) Reset() {
+	*x = ListScheduleRunsResponse{}
+	mi := &file_plugin_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBackupRequest) String() string {
+func (x *ListScheduleRunsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBackupRequest) ProtoMessage() {}
+func (*ListScheduleRunsResponse) ProtoMessage() {}
 
-func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[77]
+func (x *ListScheduleRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_plugin_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5216,23 +5772,16 @@ func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{77}
-}
-
-func (x *DeleteBackupRequest) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
+// Deprecated: Use ListScheduleRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduleRunsResponse) Descriptor() ([]byte, []int) {
+	return file_plugin_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *DeleteBackupRequest) GetBackupId() string {
+func (x *ListScheduleRunsResponse) GetRuns() []*ScheduleRun {
 	if x != nil {
-		return x.BackupId
+		return x.Runs
 	}
-	return ""
+	return nil
 }
 
 // Nodes
@@ -5250,7 +5799,7 @@ type Node struct {
 
 func (x *Node) Reset() {
 	*x = Node{}
-	mi := &file_plugin_proto_msgTypes[78]
+	mi := &file_plugin_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5262,7 +5811,7 @@ func (x *Node) String() string {
 func (*Node) ProtoMessage() {}
 
 func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[78]
+	mi := &file_plugin_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5275,7 +5824,7 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Node.ProtoReflect.Descriptor instead.
 func (*Node) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{78}
+	return file_plugin_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *Node) GetId() string {
@@ -5329,7 +5878,7 @@ type ListNodesResponse struct {
 
 func (x *ListNodesResponse) Reset() {
 	*x = ListNodesResponse{}
-	mi := &file_plugin_proto_msgTypes[79]
+	mi := &file_plugin_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5341,7 +5890,7 @@ func (x *ListNodesResponse) String() string {
 func (*ListNodesResponse) ProtoMessage() {}
 
 func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[79]
+	mi := &file_plugin_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5354,7 +5903,7 @@ func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
 func (*ListNodesResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{79}
+	return file_plugin_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *ListNodesResponse) GetNodes() []*Node {
@@ -5375,7 +5924,7 @@ type CreateNodeRequest struct {
 
 func (x *CreateNodeRequest) Reset() {
 	*x = CreateNodeRequest{}
-	mi := &file_plugin_proto_msgTypes[80]
+	mi := &file_plugin_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5387,7 +5936,7 @@ func (x *CreateNodeRequest) String() string {
 func (*CreateNodeRequest) ProtoMessage() {}
 
 func (x *CreateNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[80]
+	mi := &file_plugin_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5400,7 +5949,7 @@ func (x *CreateNodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNodeRequest.ProtoReflect.Descriptor instead.
 func (*CreateNodeRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{80}
+	return file_plugin_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *CreateNodeRequest) GetName() string {
@@ -5434,7 +5983,7 @@ type NodeWithToken struct {
 
 func (x *NodeWithToken) Reset() {
 	*x = NodeWithToken{}
-	mi := &file_plugin_proto_msgTypes[81]
+	mi := &file_plugin_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5446,7 +5995,7 @@ func (x *NodeWithToken) String() string {
 func (*NodeWithToken) ProtoMessage() {}
 
 func (x *NodeWithToken) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[81]
+	mi := &file_plugin_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5459,7 +6008,7 @@ func (x *NodeWithToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeWithToken.ProtoReflect.Descriptor instead.
 func (*NodeWithToken) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{81}
+	return file_plugin_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *NodeWithToken) GetNode() *Node {
@@ -5486,7 +6035,7 @@ type NodeToken struct {
 
 func (x *NodeToken) Reset() {
 	*x = NodeToken{}
-	mi := &file_plugin_proto_msgTypes[82]
+	mi := &file_plugin_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5498,7 +6047,7 @@ func (x *NodeToken) String() string {
 func (*NodeToken) ProtoMessage() {}
 
 func (x *NodeToken) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[82]
+	mi := &file_plugin_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5511,7 +6060,7 @@ func (x *NodeToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeToken.ProtoReflect.Descriptor instead.
 func (*NodeToken) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{82}
+	return file_plugin_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *NodeToken) GetTokenId() string {
@@ -5548,7 +6097,7 @@ type Package struct {
 
 func (x *Package) Reset() {
 	*x = Package{}
-	mi := &file_plugin_proto_msgTypes[83]
+	mi := &file_plugin_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5560,7 +6109,7 @@ func (x *Package) String() string {
 func (*Package) ProtoMessage() {}
 
 func (x *Package) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[83]
+	mi := &file_plugin_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5573,7 +6122,7 @@ func (x *Package) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Package.ProtoReflect.Descriptor instead.
 func (*Package) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{83}
+	return file_plugin_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *Package) GetId() string {
@@ -5662,7 +6211,7 @@ type ListPackagesResponse struct {
 
 func (x *ListPackagesResponse) Reset() {
 	*x = ListPackagesResponse{}
-	mi := &file_plugin_proto_msgTypes[84]
+	mi := &file_plugin_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5674,7 +6223,7 @@ func (x *ListPackagesResponse) String() string {
 func (*ListPackagesResponse) ProtoMessage() {}
 
 func (x *ListPackagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[84]
+	mi := &file_plugin_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5687,7 +6236,7 @@ func (x *ListPackagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPackagesResponse.ProtoReflect.Descriptor instead.
 func (*ListPackagesResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{84}
+	return file_plugin_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *ListPackagesResponse) GetPackages() []*Package {
@@ -5715,7 +6264,7 @@ type CreatePackageRequest struct {
 
 func (x *CreatePackageRequest) Reset() {
 	*x = CreatePackageRequest{}
-	mi := &file_plugin_proto_msgTypes[85]
+	mi := &file_plugin_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5727,7 +6276,7 @@ func (x *CreatePackageRequest) String() string {
 func (*CreatePackageRequest) ProtoMessage() {}
 
 func (x *CreatePackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[85]
+	mi := &file_plugin_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5740,7 +6289,7 @@ func (x *CreatePackageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePackageRequest.ProtoReflect.Descriptor instead.
 func (*CreatePackageRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{85}
+	return file_plugin_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *CreatePackageRequest) GetName() string {
@@ -5832,7 +6381,7 @@ type UpdatePackageRequest struct {
 
 func (x *UpdatePackageRequest) Reset() {
 	*x = UpdatePackageRequest{}
-	mi := &file_plugin_proto_msgTypes[86]
+	mi := &file_plugin_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5844,7 +6393,7 @@ func (x *UpdatePackageRequest) String() string {
 func (*UpdatePackageRequest) ProtoMessage() {}
 
 func (x *UpdatePackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[86]
+	mi := &file_plugin_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5857,7 +6406,7 @@ func (x *UpdatePackageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePackageRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePackageRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{86}
+	return file_plugin_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *UpdatePackageRequest) GetId() string {
@@ -5950,7 +6499,7 @@ type IPBan struct {
 
 func (x *IPBan) Reset() {
 	*x = IPBan{}
-	mi := &file_plugin_proto_msgTypes[87]
+	mi := &file_plugin_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5962,7 +6511,7 @@ func (x *IPBan) String() string {
 func (*IPBan) ProtoMessage() {}
 
 func (x *IPBan) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[87]
+	mi := &file_plugin_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5975,7 +6524,7 @@ func (x *IPBan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IPBan.ProtoReflect.Descriptor instead.
 func (*IPBan) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{87}
+	return file_plugin_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *IPBan) GetId() string {
@@ -6015,7 +6564,7 @@ type ListIPBansResponse struct {
 
 func (x *ListIPBansResponse) Reset() {
 	*x = ListIPBansResponse{}
-	mi := &file_plugin_proto_msgTypes[88]
+	mi := &file_plugin_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6027,7 +6576,7 @@ func (x *ListIPBansResponse) String() string {
 func (*ListIPBansResponse) ProtoMessage() {}
 
 func (x *ListIPBansResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[88]
+	mi := &file_plugin_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6040,7 +6589,7 @@ func (x *ListIPBansResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListIPBansResponse.ProtoReflect.Descriptor instead.
 func (*ListIPBansResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{88}
+	return file_plugin_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *ListIPBansResponse) GetBans() []*IPBan {
@@ -6060,7 +6609,7 @@ type CreateIPBanRequest struct {
 
 func (x *CreateIPBanRequest) Reset() {
 	*x = CreateIPBanRequest{}
-	mi := &file_plugin_proto_msgTypes[89]
+	mi := &file_plugin_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6072,7 +6621,7 @@ func (x *CreateIPBanRequest) String() string {
 func (*CreateIPBanRequest) ProtoMessage() {}
 
 func (x *CreateIPBanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[89]
+	mi := &file_plugin_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6085,7 +6634,7 @@ func (x *CreateIPBanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateIPBanRequest.ProtoReflect.Descriptor instead.
 func (*CreateIPBanRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{89}
+	return file_plugin_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *CreateIPBanRequest) GetIp() string {
@@ -6123,7 +6672,7 @@ type Mount struct {
 
 func (x *Mount) Reset() {
 	*x = Mount{}
-	mi := &file_plugin_proto_msgTypes[90]
+	mi := &file_plugin_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6135,7 +6684,7 @@ func (x *Mount) String() string {
 func (*Mount) ProtoMessage() {}
 
 func (x *Mount) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[90]
+	mi := &file_plugin_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6148,7 +6697,7 @@ func (x *Mount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Mount.ProtoReflect.Descriptor instead.
 func (*Mount) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{90}
+	return file_plugin_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *Mount) GetId() string {
@@ -6244,7 +6793,7 @@ type ListMountsResponse struct {
 
 func (x *ListMountsResponse) Reset() {
 	*x = ListMountsResponse{}
-	mi := &file_plugin_proto_msgTypes[91]
+	mi := &file_plugin_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6256,7 +6805,7 @@ func (x *ListMountsResponse) String() string {
 func (*ListMountsResponse) ProtoMessage() {}
 
 func (x *ListMountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[91]
+	mi := &file_plugin_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6269,7 +6818,7 @@ func (x *ListMountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMountsResponse.ProtoReflect.Descriptor instead.
 func (*ListMountsResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{91}
+	return file_plugin_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *ListMountsResponse) GetMounts() []*Mount {
@@ -6297,7 +6846,7 @@ type CreateMountRequest struct {
 
 func (x *CreateMountRequest) Reset() {
 	*x = CreateMountRequest{}
-	mi := &file_plugin_proto_msgTypes[92]
+	mi := &file_plugin_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6309,7 +6858,7 @@ func (x *CreateMountRequest) String() string {
 func (*CreateMountRequest) ProtoMessage() {}
 
 func (x *CreateMountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[92]
+	mi := &file_plugin_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6322,7 +6871,7 @@ func (x *CreateMountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateMountRequest.ProtoReflect.Descriptor instead.
 func (*CreateMountRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{92}
+	return file_plugin_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *CreateMountRequest) GetName() string {
@@ -6414,7 +6963,7 @@ type UpdateMountRequest struct {
 
 func (x *UpdateMountRequest) Reset() {
 	*x = UpdateMountRequest{}
-	mi := &file_plugin_proto_msgTypes[93]
+	mi := &file_plugin_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6426,7 +6975,7 @@ func (x *UpdateMountRequest) String() string {
 func (*UpdateMountRequest) ProtoMessage() {}
 
 func (x *UpdateMountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[93]
+	mi := &file_plugin_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6439,7 +6988,7 @@ func (x *UpdateMountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMountRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMountRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{93}
+	return file_plugin_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *UpdateMountRequest) GetId() string {
@@ -6529,7 +7078,7 @@ type MountServerRequest struct {
 
 func (x *MountServerRequest) Reset() {
 	*x = MountServerRequest{}
-	mi := &file_plugin_proto_msgTypes[94]
+	mi := &file_plugin_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6541,7 +7090,7 @@ func (x *MountServerRequest) String() string {
 func (*MountServerRequest) ProtoMessage() {}
 
 func (x *MountServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[94]
+	mi := &file_plugin_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6554,7 +7103,7 @@ func (x *MountServerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MountServerRequest.ProtoReflect.Descriptor instead.
 func (*MountServerRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{94}
+	return file_plugin_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *MountServerRequest) GetMountId() string {
@@ -6587,7 +7136,7 @@ type ServerMountInfo struct {
 
 func (x *ServerMountInfo) Reset() {
 	*x = ServerMountInfo{}
-	mi := &file_plugin_proto_msgTypes[95]
+	mi := &file_plugin_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6599,7 +7148,7 @@ func (x *ServerMountInfo) String() string {
 func (*ServerMountInfo) ProtoMessage() {}
 
 func (x *ServerMountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[95]
+	mi := &file_plugin_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6612,7 +7161,7 @@ func (x *ServerMountInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerMountInfo.ProtoReflect.Descriptor instead.
 func (*ServerMountInfo) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{95}
+	return file_plugin_proto_rawDescGZIP(), []int{102}
 }
 
 func (x *ServerMountInfo) GetId() string {
@@ -6680,7 +7229,7 @@ type ServerMountsResponse struct {
 
 func (x *ServerMountsResponse) Reset() {
 	*x = ServerMountsResponse{}
-	mi := &file_plugin_proto_msgTypes[96]
+	mi := &file_plugin_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6692,7 +7241,7 @@ func (x *ServerMountsResponse) String() string {
 func (*ServerMountsResponse) ProtoMessage() {}
 
 func (x *ServerMountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[96]
+	mi := &file_plugin_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6705,7 +7254,7 @@ func (x *ServerMountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerMountsResponse.ProtoReflect.Descriptor instead.
 func (*ServerMountsResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{96}
+	return file_plugin_proto_rawDescGZIP(), []int{103}
 }
 
 func (x *ServerMountsResponse) GetMounts() []*ServerMountInfo {
@@ -6726,7 +7275,7 @@ type Settings struct {
 
 func (x *Settings) Reset() {
 	*x = Settings{}
-	mi := &file_plugin_proto_msgTypes[97]
+	mi := &file_plugin_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6738,7 +7287,7 @@ func (x *Settings) String() string {
 func (*Settings) ProtoMessage() {}
 
 func (x *Settings) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[97]
+	mi := &file_plugin_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6751,7 +7300,7 @@ func (x *Settings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Settings.ProtoReflect.Descriptor instead.
 func (*Settings) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{97}
+	return file_plugin_proto_rawDescGZIP(), []int{104}
 }
 
 func (x *Settings) GetRegistrationEnabled() bool {
@@ -6785,7 +7334,7 @@ type ActivityLog struct {
 
 func (x *ActivityLog) Reset() {
 	*x = ActivityLog{}
-	mi := &file_plugin_proto_msgTypes[98]
+	mi := &file_plugin_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6797,7 +7346,7 @@ func (x *ActivityLog) String() string {
 func (*ActivityLog) ProtoMessage() {}
 
 func (x *ActivityLog) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[98]
+	mi := &file_plugin_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6810,7 +7359,7 @@ func (x *ActivityLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivityLog.ProtoReflect.Descriptor instead.
 func (*ActivityLog) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{98}
+	return file_plugin_proto_rawDescGZIP(), []int{105}
 }
 
 func (x *ActivityLog) GetId() string {
@@ -6881,7 +7430,7 @@ type GetLogsRequest struct {
 
 func (x *GetLogsRequest) Reset() {
 	*x = GetLogsRequest{}
-	mi := &file_plugin_proto_msgTypes[99]
+	mi := &file_plugin_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6893,7 +7442,7 @@ func (x *GetLogsRequest) String() string {
 func (*GetLogsRequest) ProtoMessage() {}
 
 func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[99]
+	mi := &file_plugin_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6906,7 +7455,7 @@ func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetLogsRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{99}
+	return file_plugin_proto_rawDescGZIP(), []int{106}
 }
 
 func (x *GetLogsRequest) GetLimit() int32 {
@@ -6947,7 +7496,7 @@ type GetLogsResponse struct {
 
 func (x *GetLogsResponse) Reset() {
 	*x = GetLogsResponse{}
-	mi := &file_plugin_proto_msgTypes[100]
+	mi := &file_plugin_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6959,7 +7508,7 @@ func (x *GetLogsResponse) String() string {
 func (*GetLogsResponse) ProtoMessage() {}
 
 func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[100]
+	mi := &file_plugin_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6972,7 +7521,7 @@ func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetLogsResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{100}
+	return file_plugin_proto_rawDescGZIP(), []int{107}
 }
 
 func (x *GetLogsResponse) GetLogs() []*ActivityLog {
@@ -7000,7 +7549,7 @@ type LogRequest struct {
 
 func (x *LogRequest) Reset() {
 	*x = LogRequest{}
-	mi := &file_plugin_proto_msgTypes[101]
+	mi := &file_plugin_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7012,7 +7561,7 @@ func (x *LogRequest) String() string {
 func (*LogRequest) ProtoMessage() {}
 
 func (x *LogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[101]
+	mi := &file_plugin_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7025,7 +7574,7 @@ func (x *LogRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
 func (*LogRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{101}
+	return file_plugin_proto_rawDescGZIP(), []int{108}
 }
 
 func (x *LogRequest) GetLevel() string {
@@ -7051,7 +7600,7 @@ type KVRequest struct {
 
 func (x *KVRequest) Reset() {
 	*x = KVRequest{}
-	mi := &file_plugin_proto_msgTypes[102]
+	mi := &file_plugin_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7063,7 +7612,7 @@ func (x *KVRequest) String() string {
 func (*KVRequest) ProtoMessage() {}
 
 func (x *KVRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[102]
+	mi := &file_plugin_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7076,7 +7625,7 @@ func (x *KVRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVRequest.ProtoReflect.Descriptor instead.
 func (*KVRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{102}
+	return file_plugin_proto_rawDescGZIP(), []int{109}
 }
 
 func (x *KVRequest) GetKey() string {
@@ -7096,7 +7645,7 @@ type KVResponse struct {
 
 func (x *KVResponse) Reset() {
 	*x = KVResponse{}
-	mi := &file_plugin_proto_msgTypes[103]
+	mi := &file_plugin_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7108,7 +7657,7 @@ func (x *KVResponse) String() string {
 func (*KVResponse) ProtoMessage() {}
 
 func (x *KVResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[103]
+	mi := &file_plugin_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7121,7 +7670,7 @@ func (x *KVResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVResponse.ProtoReflect.Descriptor instead.
 func (*KVResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{103}
+	return file_plugin_proto_rawDescGZIP(), []int{110}
 }
 
 func (x *KVResponse) GetValue() string {
@@ -7148,7 +7697,7 @@ type KVSetRequest struct {
 
 func (x *KVSetRequest) Reset() {
 	*x = KVSetRequest{}
-	mi := &file_plugin_proto_msgTypes[104]
+	mi := &file_plugin_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7160,7 +7709,7 @@ func (x *KVSetRequest) String() string {
 func (*KVSetRequest) ProtoMessage() {}
 
 func (x *KVSetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[104]
+	mi := &file_plugin_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7173,7 +7722,7 @@ func (x *KVSetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KVSetRequest.ProtoReflect.Descriptor instead.
 func (*KVSetRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{104}
+	return file_plugin_proto_rawDescGZIP(), []int{111}
 }
 
 func (x *KVSetRequest) GetKey() string {
@@ -7200,7 +7749,7 @@ type QueryDBRequest struct {
 
 func (x *QueryDBRequest) Reset() {
 	*x = QueryDBRequest{}
-	mi := &file_plugin_proto_msgTypes[105]
+	mi := &file_plugin_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7212,7 +7761,7 @@ func (x *QueryDBRequest) String() string {
 func (*QueryDBRequest) ProtoMessage() {}
 
 func (x *QueryDBRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[105]
+	mi := &file_plugin_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7225,7 +7774,7 @@ func (x *QueryDBRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDBRequest.ProtoReflect.Descriptor instead.
 func (*QueryDBRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{105}
+	return file_plugin_proto_rawDescGZIP(), []int{112}
 }
 
 func (x *QueryDBRequest) GetQuery() string {
@@ -7251,7 +7800,7 @@ type QueryDBResponse struct {
 
 func (x *QueryDBResponse) Reset() {
 	*x = QueryDBResponse{}
-	mi := &file_plugin_proto_msgTypes[106]
+	mi := &file_plugin_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7263,7 +7812,7 @@ func (x *QueryDBResponse) String() string {
 func (*QueryDBResponse) ProtoMessage() {}
 
 func (x *QueryDBResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[106]
+	mi := &file_plugin_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7276,7 +7825,7 @@ func (x *QueryDBResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDBResponse.ProtoReflect.Descriptor instead.
 func (*QueryDBResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{106}
+	return file_plugin_proto_rawDescGZIP(), []int{113}
 }
 
 func (x *QueryDBResponse) GetRows() [][]byte {
@@ -7296,7 +7845,7 @@ type BroadcastEventRequest struct {
 
 func (x *BroadcastEventRequest) Reset() {
 	*x = BroadcastEventRequest{}
-	mi := &file_plugin_proto_msgTypes[107]
+	mi := &file_plugin_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7308,7 +7857,7 @@ func (x *BroadcastEventRequest) String() string {
 func (*BroadcastEventRequest) ProtoMessage() {}
 
 func (x *BroadcastEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[107]
+	mi := &file_plugin_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7321,7 +7870,7 @@ func (x *BroadcastEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BroadcastEventRequest.ProtoReflect.Descriptor instead.
 func (*BroadcastEventRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{107}
+	return file_plugin_proto_rawDescGZIP(), []int{114}
 }
 
 func (x *BroadcastEventRequest) GetEventType() string {
@@ -7350,7 +7899,7 @@ type NotificationRequest struct {
 
 func (x *NotificationRequest) Reset() {
 	*x = NotificationRequest{}
-	mi := &file_plugin_proto_msgTypes[108]
+	mi := &file_plugin_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7362,7 +7911,7 @@ func (x *NotificationRequest) String() string {
 func (*NotificationRequest) ProtoMessage() {}
 
 func (x *NotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[108]
+	mi := &file_plugin_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7375,7 +7924,7 @@ func (x *NotificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationRequest.ProtoReflect.Descriptor instead.
 func (*NotificationRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{108}
+	return file_plugin_proto_rawDescGZIP(), []int{115}
 }
 
 func (x *NotificationRequest) GetUserId() string {
@@ -7420,7 +7969,7 @@ type PluginHTTPRequest struct {
 
 func (x *PluginHTTPRequest) Reset() {
 	*x = PluginHTTPRequest{}
-	mi := &file_plugin_proto_msgTypes[109]
+	mi := &file_plugin_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7432,7 +7981,7 @@ func (x *PluginHTTPRequest) String() string {
 func (*PluginHTTPRequest) ProtoMessage() {}
 
 func (x *PluginHTTPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[109]
+	mi := &file_plugin_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7445,7 +7994,7 @@ func (x *PluginHTTPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginHTTPRequest.ProtoReflect.Descriptor instead.
 func (*PluginHTTPRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{109}
+	return file_plugin_proto_rawDescGZIP(), []int{116}
 }
 
 func (x *PluginHTTPRequest) GetMethod() string {
@@ -7495,7 +8044,7 @@ type PluginHTTPResponse struct {
 
 func (x *PluginHTTPResponse) Reset() {
 	*x = PluginHTTPResponse{}
-	mi := &file_plugin_proto_msgTypes[110]
+	mi := &file_plugin_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7507,7 +8056,7 @@ func (x *PluginHTTPResponse) String() string {
 func (*PluginHTTPResponse) ProtoMessage() {}
 
 func (x *PluginHTTPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[110]
+	mi := &file_plugin_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7520,7 +8069,7 @@ func (x *PluginHTTPResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PluginHTTPResponse.ProtoReflect.Descriptor instead.
 func (*PluginHTTPResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{110}
+	return file_plugin_proto_rawDescGZIP(), []int{117}
 }
 
 func (x *PluginHTTPResponse) GetStatus() int32 {
@@ -7563,7 +8112,7 @@ type CallPluginRequest struct {
 
 func (x *CallPluginRequest) Reset() {
 	*x = CallPluginRequest{}
-	mi := &file_plugin_proto_msgTypes[111]
+	mi := &file_plugin_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7575,7 +8124,7 @@ func (x *CallPluginRequest) String() string {
 func (*CallPluginRequest) ProtoMessage() {}
 
 func (x *CallPluginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[111]
+	mi := &file_plugin_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7588,7 +8137,7 @@ func (x *CallPluginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallPluginRequest.ProtoReflect.Descriptor instead.
 func (*CallPluginRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{111}
+	return file_plugin_proto_rawDescGZIP(), []int{118}
 }
 
 func (x *CallPluginRequest) GetPluginId() string {
@@ -7622,7 +8171,7 @@ type CallPluginResponse struct {
 
 func (x *CallPluginResponse) Reset() {
 	*x = CallPluginResponse{}
-	mi := &file_plugin_proto_msgTypes[112]
+	mi := &file_plugin_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7634,7 +8183,7 @@ func (x *CallPluginResponse) String() string {
 func (*CallPluginResponse) ProtoMessage() {}
 
 func (x *CallPluginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[112]
+	mi := &file_plugin_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7647,7 +8196,7 @@ func (x *CallPluginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallPluginResponse.ProtoReflect.Descriptor instead.
 func (*CallPluginResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{112}
+	return file_plugin_proto_rawDescGZIP(), []int{119}
 }
 
 func (x *CallPluginResponse) GetData() []byte {
@@ -7675,7 +8224,7 @@ type AddonTypeInfo struct {
 
 func (x *AddonTypeInfo) Reset() {
 	*x = AddonTypeInfo{}
-	mi := &file_plugin_proto_msgTypes[113]
+	mi := &file_plugin_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7687,7 +8236,7 @@ func (x *AddonTypeInfo) String() string {
 func (*AddonTypeInfo) ProtoMessage() {}
 
 func (x *AddonTypeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[113]
+	mi := &file_plugin_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7700,7 +8249,7 @@ func (x *AddonTypeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddonTypeInfo.ProtoReflect.Descriptor instead.
 func (*AddonTypeInfo) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{113}
+	return file_plugin_proto_rawDescGZIP(), []int{120}
 }
 
 func (x *AddonTypeInfo) GetTypeId() string {
@@ -7740,7 +8289,7 @@ type AddonTypeRequest struct {
 
 func (x *AddonTypeRequest) Reset() {
 	*x = AddonTypeRequest{}
-	mi := &file_plugin_proto_msgTypes[114]
+	mi := &file_plugin_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7752,7 +8301,7 @@ func (x *AddonTypeRequest) String() string {
 func (*AddonTypeRequest) ProtoMessage() {}
 
 func (x *AddonTypeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[114]
+	mi := &file_plugin_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7765,7 +8314,7 @@ func (x *AddonTypeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddonTypeRequest.ProtoReflect.Descriptor instead.
 func (*AddonTypeRequest) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{114}
+	return file_plugin_proto_rawDescGZIP(), []int{121}
 }
 
 func (x *AddonTypeRequest) GetTypeId() string {
@@ -7836,7 +8385,7 @@ type AddonTypeResponse struct {
 
 func (x *AddonTypeResponse) Reset() {
 	*x = AddonTypeResponse{}
-	mi := &file_plugin_proto_msgTypes[115]
+	mi := &file_plugin_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7848,7 +8397,7 @@ func (x *AddonTypeResponse) String() string {
 func (*AddonTypeResponse) ProtoMessage() {}
 
 func (x *AddonTypeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[115]
+	mi := &file_plugin_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7861,7 +8410,7 @@ func (x *AddonTypeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddonTypeResponse.ProtoReflect.Descriptor instead.
 func (*AddonTypeResponse) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{115}
+	return file_plugin_proto_rawDescGZIP(), []int{122}
 }
 
 func (x *AddonTypeResponse) GetSuccess() bool {
@@ -7908,7 +8457,7 @@ type AddonInstallAction struct {
 
 func (x *AddonInstallAction) Reset() {
 	*x = AddonInstallAction{}
-	mi := &file_plugin_proto_msgTypes[116]
+	mi := &file_plugin_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7920,7 +8469,7 @@ func (x *AddonInstallAction) String() string {
 func (*AddonInstallAction) ProtoMessage() {}
 
 func (x *AddonInstallAction) ProtoReflect() protoreflect.Message {
-	mi := &file_plugin_proto_msgTypes[116]
+	mi := &file_plugin_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7933,7 +8482,7 @@ func (x *AddonInstallAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddonInstallAction.ProtoReflect.Descriptor instead.
 func (*AddonInstallAction) Descriptor() ([]byte, []int) {
-	return file_plugin_proto_rawDescGZIP(), []int{116}
+	return file_plugin_proto_rawDescGZIP(), []int{123}
 }
 
 func (x *AddonInstallAction) GetType() AddonInstallAction_ActionType {
@@ -8385,7 +8934,25 @@ const file_plugin_proto_rawDesc = "" +
 	"\x0fMoveFileRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x12\n" +
 	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
-	"\x02to\x18\x03 \x01(\tR\x02to\"_\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"\xf7\x01\n" +
+	"\x19SearchFileContentsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x18\n" +
+	"\apattern\x18\x02 \x01(\tR\apattern\x12\x14\n" +
+	"\x05regex\x18\x03 \x01(\bR\x05regex\x12%\n" +
+	"\x0ecase_sensitive\x18\x04 \x01(\bR\rcaseSensitive\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x18\n" +
+	"\ainclude\x18\x06 \x03(\tR\ainclude\x12\"\n" +
+	"\rmax_file_size\x18\a \x01(\x03R\vmaxFileSize\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\"N\n" +
+	"\x10FileContentMatch\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"\xb9\x01\n" +
+	"\x1aSearchFileContentsResponse\x123\n" +
+	"\amatches\x18\x01 \x03(\v2\x19.plugins.FileContentMatchR\amatches\x12#\n" +
+	"\rfiles_scanned\x18\x02 \x01(\x05R\ffilesScanned\x12#\n" +
+	"\rfiles_skipped\x18\x03 \x01(\x05R\ffilesSkipped\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\"_\n" +
 	"\x06Backup\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -8399,7 +8966,40 @@ const file_plugin_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"O\n" +
 	"\x13DeleteBackupRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
-	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\"\x96\x01\n" +
+	"\tbackup_id\x18\x02 \x01(\tR\bbackupId\"\x9e\x02\n" +
+	"\x12ScheduleTaskResult\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x05R\bsequence\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\a \x01(\tR\n" +
+	"finishedAt\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12\x16\n" +
+	"\x06halted\x18\t \x01(\bR\x06halted\x12\x18\n" +
+	"\askipped\x18\n" +
+	" \x01(\bR\askipped\"\x9a\x02\n" +
+	"\vScheduleRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vschedule_id\x18\x02 \x01(\tR\n" +
+	"scheduleId\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x18\n" +
+	"\atrigger\x18\x04 \x01(\tR\atrigger\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\tR\n" +
+	"finishedAt\x125\n" +
+	"\aresults\x18\t \x03(\v2\x1b.plugins.ScheduleTaskResultR\aresults\"P\n" +
+	"\x17ListScheduleRunsRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"D\n" +
+	"\x18ListScheduleRunsResponse\x12(\n" +
+	"\x04runs\x18\x01 \x03(\v2\x14.plugins.ScheduleRunR\x04runs\"\x96\x01\n" +
 	"\x04Node\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -8666,7 +9266,7 @@ const file_plugin_proto_rawDesc = "" +
 	"OnSchedule\x12\x18.plugins.ScheduleRequest\x1a\x0e.plugins.Empty\x128\n" +
 	"\aOnMixin\x12\x15.plugins.MixinRequest\x1a\x16.plugins.MixinResponse\x12*\n" +
 	"\bShutdown\x12\x0e.plugins.Empty\x1a\x0e.plugins.Empty\x126\n" +
-	"\tSendEmail\x12\x19.plugins.SendEmailRequest\x1a\x0e.plugins.Empty2\x8d1\n" +
+	"\tSendEmail\x12\x19.plugins.SendEmailRequest\x1a\x0e.plugins.Empty2\xc52\n" +
 	"\fPanelService\x12<\n" +
 	"\aConnect\x12\x16.plugins.PluginMessage\x1a\x15.plugins.PanelMessage(\x010\x01\x120\n" +
 	"\tGetServer\x12\x12.plugins.IDRequest\x1a\x0f.plugins.Server\x12H\n" +
@@ -8740,10 +9340,12 @@ const file_plugin_proto_rawDesc = "" +
 	"\bMoveFile\x12\x18.plugins.MoveFileRequest\x1a\x0e.plugins.Empty\x124\n" +
 	"\bCopyFile\x12\x18.plugins.MoveFileRequest\x1a\x0e.plugins.Empty\x129\n" +
 	"\rCompressFiles\x12\x18.plugins.CompressRequest\x1a\x0e.plugins.Empty\x12:\n" +
-	"\x0eDecompressFile\x12\x18.plugins.FilePathRequest\x1a\x0e.plugins.Empty\x12?\n" +
+	"\x0eDecompressFile\x12\x18.plugins.FilePathRequest\x1a\x0e.plugins.Empty\x12]\n" +
+	"\x12SearchFileContents\x12\".plugins.SearchFileContentsRequest\x1a#.plugins.SearchFileContentsResponse\x12?\n" +
 	"\vListBackups\x12\x12.plugins.IDRequest\x1a\x1c.plugins.ListBackupsResponse\x12<\n" +
 	"\fCreateBackup\x12\x1c.plugins.CreateBackupRequest\x1a\x0e.plugins.Empty\x12<\n" +
-	"\fDeleteBackup\x12\x1c.plugins.DeleteBackupRequest\x1a\x0e.plugins.Empty\x127\n" +
+	"\fDeleteBackup\x12\x1c.plugins.DeleteBackupRequest\x1a\x0e.plugins.Empty\x12W\n" +
+	"\x10ListScheduleRuns\x12 .plugins.ListScheduleRunsRequest\x1a!.plugins.ListScheduleRunsResponse\x127\n" +
 	"\tListNodes\x12\x0e.plugins.Empty\x1a\x1a.plugins.ListNodesResponse\x12,\n" +
 	"\aGetNode\x12\x12.plugins.IDRequest\x1a\r.plugins.Node\x12@\n" +
 	"\n" +
@@ -8802,7 +9404,7 @@ func file_plugin_proto_rawDescGZIP() []byte {
 }
 
 var file_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 128)
+var file_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 135)
 var file_plugin_proto_goTypes = []any{
 	(MixinResponse_Action)(0),          // 0: plugins.MixinResponse.Action
 	(AddonInstallAction_ActionType)(0), // 1: plugins.AddonInstallAction.ActionType
@@ -8880,60 +9482,67 @@ var file_plugin_proto_goTypes = []any{
 	(*FileContent)(nil),                // 73: plugins.FileContent
 	(*WriteFileRequest)(nil),           // 74: plugins.WriteFileRequest
 	(*MoveFileRequest)(nil),            // 75: plugins.MoveFileRequest
-	(*Backup)(nil),                     // 76: plugins.Backup
-	(*ListBackupsResponse)(nil),        // 77: plugins.ListBackupsResponse
-	(*CreateBackupRequest)(nil),        // 78: plugins.CreateBackupRequest
-	(*DeleteBackupRequest)(nil),        // 79: plugins.DeleteBackupRequest
-	(*Node)(nil),                       // 80: plugins.Node
-	(*ListNodesResponse)(nil),          // 81: plugins.ListNodesResponse
-	(*CreateNodeRequest)(nil),          // 82: plugins.CreateNodeRequest
-	(*NodeWithToken)(nil),              // 83: plugins.NodeWithToken
-	(*NodeToken)(nil),                  // 84: plugins.NodeToken
-	(*Package)(nil),                    // 85: plugins.Package
-	(*ListPackagesResponse)(nil),       // 86: plugins.ListPackagesResponse
-	(*CreatePackageRequest)(nil),       // 87: plugins.CreatePackageRequest
-	(*UpdatePackageRequest)(nil),       // 88: plugins.UpdatePackageRequest
-	(*IPBan)(nil),                      // 89: plugins.IPBan
-	(*ListIPBansResponse)(nil),         // 90: plugins.ListIPBansResponse
-	(*CreateIPBanRequest)(nil),         // 91: plugins.CreateIPBanRequest
-	(*Mount)(nil),                      // 92: plugins.Mount
-	(*ListMountsResponse)(nil),         // 93: plugins.ListMountsResponse
-	(*CreateMountRequest)(nil),         // 94: plugins.CreateMountRequest
-	(*UpdateMountRequest)(nil),         // 95: plugins.UpdateMountRequest
-	(*MountServerRequest)(nil),         // 96: plugins.MountServerRequest
-	(*ServerMountInfo)(nil),            // 97: plugins.ServerMountInfo
-	(*ServerMountsResponse)(nil),       // 98: plugins.ServerMountsResponse
-	(*Settings)(nil),                   // 99: plugins.Settings
-	(*ActivityLog)(nil),                // 100: plugins.ActivityLog
-	(*GetLogsRequest)(nil),             // 101: plugins.GetLogsRequest
-	(*GetLogsResponse)(nil),            // 102: plugins.GetLogsResponse
-	(*LogRequest)(nil),                 // 103: plugins.LogRequest
-	(*KVRequest)(nil),                  // 104: plugins.KVRequest
-	(*KVResponse)(nil),                 // 105: plugins.KVResponse
-	(*KVSetRequest)(nil),               // 106: plugins.KVSetRequest
-	(*QueryDBRequest)(nil),             // 107: plugins.QueryDBRequest
-	(*QueryDBResponse)(nil),            // 108: plugins.QueryDBResponse
-	(*BroadcastEventRequest)(nil),      // 109: plugins.BroadcastEventRequest
-	(*NotificationRequest)(nil),        // 110: plugins.NotificationRequest
-	(*PluginHTTPRequest)(nil),          // 111: plugins.PluginHTTPRequest
-	(*PluginHTTPResponse)(nil),         // 112: plugins.PluginHTTPResponse
-	(*CallPluginRequest)(nil),          // 113: plugins.CallPluginRequest
-	(*CallPluginResponse)(nil),         // 114: plugins.CallPluginResponse
-	(*AddonTypeInfo)(nil),              // 115: plugins.AddonTypeInfo
-	(*AddonTypeRequest)(nil),           // 116: plugins.AddonTypeRequest
-	(*AddonTypeResponse)(nil),          // 117: plugins.AddonTypeResponse
-	(*AddonInstallAction)(nil),         // 118: plugins.AddonInstallAction
-	nil,                                // 119: plugins.Event.DataEntry
-	nil,                                // 120: plugins.HTTPRequest.HeadersEntry
-	nil,                                // 121: plugins.HTTPRequest.QueryEntry
-	nil,                                // 122: plugins.HTTPResponse.HeadersEntry
-	nil,                                // 123: plugins.UpdateVariablesRequest.VariablesEntry
-	nil,                                // 124: plugins.BroadcastEventRequest.DataEntry
-	nil,                                // 125: plugins.PluginHTTPRequest.HeadersEntry
-	nil,                                // 126: plugins.PluginHTTPResponse.HeadersEntry
-	nil,                                // 127: plugins.AddonTypeRequest.SourceInfoEntry
-	nil,                                // 128: plugins.AddonTypeRequest.ServerVariablesEntry
-	nil,                                // 129: plugins.AddonInstallAction.HeadersEntry
+	(*SearchFileContentsRequest)(nil),  // 76: plugins.SearchFileContentsRequest
+	(*FileContentMatch)(nil),           // 77: plugins.FileContentMatch
+	(*SearchFileContentsResponse)(nil), // 78: plugins.SearchFileContentsResponse
+	(*Backup)(nil),                     // 79: plugins.Backup
+	(*ListBackupsResponse)(nil),        // 80: plugins.ListBackupsResponse
+	(*CreateBackupRequest)(nil),        // 81: plugins.CreateBackupRequest
+	(*DeleteBackupRequest)(nil),        // 82: plugins.DeleteBackupRequest
+	(*ScheduleTaskResult)(nil),         // 83: plugins.ScheduleTaskResult
+	(*ScheduleRun)(nil),                // 84: plugins.ScheduleRun
+	(*ListScheduleRunsRequest)(nil),    // 85: plugins.ListScheduleRunsRequest
+	(*ListScheduleRunsResponse)(nil),   // 86: plugins.ListScheduleRunsResponse
+	(*Node)(nil),                       // 87: plugins.Node
+	(*ListNodesResponse)(nil),          // 88: plugins.ListNodesResponse
+	(*CreateNodeRequest)(nil),          // 89: plugins.CreateNodeRequest
+	(*NodeWithToken)(nil),              // 90: plugins.NodeWithToken
+	(*NodeToken)(nil),                  // 91: plugins.NodeToken
+	(*Package)(nil),                    // 92: plugins.Package
+	(*ListPackagesResponse)(nil),       // 93: plugins.ListPackagesResponse
+	(*CreatePackageRequest)(nil),       // 94: plugins.CreatePackageRequest
+	(*UpdatePackageRequest)(nil),       // 95: plugins.UpdatePackageRequest
+	(*IPBan)(nil),                      // 96: plugins.IPBan
+	(*ListIPBansResponse)(nil),         // 97: plugins.ListIPBansResponse
+	(*CreateIPBanRequest)(nil),         // 98: plugins.CreateIPBanRequest
+	(*Mount)(nil),                      // 99: plugins.Mount
+	(*ListMountsResponse)(nil),         // 100: plugins.ListMountsResponse
+	(*CreateMountRequest)(nil),         // 101: plugins.CreateMountRequest
+	(*UpdateMountRequest)(nil),         // 102: plugins.UpdateMountRequest
+	(*MountServerRequest)(nil),         // 103: plugins.MountServerRequest
+	(*ServerMountInfo)(nil),            // 104: plugins.ServerMountInfo
+	(*ServerMountsResponse)(nil),       // 105: plugins.ServerMountsResponse
+	(*Settings)(nil),                   // 106: plugins.Settings
+	(*ActivityLog)(nil),                // 107: plugins.ActivityLog
+	(*GetLogsRequest)(nil),             // 108: plugins.GetLogsRequest
+	(*GetLogsResponse)(nil),            // 109: plugins.GetLogsResponse
+	(*LogRequest)(nil),                 // 110: plugins.LogRequest
+	(*KVRequest)(nil),                  // 111: plugins.KVRequest
+	(*KVResponse)(nil),                 // 112: plugins.KVResponse
+	(*KVSetRequest)(nil),               // 113: plugins.KVSetRequest
+	(*QueryDBRequest)(nil),             // 114: plugins.QueryDBRequest
+	(*QueryDBResponse)(nil),            // 115: plugins.QueryDBResponse
+	(*BroadcastEventRequest)(nil),      // 116: plugins.BroadcastEventRequest
+	(*NotificationRequest)(nil),        // 117: plugins.NotificationRequest
+	(*PluginHTTPRequest)(nil),          // 118: plugins.PluginHTTPRequest
+	(*PluginHTTPResponse)(nil),         // 119: plugins.PluginHTTPResponse
+	(*CallPluginRequest)(nil),          // 120: plugins.CallPluginRequest
+	(*CallPluginResponse)(nil),         // 121: plugins.CallPluginResponse
+	(*AddonTypeInfo)(nil),              // 122: plugins.AddonTypeInfo
+	(*AddonTypeRequest)(nil),           // 123: plugins.AddonTypeRequest
+	(*AddonTypeResponse)(nil),          // 124: plugins.AddonTypeResponse
+	(*AddonInstallAction)(nil),         // 125: plugins.AddonInstallAction
+	nil,                                // 126: plugins.Event.DataEntry
+	nil,                                // 127: plugins.HTTPRequest.HeadersEntry
+	nil,                                // 128: plugins.HTTPRequest.QueryEntry
+	nil,                                // 129: plugins.HTTPResponse.HeadersEntry
+	nil,                                // 130: plugins.UpdateVariablesRequest.VariablesEntry
+	nil,                                // 131: plugins.BroadcastEventRequest.DataEntry
+	nil,                                // 132: plugins.PluginHTTPRequest.HeadersEntry
+	nil,                                // 133: plugins.PluginHTTPResponse.HeadersEntry
+	nil,                                // 134: plugins.AddonTypeRequest.SourceInfoEntry
+	nil,                                // 135: plugins.AddonTypeRequest.ServerVariablesEntry
+	nil,                                // 136: plugins.AddonInstallAction.HeadersEntry
 }
 var file_plugin_proto_depIdxs = []int32{
 	12,  // 0: plugins.PluginMessage.register:type_name -> plugins.PluginInfo
@@ -8941,18 +9550,18 @@ var file_plugin_proto_depIdxs = []int32{
 	28,  // 2: plugins.PluginMessage.http_response:type_name -> plugins.HTTPResponse
 	4,   // 3: plugins.PluginMessage.schedule_response:type_name -> plugins.Empty
 	20,  // 4: plugins.PluginMessage.mixin_response:type_name -> plugins.MixinResponse
-	117, // 5: plugins.PluginMessage.addon_type_response:type_name -> plugins.AddonTypeResponse
+	124, // 5: plugins.PluginMessage.addon_type_response:type_name -> plugins.AddonTypeResponse
 	4,   // 6: plugins.PanelMessage.registered:type_name -> plugins.Empty
 	25,  // 7: plugins.PanelMessage.event:type_name -> plugins.Event
 	27,  // 8: plugins.PanelMessage.http:type_name -> plugins.HTTPRequest
 	29,  // 9: plugins.PanelMessage.schedule:type_name -> plugins.ScheduleRequest
 	19,  // 10: plugins.PanelMessage.mixin:type_name -> plugins.MixinRequest
 	4,   // 11: plugins.PanelMessage.shutdown:type_name -> plugins.Empty
-	116, // 12: plugins.PanelMessage.addon_type:type_name -> plugins.AddonTypeRequest
+	123, // 12: plugins.PanelMessage.addon_type:type_name -> plugins.AddonTypeRequest
 	22,  // 13: plugins.PluginInfo.routes:type_name -> plugins.RouteInfo
 	24,  // 14: plugins.PluginInfo.schedules:type_name -> plugins.ScheduleInfo
 	18,  // 15: plugins.PluginInfo.mixins:type_name -> plugins.MixinInfo
-	115, // 16: plugins.PluginInfo.addon_types:type_name -> plugins.AddonTypeInfo
+	122, // 16: plugins.PluginInfo.addon_types:type_name -> plugins.AddonTypeInfo
 	13,  // 17: plugins.PluginInfo.ui:type_name -> plugins.PluginUIInfo
 	14,  // 18: plugins.PluginUIInfo.pages:type_name -> plugins.PluginUIPage
 	15,  // 19: plugins.PluginUIInfo.tabs:type_name -> plugins.PluginUITab
@@ -8961,12 +9570,12 @@ var file_plugin_proto_depIdxs = []int32{
 	0,   // 22: plugins.MixinResponse.action:type_name -> plugins.MixinResponse.Action
 	21,  // 23: plugins.MixinResponse.notifications:type_name -> plugins.Notification
 	23,  // 24: plugins.RouteInfo.rate_limit:type_name -> plugins.RateLimitConfig
-	119, // 25: plugins.Event.data:type_name -> plugins.Event.DataEntry
-	120, // 26: plugins.HTTPRequest.headers:type_name -> plugins.HTTPRequest.HeadersEntry
-	121, // 27: plugins.HTTPRequest.query:type_name -> plugins.HTTPRequest.QueryEntry
-	122, // 28: plugins.HTTPResponse.headers:type_name -> plugins.HTTPResponse.HeadersEntry
+	126, // 25: plugins.Event.data:type_name -> plugins.Event.DataEntry
+	127, // 26: plugins.HTTPRequest.headers:type_name -> plugins.HTTPRequest.HeadersEntry
+	128, // 27: plugins.HTTPRequest.query:type_name -> plugins.HTTPRequest.QueryEntry
+	129, // 28: plugins.HTTPResponse.headers:type_name -> plugins.HTTPResponse.HeadersEntry
 	30,  // 29: plugins.ListServersResponse.servers:type_name -> plugins.Server
-	123, // 30: plugins.UpdateVariablesRequest.variables:type_name -> plugins.UpdateVariablesRequest.VariablesEntry
+	130, // 30: plugins.UpdateVariablesRequest.variables:type_name -> plugins.UpdateVariablesRequest.VariablesEntry
 	48,  // 31: plugins.SearchLogsResponse.matches:type_name -> plugins.LogMatch
 	50,  // 32: plugins.LogFilesResponse.files:type_name -> plugins.LogFileInfo
 	52,  // 33: plugins.ListUsersResponse.users:type_name -> plugins.User
@@ -8974,249 +9583,256 @@ var file_plugin_proto_depIdxs = []int32{
 	63,  // 35: plugins.ListDatabasesResponse.databases:type_name -> plugins.Database
 	66,  // 36: plugins.ListDatabaseHostsResponse.hosts:type_name -> plugins.DatabaseHost
 	70,  // 37: plugins.ListFilesResponse.files:type_name -> plugins.FileInfo
-	76,  // 38: plugins.ListBackupsResponse.backups:type_name -> plugins.Backup
-	80,  // 39: plugins.ListNodesResponse.nodes:type_name -> plugins.Node
-	80,  // 40: plugins.NodeWithToken.node:type_name -> plugins.Node
-	85,  // 41: plugins.ListPackagesResponse.packages:type_name -> plugins.Package
-	89,  // 42: plugins.ListIPBansResponse.bans:type_name -> plugins.IPBan
-	92,  // 43: plugins.ListMountsResponse.mounts:type_name -> plugins.Mount
-	97,  // 44: plugins.ServerMountsResponse.mounts:type_name -> plugins.ServerMountInfo
-	100, // 45: plugins.GetLogsResponse.logs:type_name -> plugins.ActivityLog
-	124, // 46: plugins.BroadcastEventRequest.data:type_name -> plugins.BroadcastEventRequest.DataEntry
-	125, // 47: plugins.PluginHTTPRequest.headers:type_name -> plugins.PluginHTTPRequest.HeadersEntry
-	126, // 48: plugins.PluginHTTPResponse.headers:type_name -> plugins.PluginHTTPResponse.HeadersEntry
-	127, // 49: plugins.AddonTypeRequest.source_info:type_name -> plugins.AddonTypeRequest.SourceInfoEntry
-	128, // 50: plugins.AddonTypeRequest.server_variables:type_name -> plugins.AddonTypeRequest.ServerVariablesEntry
-	118, // 51: plugins.AddonTypeResponse.actions:type_name -> plugins.AddonInstallAction
-	1,   // 52: plugins.AddonInstallAction.type:type_name -> plugins.AddonInstallAction.ActionType
-	129, // 53: plugins.AddonInstallAction.headers:type_name -> plugins.AddonInstallAction.HeadersEntry
-	4,   // 54: plugins.PluginService.GetInfo:input_type -> plugins.Empty
-	25,  // 55: plugins.PluginService.OnEvent:input_type -> plugins.Event
-	27,  // 56: plugins.PluginService.OnHTTP:input_type -> plugins.HTTPRequest
-	29,  // 57: plugins.PluginService.OnSchedule:input_type -> plugins.ScheduleRequest
-	19,  // 58: plugins.PluginService.OnMixin:input_type -> plugins.MixinRequest
-	4,   // 59: plugins.PluginService.Shutdown:input_type -> plugins.Empty
-	9,   // 60: plugins.PluginService.SendEmail:input_type -> plugins.SendEmailRequest
-	2,   // 61: plugins.PanelService.Connect:input_type -> plugins.PluginMessage
-	5,   // 62: plugins.PanelService.GetServer:input_type -> plugins.IDRequest
-	31,  // 63: plugins.PanelService.ListServers:input_type -> plugins.ListServersRequest
-	33,  // 64: plugins.PanelService.CreateServer:input_type -> plugins.CreateServerRequest
-	5,   // 65: plugins.PanelService.DeleteServer:input_type -> plugins.IDRequest
-	34,  // 66: plugins.PanelService.UpdateServer:input_type -> plugins.UpdateServerRequest
-	5,   // 67: plugins.PanelService.SuspendServer:input_type -> plugins.IDRequest
-	5,   // 68: plugins.PanelService.UnsuspendServer:input_type -> plugins.IDRequest
-	5,   // 69: plugins.PanelService.StartServer:input_type -> plugins.IDRequest
-	5,   // 70: plugins.PanelService.StopServer:input_type -> plugins.IDRequest
-	5,   // 71: plugins.PanelService.RestartServer:input_type -> plugins.IDRequest
-	5,   // 72: plugins.PanelService.KillServer:input_type -> plugins.IDRequest
-	5,   // 73: plugins.PanelService.ReinstallServer:input_type -> plugins.IDRequest
-	35,  // 74: plugins.PanelService.TransferServer:input_type -> plugins.TransferServerRequest
-	36,  // 75: plugins.PanelService.GetConsoleLog:input_type -> plugins.ConsoleLogRequest
-	38,  // 76: plugins.PanelService.SendCommand:input_type -> plugins.SendCommandRequest
-	43,  // 77: plugins.PanelService.StreamConsole:input_type -> plugins.StreamConsoleRequest
-	5,   // 78: plugins.PanelService.GetFullLog:input_type -> plugins.IDRequest
-	46,  // 79: plugins.PanelService.SearchLogs:input_type -> plugins.SearchLogsRequest
-	5,   // 80: plugins.PanelService.ListLogFiles:input_type -> plugins.IDRequest
-	51,  // 81: plugins.PanelService.ReadLogFile:input_type -> plugins.ReadLogFileRequest
-	5,   // 82: plugins.PanelService.GetServerStats:input_type -> plugins.IDRequest
-	40,  // 83: plugins.PanelService.AddAllocation:input_type -> plugins.AllocationRequest
-	40,  // 84: plugins.PanelService.DeleteAllocation:input_type -> plugins.AllocationRequest
-	40,  // 85: plugins.PanelService.SetPrimaryAllocation:input_type -> plugins.AllocationRequest
-	42,  // 86: plugins.PanelService.UpdateServerVariables:input_type -> plugins.UpdateVariablesRequest
-	5,   // 87: plugins.PanelService.GetUser:input_type -> plugins.IDRequest
-	6,   // 88: plugins.PanelService.GetUserByEmail:input_type -> plugins.EmailRequest
-	7,   // 89: plugins.PanelService.GetUserByUsername:input_type -> plugins.UsernameRequest
-	53,  // 90: plugins.PanelService.ListUsers:input_type -> plugins.ListUsersRequest
-	55,  // 91: plugins.PanelService.CreateUser:input_type -> plugins.CreateUserRequest
-	5,   // 92: plugins.PanelService.DeleteUser:input_type -> plugins.IDRequest
-	56,  // 93: plugins.PanelService.UpdateUser:input_type -> plugins.UpdateUserRequest
-	5,   // 94: plugins.PanelService.BanUser:input_type -> plugins.IDRequest
-	5,   // 95: plugins.PanelService.UnbanUser:input_type -> plugins.IDRequest
-	5,   // 96: plugins.PanelService.SetAdmin:input_type -> plugins.IDRequest
-	5,   // 97: plugins.PanelService.RevokeAdmin:input_type -> plugins.IDRequest
-	57,  // 98: plugins.PanelService.SetUserResources:input_type -> plugins.SetUserResourcesRequest
-	5,   // 99: plugins.PanelService.ForcePasswordReset:input_type -> plugins.IDRequest
-	6,   // 100: plugins.PanelService.RequestPasswordReset:input_type -> plugins.EmailRequest
-	5,   // 101: plugins.PanelService.SendVerificationEmail:input_type -> plugins.IDRequest
-	5,   // 102: plugins.PanelService.GetUser2FAStatus:input_type -> plugins.IDRequest
-	10,  // 103: plugins.PanelService.AdminDisable2FA:input_type -> plugins.Handle2FARequest
-	5,   // 104: plugins.PanelService.ListSubusers:input_type -> plugins.IDRequest
-	60,  // 105: plugins.PanelService.AddSubuser:input_type -> plugins.AddSubuserRequest
-	61,  // 106: plugins.PanelService.UpdateSubuser:input_type -> plugins.UpdateSubuserRequest
-	62,  // 107: plugins.PanelService.RemoveSubuser:input_type -> plugins.RemoveSubuserRequest
-	5,   // 108: plugins.PanelService.ListDatabases:input_type -> plugins.IDRequest
-	65,  // 109: plugins.PanelService.CreateDatabase:input_type -> plugins.CreateDatabaseRequest
-	5,   // 110: plugins.PanelService.DeleteDatabase:input_type -> plugins.IDRequest
-	5,   // 111: plugins.PanelService.RotateDatabasePassword:input_type -> plugins.IDRequest
-	4,   // 112: plugins.PanelService.ListDatabaseHosts:input_type -> plugins.Empty
-	68,  // 113: plugins.PanelService.CreateDatabaseHost:input_type -> plugins.CreateDatabaseHostRequest
-	69,  // 114: plugins.PanelService.UpdateDatabaseHost:input_type -> plugins.UpdateDatabaseHostRequest
-	5,   // 115: plugins.PanelService.DeleteDatabaseHost:input_type -> plugins.IDRequest
-	72,  // 116: plugins.PanelService.ListFiles:input_type -> plugins.FilePathRequest
-	72,  // 117: plugins.PanelService.ReadFile:input_type -> plugins.FilePathRequest
-	74,  // 118: plugins.PanelService.WriteFile:input_type -> plugins.WriteFileRequest
-	72,  // 119: plugins.PanelService.DeleteFile:input_type -> plugins.FilePathRequest
-	72,  // 120: plugins.PanelService.CreateFolder:input_type -> plugins.FilePathRequest
-	75,  // 121: plugins.PanelService.MoveFile:input_type -> plugins.MoveFileRequest
-	75,  // 122: plugins.PanelService.CopyFile:input_type -> plugins.MoveFileRequest
-	41,  // 123: plugins.PanelService.CompressFiles:input_type -> plugins.CompressRequest
-	72,  // 124: plugins.PanelService.DecompressFile:input_type -> plugins.FilePathRequest
-	5,   // 125: plugins.PanelService.ListBackups:input_type -> plugins.IDRequest
-	78,  // 126: plugins.PanelService.CreateBackup:input_type -> plugins.CreateBackupRequest
-	79,  // 127: plugins.PanelService.DeleteBackup:input_type -> plugins.DeleteBackupRequest
-	4,   // 128: plugins.PanelService.ListNodes:input_type -> plugins.Empty
-	5,   // 129: plugins.PanelService.GetNode:input_type -> plugins.IDRequest
-	82,  // 130: plugins.PanelService.CreateNode:input_type -> plugins.CreateNodeRequest
-	5,   // 131: plugins.PanelService.DeleteNode:input_type -> plugins.IDRequest
-	5,   // 132: plugins.PanelService.ResetNodeToken:input_type -> plugins.IDRequest
-	4,   // 133: plugins.PanelService.ListPackages:input_type -> plugins.Empty
-	5,   // 134: plugins.PanelService.GetPackage:input_type -> plugins.IDRequest
-	87,  // 135: plugins.PanelService.CreatePackage:input_type -> plugins.CreatePackageRequest
-	88,  // 136: plugins.PanelService.UpdatePackage:input_type -> plugins.UpdatePackageRequest
-	5,   // 137: plugins.PanelService.DeletePackage:input_type -> plugins.IDRequest
-	4,   // 138: plugins.PanelService.ListIPBans:input_type -> plugins.Empty
-	91,  // 139: plugins.PanelService.CreateIPBan:input_type -> plugins.CreateIPBanRequest
-	5,   // 140: plugins.PanelService.DeleteIPBan:input_type -> plugins.IDRequest
-	4,   // 141: plugins.PanelService.ListMounts:input_type -> plugins.Empty
-	5,   // 142: plugins.PanelService.GetMount:input_type -> plugins.IDRequest
-	94,  // 143: plugins.PanelService.CreateMount:input_type -> plugins.CreateMountRequest
-	95,  // 144: plugins.PanelService.UpdateMount:input_type -> plugins.UpdateMountRequest
-	5,   // 145: plugins.PanelService.DeleteMount:input_type -> plugins.IDRequest
-	96,  // 146: plugins.PanelService.AddMountToServer:input_type -> plugins.MountServerRequest
-	96,  // 147: plugins.PanelService.RemoveMountFromServer:input_type -> plugins.MountServerRequest
-	5,   // 148: plugins.PanelService.GetServerMounts:input_type -> plugins.IDRequest
-	96,  // 149: plugins.PanelService.MountServerMount:input_type -> plugins.MountServerRequest
-	96,  // 150: plugins.PanelService.UnmountServerMount:input_type -> plugins.MountServerRequest
-	4,   // 151: plugins.PanelService.GetSettings:input_type -> plugins.Empty
-	8,   // 152: plugins.PanelService.SetRegistrationEnabled:input_type -> plugins.BoolRequest
-	8,   // 153: plugins.PanelService.SetServerCreationEnabled:input_type -> plugins.BoolRequest
-	101, // 154: plugins.PanelService.GetActivityLogs:input_type -> plugins.GetLogsRequest
-	103, // 155: plugins.PanelService.Log:input_type -> plugins.LogRequest
-	104, // 156: plugins.PanelService.GetKV:input_type -> plugins.KVRequest
-	106, // 157: plugins.PanelService.SetKV:input_type -> plugins.KVSetRequest
-	104, // 158: plugins.PanelService.DeleteKV:input_type -> plugins.KVRequest
-	107, // 159: plugins.PanelService.QueryDB:input_type -> plugins.QueryDBRequest
-	109, // 160: plugins.PanelService.BroadcastEvent:input_type -> plugins.BroadcastEventRequest
-	110, // 161: plugins.PanelService.SendNotification:input_type -> plugins.NotificationRequest
-	111, // 162: plugins.PanelService.HTTPRequest:input_type -> plugins.PluginHTTPRequest
-	113, // 163: plugins.PanelService.CallPlugin:input_type -> plugins.CallPluginRequest
-	9,   // 164: plugins.PanelService.SendEmail:input_type -> plugins.SendEmailRequest
-	12,  // 165: plugins.PluginService.GetInfo:output_type -> plugins.PluginInfo
-	26,  // 166: plugins.PluginService.OnEvent:output_type -> plugins.EventResponse
-	28,  // 167: plugins.PluginService.OnHTTP:output_type -> plugins.HTTPResponse
-	4,   // 168: plugins.PluginService.OnSchedule:output_type -> plugins.Empty
-	20,  // 169: plugins.PluginService.OnMixin:output_type -> plugins.MixinResponse
-	4,   // 170: plugins.PluginService.Shutdown:output_type -> plugins.Empty
-	4,   // 171: plugins.PluginService.SendEmail:output_type -> plugins.Empty
-	3,   // 172: plugins.PanelService.Connect:output_type -> plugins.PanelMessage
-	30,  // 173: plugins.PanelService.GetServer:output_type -> plugins.Server
-	32,  // 174: plugins.PanelService.ListServers:output_type -> plugins.ListServersResponse
-	30,  // 175: plugins.PanelService.CreateServer:output_type -> plugins.Server
-	4,   // 176: plugins.PanelService.DeleteServer:output_type -> plugins.Empty
-	30,  // 177: plugins.PanelService.UpdateServer:output_type -> plugins.Server
-	4,   // 178: plugins.PanelService.SuspendServer:output_type -> plugins.Empty
-	4,   // 179: plugins.PanelService.UnsuspendServer:output_type -> plugins.Empty
-	4,   // 180: plugins.PanelService.StartServer:output_type -> plugins.Empty
-	4,   // 181: plugins.PanelService.StopServer:output_type -> plugins.Empty
-	4,   // 182: plugins.PanelService.RestartServer:output_type -> plugins.Empty
-	4,   // 183: plugins.PanelService.KillServer:output_type -> plugins.Empty
-	4,   // 184: plugins.PanelService.ReinstallServer:output_type -> plugins.Empty
-	4,   // 185: plugins.PanelService.TransferServer:output_type -> plugins.Empty
-	37,  // 186: plugins.PanelService.GetConsoleLog:output_type -> plugins.ConsoleLogResponse
-	4,   // 187: plugins.PanelService.SendCommand:output_type -> plugins.Empty
-	44,  // 188: plugins.PanelService.StreamConsole:output_type -> plugins.ConsoleLine
-	45,  // 189: plugins.PanelService.GetFullLog:output_type -> plugins.FullLogResponse
-	47,  // 190: plugins.PanelService.SearchLogs:output_type -> plugins.SearchLogsResponse
-	49,  // 191: plugins.PanelService.ListLogFiles:output_type -> plugins.LogFilesResponse
-	45,  // 192: plugins.PanelService.ReadLogFile:output_type -> plugins.FullLogResponse
-	39,  // 193: plugins.PanelService.GetServerStats:output_type -> plugins.ServerStats
-	4,   // 194: plugins.PanelService.AddAllocation:output_type -> plugins.Empty
-	4,   // 195: plugins.PanelService.DeleteAllocation:output_type -> plugins.Empty
-	4,   // 196: plugins.PanelService.SetPrimaryAllocation:output_type -> plugins.Empty
-	4,   // 197: plugins.PanelService.UpdateServerVariables:output_type -> plugins.Empty
-	52,  // 198: plugins.PanelService.GetUser:output_type -> plugins.User
-	52,  // 199: plugins.PanelService.GetUserByEmail:output_type -> plugins.User
-	52,  // 200: plugins.PanelService.GetUserByUsername:output_type -> plugins.User
-	54,  // 201: plugins.PanelService.ListUsers:output_type -> plugins.ListUsersResponse
-	52,  // 202: plugins.PanelService.CreateUser:output_type -> plugins.User
-	4,   // 203: plugins.PanelService.DeleteUser:output_type -> plugins.Empty
-	52,  // 204: plugins.PanelService.UpdateUser:output_type -> plugins.User
-	4,   // 205: plugins.PanelService.BanUser:output_type -> plugins.Empty
-	4,   // 206: plugins.PanelService.UnbanUser:output_type -> plugins.Empty
-	4,   // 207: plugins.PanelService.SetAdmin:output_type -> plugins.Empty
-	4,   // 208: plugins.PanelService.RevokeAdmin:output_type -> plugins.Empty
-	4,   // 209: plugins.PanelService.SetUserResources:output_type -> plugins.Empty
-	4,   // 210: plugins.PanelService.ForcePasswordReset:output_type -> plugins.Empty
-	4,   // 211: plugins.PanelService.RequestPasswordReset:output_type -> plugins.Empty
-	4,   // 212: plugins.PanelService.SendVerificationEmail:output_type -> plugins.Empty
-	11,  // 213: plugins.PanelService.GetUser2FAStatus:output_type -> plugins.TwoFactorStatus
-	4,   // 214: plugins.PanelService.AdminDisable2FA:output_type -> plugins.Empty
-	59,  // 215: plugins.PanelService.ListSubusers:output_type -> plugins.ListSubusersResponse
-	58,  // 216: plugins.PanelService.AddSubuser:output_type -> plugins.Subuser
-	4,   // 217: plugins.PanelService.UpdateSubuser:output_type -> plugins.Empty
-	4,   // 218: plugins.PanelService.RemoveSubuser:output_type -> plugins.Empty
-	64,  // 219: plugins.PanelService.ListDatabases:output_type -> plugins.ListDatabasesResponse
-	63,  // 220: plugins.PanelService.CreateDatabase:output_type -> plugins.Database
-	4,   // 221: plugins.PanelService.DeleteDatabase:output_type -> plugins.Empty
-	63,  // 222: plugins.PanelService.RotateDatabasePassword:output_type -> plugins.Database
-	67,  // 223: plugins.PanelService.ListDatabaseHosts:output_type -> plugins.ListDatabaseHostsResponse
-	66,  // 224: plugins.PanelService.CreateDatabaseHost:output_type -> plugins.DatabaseHost
-	4,   // 225: plugins.PanelService.UpdateDatabaseHost:output_type -> plugins.Empty
-	4,   // 226: plugins.PanelService.DeleteDatabaseHost:output_type -> plugins.Empty
-	71,  // 227: plugins.PanelService.ListFiles:output_type -> plugins.ListFilesResponse
-	73,  // 228: plugins.PanelService.ReadFile:output_type -> plugins.FileContent
-	4,   // 229: plugins.PanelService.WriteFile:output_type -> plugins.Empty
-	4,   // 230: plugins.PanelService.DeleteFile:output_type -> plugins.Empty
-	4,   // 231: plugins.PanelService.CreateFolder:output_type -> plugins.Empty
-	4,   // 232: plugins.PanelService.MoveFile:output_type -> plugins.Empty
-	4,   // 233: plugins.PanelService.CopyFile:output_type -> plugins.Empty
-	4,   // 234: plugins.PanelService.CompressFiles:output_type -> plugins.Empty
-	4,   // 235: plugins.PanelService.DecompressFile:output_type -> plugins.Empty
-	77,  // 236: plugins.PanelService.ListBackups:output_type -> plugins.ListBackupsResponse
-	4,   // 237: plugins.PanelService.CreateBackup:output_type -> plugins.Empty
-	4,   // 238: plugins.PanelService.DeleteBackup:output_type -> plugins.Empty
-	81,  // 239: plugins.PanelService.ListNodes:output_type -> plugins.ListNodesResponse
-	80,  // 240: plugins.PanelService.GetNode:output_type -> plugins.Node
-	83,  // 241: plugins.PanelService.CreateNode:output_type -> plugins.NodeWithToken
-	4,   // 242: plugins.PanelService.DeleteNode:output_type -> plugins.Empty
-	84,  // 243: plugins.PanelService.ResetNodeToken:output_type -> plugins.NodeToken
-	86,  // 244: plugins.PanelService.ListPackages:output_type -> plugins.ListPackagesResponse
-	85,  // 245: plugins.PanelService.GetPackage:output_type -> plugins.Package
-	85,  // 246: plugins.PanelService.CreatePackage:output_type -> plugins.Package
-	85,  // 247: plugins.PanelService.UpdatePackage:output_type -> plugins.Package
-	4,   // 248: plugins.PanelService.DeletePackage:output_type -> plugins.Empty
-	90,  // 249: plugins.PanelService.ListIPBans:output_type -> plugins.ListIPBansResponse
-	89,  // 250: plugins.PanelService.CreateIPBan:output_type -> plugins.IPBan
-	4,   // 251: plugins.PanelService.DeleteIPBan:output_type -> plugins.Empty
-	93,  // 252: plugins.PanelService.ListMounts:output_type -> plugins.ListMountsResponse
-	92,  // 253: plugins.PanelService.GetMount:output_type -> plugins.Mount
-	92,  // 254: plugins.PanelService.CreateMount:output_type -> plugins.Mount
-	92,  // 255: plugins.PanelService.UpdateMount:output_type -> plugins.Mount
-	4,   // 256: plugins.PanelService.DeleteMount:output_type -> plugins.Empty
-	4,   // 257: plugins.PanelService.AddMountToServer:output_type -> plugins.Empty
-	4,   // 258: plugins.PanelService.RemoveMountFromServer:output_type -> plugins.Empty
-	98,  // 259: plugins.PanelService.GetServerMounts:output_type -> plugins.ServerMountsResponse
-	4,   // 260: plugins.PanelService.MountServerMount:output_type -> plugins.Empty
-	4,   // 261: plugins.PanelService.UnmountServerMount:output_type -> plugins.Empty
-	99,  // 262: plugins.PanelService.GetSettings:output_type -> plugins.Settings
-	4,   // 263: plugins.PanelService.SetRegistrationEnabled:output_type -> plugins.Empty
-	4,   // 264: plugins.PanelService.SetServerCreationEnabled:output_type -> plugins.Empty
-	102, // 265: plugins.PanelService.GetActivityLogs:output_type -> plugins.GetLogsResponse
-	4,   // 266: plugins.PanelService.Log:output_type -> plugins.Empty
-	105, // 267: plugins.PanelService.GetKV:output_type -> plugins.KVResponse
-	4,   // 268: plugins.PanelService.SetKV:output_type -> plugins.Empty
-	4,   // 269: plugins.PanelService.DeleteKV:output_type -> plugins.Empty
-	108, // 270: plugins.PanelService.QueryDB:output_type -> plugins.QueryDBResponse
-	4,   // 271: plugins.PanelService.BroadcastEvent:output_type -> plugins.Empty
-	4,   // 272: plugins.PanelService.SendNotification:output_type -> plugins.Empty
-	112, // 273: plugins.PanelService.HTTPRequest:output_type -> plugins.PluginHTTPResponse
-	114, // 274: plugins.PanelService.CallPlugin:output_type -> plugins.CallPluginResponse
-	4,   // 275: plugins.PanelService.SendEmail:output_type -> plugins.Empty
-	165, // [165:276] is the sub-list for method output_type
-	54,  // [54:165] is the sub-list for method input_type
-	54,  // [54:54] is the sub-list for extension type_name
-	54,  // [54:54] is the sub-list for extension extendee
-	0,   // [0:54] is the sub-list for field type_name
+	77,  // 38: plugins.SearchFileContentsResponse.matches:type_name -> plugins.FileContentMatch
+	79,  // 39: plugins.ListBackupsResponse.backups:type_name -> plugins.Backup
+	83,  // 40: plugins.ScheduleRun.results:type_name -> plugins.ScheduleTaskResult
+	84,  // 41: plugins.ListScheduleRunsResponse.runs:type_name -> plugins.ScheduleRun
+	87,  // 42: plugins.ListNodesResponse.nodes:type_name -> plugins.Node
+	87,  // 43: plugins.NodeWithToken.node:type_name -> plugins.Node
+	92,  // 44: plugins.ListPackagesResponse.packages:type_name -> plugins.Package
+	96,  // 45: plugins.ListIPBansResponse.bans:type_name -> plugins.IPBan
+	99,  // 46: plugins.ListMountsResponse.mounts:type_name -> plugins.Mount
+	104, // 47: plugins.ServerMountsResponse.mounts:type_name -> plugins.ServerMountInfo
+	107, // 48: plugins.GetLogsResponse.logs:type_name -> plugins.ActivityLog
+	131, // 49: plugins.BroadcastEventRequest.data:type_name -> plugins.BroadcastEventRequest.DataEntry
+	132, // 50: plugins.PluginHTTPRequest.headers:type_name -> plugins.PluginHTTPRequest.HeadersEntry
+	133, // 51: plugins.PluginHTTPResponse.headers:type_name -> plugins.PluginHTTPResponse.HeadersEntry
+	134, // 52: plugins.AddonTypeRequest.source_info:type_name -> plugins.AddonTypeRequest.SourceInfoEntry
+	135, // 53: plugins.AddonTypeRequest.server_variables:type_name -> plugins.AddonTypeRequest.ServerVariablesEntry
+	125, // 54: plugins.AddonTypeResponse.actions:type_name -> plugins.AddonInstallAction
+	1,   // 55: plugins.AddonInstallAction.type:type_name -> plugins.AddonInstallAction.ActionType
+	136, // 56: plugins.AddonInstallAction.headers:type_name -> plugins.AddonInstallAction.HeadersEntry
+	4,   // 57: plugins.PluginService.GetInfo:input_type -> plugins.Empty
+	25,  // 58: plugins.PluginService.OnEvent:input_type -> plugins.Event
+	27,  // 59: plugins.PluginService.OnHTTP:input_type -> plugins.HTTPRequest
+	29,  // 60: plugins.PluginService.OnSchedule:input_type -> plugins.ScheduleRequest
+	19,  // 61: plugins.PluginService.OnMixin:input_type -> plugins.MixinRequest
+	4,   // 62: plugins.PluginService.Shutdown:input_type -> plugins.Empty
+	9,   // 63: plugins.PluginService.SendEmail:input_type -> plugins.SendEmailRequest
+	2,   // 64: plugins.PanelService.Connect:input_type -> plugins.PluginMessage
+	5,   // 65: plugins.PanelService.GetServer:input_type -> plugins.IDRequest
+	31,  // 66: plugins.PanelService.ListServers:input_type -> plugins.ListServersRequest
+	33,  // 67: plugins.PanelService.CreateServer:input_type -> plugins.CreateServerRequest
+	5,   // 68: plugins.PanelService.DeleteServer:input_type -> plugins.IDRequest
+	34,  // 69: plugins.PanelService.UpdateServer:input_type -> plugins.UpdateServerRequest
+	5,   // 70: plugins.PanelService.SuspendServer:input_type -> plugins.IDRequest
+	5,   // 71: plugins.PanelService.UnsuspendServer:input_type -> plugins.IDRequest
+	5,   // 72: plugins.PanelService.StartServer:input_type -> plugins.IDRequest
+	5,   // 73: plugins.PanelService.StopServer:input_type -> plugins.IDRequest
+	5,   // 74: plugins.PanelService.RestartServer:input_type -> plugins.IDRequest
+	5,   // 75: plugins.PanelService.KillServer:input_type -> plugins.IDRequest
+	5,   // 76: plugins.PanelService.ReinstallServer:input_type -> plugins.IDRequest
+	35,  // 77: plugins.PanelService.TransferServer:input_type -> plugins.TransferServerRequest
+	36,  // 78: plugins.PanelService.GetConsoleLog:input_type -> plugins.ConsoleLogRequest
+	38,  // 79: plugins.PanelService.SendCommand:input_type -> plugins.SendCommandRequest
+	43,  // 80: plugins.PanelService.StreamConsole:input_type -> plugins.StreamConsoleRequest
+	5,   // 81: plugins.PanelService.GetFullLog:input_type -> plugins.IDRequest
+	46,  // 82: plugins.PanelService.SearchLogs:input_type -> plugins.SearchLogsRequest
+	5,   // 83: plugins.PanelService.ListLogFiles:input_type -> plugins.IDRequest
+	51,  // 84: plugins.PanelService.ReadLogFile:input_type -> plugins.ReadLogFileRequest
+	5,   // 85: plugins.PanelService.GetServerStats:input_type -> plugins.IDRequest
+	40,  // 86: plugins.PanelService.AddAllocation:input_type -> plugins.AllocationRequest
+	40,  // 87: plugins.PanelService.DeleteAllocation:input_type -> plugins.AllocationRequest
+	40,  // 88: plugins.PanelService.SetPrimaryAllocation:input_type -> plugins.AllocationRequest
+	42,  // 89: plugins.PanelService.UpdateServerVariables:input_type -> plugins.UpdateVariablesRequest
+	5,   // 90: plugins.PanelService.GetUser:input_type -> plugins.IDRequest
+	6,   // 91: plugins.PanelService.GetUserByEmail:input_type -> plugins.EmailRequest
+	7,   // 92: plugins.PanelService.GetUserByUsername:input_type -> plugins.UsernameRequest
+	53,  // 93: plugins.PanelService.ListUsers:input_type -> plugins.ListUsersRequest
+	55,  // 94: plugins.PanelService.CreateUser:input_type -> plugins.CreateUserRequest
+	5,   // 95: plugins.PanelService.DeleteUser:input_type -> plugins.IDRequest
+	56,  // 96: plugins.PanelService.UpdateUser:input_type -> plugins.UpdateUserRequest
+	5,   // 97: plugins.PanelService.BanUser:input_type -> plugins.IDRequest
+	5,   // 98: plugins.PanelService.UnbanUser:input_type -> plugins.IDRequest
+	5,   // 99: plugins.PanelService.SetAdmin:input_type -> plugins.IDRequest
+	5,   // 100: plugins.PanelService.RevokeAdmin:input_type -> plugins.IDRequest
+	57,  // 101: plugins.PanelService.SetUserResources:input_type -> plugins.SetUserResourcesRequest
+	5,   // 102: plugins.PanelService.ForcePasswordReset:input_type -> plugins.IDRequest
+	6,   // 103: plugins.PanelService.RequestPasswordReset:input_type -> plugins.EmailRequest
+	5,   // 104: plugins.PanelService.SendVerificationEmail:input_type -> plugins.IDRequest
+	5,   // 105: plugins.PanelService.GetUser2FAStatus:input_type -> plugins.IDRequest
+	10,  // 106: plugins.PanelService.AdminDisable2FA:input_type -> plugins.Handle2FARequest
+	5,   // 107: plugins.PanelService.ListSubusers:input_type -> plugins.IDRequest
+	60,  // 108: plugins.PanelService.AddSubuser:input_type -> plugins.AddSubuserRequest
+	61,  // 109: plugins.PanelService.UpdateSubuser:input_type -> plugins.UpdateSubuserRequest
+	62,  // 110: plugins.PanelService.RemoveSubuser:input_type -> plugins.RemoveSubuserRequest
+	5,   // 111: plugins.PanelService.ListDatabases:input_type -> plugins.IDRequest
+	65,  // 112: plugins.PanelService.CreateDatabase:input_type -> plugins.CreateDatabaseRequest
+	5,   // 113: plugins.PanelService.DeleteDatabase:input_type -> plugins.IDRequest
+	5,   // 114: plugins.PanelService.RotateDatabasePassword:input_type -> plugins.IDRequest
+	4,   // 115: plugins.PanelService.ListDatabaseHosts:input_type -> plugins.Empty
+	68,  // 116: plugins.PanelService.CreateDatabaseHost:input_type -> plugins.CreateDatabaseHostRequest
+	69,  // 117: plugins.PanelService.UpdateDatabaseHost:input_type -> plugins.UpdateDatabaseHostRequest
+	5,   // 118: plugins.PanelService.DeleteDatabaseHost:input_type -> plugins.IDRequest
+	72,  // 119: plugins.PanelService.ListFiles:input_type -> plugins.FilePathRequest
+	72,  // 120: plugins.PanelService.ReadFile:input_type -> plugins.FilePathRequest
+	74,  // 121: plugins.PanelService.WriteFile:input_type -> plugins.WriteFileRequest
+	72,  // 122: plugins.PanelService.DeleteFile:input_type -> plugins.FilePathRequest
+	72,  // 123: plugins.PanelService.CreateFolder:input_type -> plugins.FilePathRequest
+	75,  // 124: plugins.PanelService.MoveFile:input_type -> plugins.MoveFileRequest
+	75,  // 125: plugins.PanelService.CopyFile:input_type -> plugins.MoveFileRequest
+	41,  // 126: plugins.PanelService.CompressFiles:input_type -> plugins.CompressRequest
+	72,  // 127: plugins.PanelService.DecompressFile:input_type -> plugins.FilePathRequest
+	76,  // 128: plugins.PanelService.SearchFileContents:input_type -> plugins.SearchFileContentsRequest
+	5,   // 129: plugins.PanelService.ListBackups:input_type -> plugins.IDRequest
+	81,  // 130: plugins.PanelService.CreateBackup:input_type -> plugins.CreateBackupRequest
+	82,  // 131: plugins.PanelService.DeleteBackup:input_type -> plugins.DeleteBackupRequest
+	85,  // 132: plugins.PanelService.ListScheduleRuns:input_type -> plugins.ListScheduleRunsRequest
+	4,   // 133: plugins.PanelService.ListNodes:input_type -> plugins.Empty
+	5,   // 134: plugins.PanelService.GetNode:input_type -> plugins.IDRequest
+	89,  // 135: plugins.PanelService.CreateNode:input_type -> plugins.CreateNodeRequest
+	5,   // 136: plugins.PanelService.DeleteNode:input_type -> plugins.IDRequest
+	5,   // 137: plugins.PanelService.ResetNodeToken:input_type -> plugins.IDRequest
+	4,   // 138: plugins.PanelService.ListPackages:input_type -> plugins.Empty
+	5,   // 139: plugins.PanelService.GetPackage:input_type -> plugins.IDRequest
+	94,  // 140: plugins.PanelService.CreatePackage:input_type -> plugins.CreatePackageRequest
+	95,  // 141: plugins.PanelService.UpdatePackage:input_type -> plugins.UpdatePackageRequest
+	5,   // 142: plugins.PanelService.DeletePackage:input_type -> plugins.IDRequest
+	4,   // 143: plugins.PanelService.ListIPBans:input_type -> plugins.Empty
+	98,  // 144: plugins.PanelService.CreateIPBan:input_type -> plugins.CreateIPBanRequest
+	5,   // 145: plugins.PanelService.DeleteIPBan:input_type -> plugins.IDRequest
+	4,   // 146: plugins.PanelService.ListMounts:input_type -> plugins.Empty
+	5,   // 147: plugins.PanelService.GetMount:input_type -> plugins.IDRequest
+	101, // 148: plugins.PanelService.CreateMount:input_type -> plugins.CreateMountRequest
+	102, // 149: plugins.PanelService.UpdateMount:input_type -> plugins.UpdateMountRequest
+	5,   // 150: plugins.PanelService.DeleteMount:input_type -> plugins.IDRequest
+	103, // 151: plugins.PanelService.AddMountToServer:input_type -> plugins.MountServerRequest
+	103, // 152: plugins.PanelService.RemoveMountFromServer:input_type -> plugins.MountServerRequest
+	5,   // 153: plugins.PanelService.GetServerMounts:input_type -> plugins.IDRequest
+	103, // 154: plugins.PanelService.MountServerMount:input_type -> plugins.MountServerRequest
+	103, // 155: plugins.PanelService.UnmountServerMount:input_type -> plugins.MountServerRequest
+	4,   // 156: plugins.PanelService.GetSettings:input_type -> plugins.Empty
+	8,   // 157: plugins.PanelService.SetRegistrationEnabled:input_type -> plugins.BoolRequest
+	8,   // 158: plugins.PanelService.SetServerCreationEnabled:input_type -> plugins.BoolRequest
+	108, // 159: plugins.PanelService.GetActivityLogs:input_type -> plugins.GetLogsRequest
+	110, // 160: plugins.PanelService.Log:input_type -> plugins.LogRequest
+	111, // 161: plugins.PanelService.GetKV:input_type -> plugins.KVRequest
+	113, // 162: plugins.PanelService.SetKV:input_type -> plugins.KVSetRequest
+	111, // 163: plugins.PanelService.DeleteKV:input_type -> plugins.KVRequest
+	114, // 164: plugins.PanelService.QueryDB:input_type -> plugins.QueryDBRequest
+	116, // 165: plugins.PanelService.BroadcastEvent:input_type -> plugins.BroadcastEventRequest
+	117, // 166: plugins.PanelService.SendNotification:input_type -> plugins.NotificationRequest
+	118, // 167: plugins.PanelService.HTTPRequest:input_type -> plugins.PluginHTTPRequest
+	120, // 168: plugins.PanelService.CallPlugin:input_type -> plugins.CallPluginRequest
+	9,   // 169: plugins.PanelService.SendEmail:input_type -> plugins.SendEmailRequest
+	12,  // 170: plugins.PluginService.GetInfo:output_type -> plugins.PluginInfo
+	26,  // 171: plugins.PluginService.OnEvent:output_type -> plugins.EventResponse
+	28,  // 172: plugins.PluginService.OnHTTP:output_type -> plugins.HTTPResponse
+	4,   // 173: plugins.PluginService.OnSchedule:output_type -> plugins.Empty
+	20,  // 174: plugins.PluginService.OnMixin:output_type -> plugins.MixinResponse
+	4,   // 175: plugins.PluginService.Shutdown:output_type -> plugins.Empty
+	4,   // 176: plugins.PluginService.SendEmail:output_type -> plugins.Empty
+	3,   // 177: plugins.PanelService.Connect:output_type -> plugins.PanelMessage
+	30,  // 178: plugins.PanelService.GetServer:output_type -> plugins.Server
+	32,  // 179: plugins.PanelService.ListServers:output_type -> plugins.ListServersResponse
+	30,  // 180: plugins.PanelService.CreateServer:output_type -> plugins.Server
+	4,   // 181: plugins.PanelService.DeleteServer:output_type -> plugins.Empty
+	30,  // 182: plugins.PanelService.UpdateServer:output_type -> plugins.Server
+	4,   // 183: plugins.PanelService.SuspendServer:output_type -> plugins.Empty
+	4,   // 184: plugins.PanelService.UnsuspendServer:output_type -> plugins.Empty
+	4,   // 185: plugins.PanelService.StartServer:output_type -> plugins.Empty
+	4,   // 186: plugins.PanelService.StopServer:output_type -> plugins.Empty
+	4,   // 187: plugins.PanelService.RestartServer:output_type -> plugins.Empty
+	4,   // 188: plugins.PanelService.KillServer:output_type -> plugins.Empty
+	4,   // 189: plugins.PanelService.ReinstallServer:output_type -> plugins.Empty
+	4,   // 190: plugins.PanelService.TransferServer:output_type -> plugins.Empty
+	37,  // 191: plugins.PanelService.GetConsoleLog:output_type -> plugins.ConsoleLogResponse
+	4,   // 192: plugins.PanelService.SendCommand:output_type -> plugins.Empty
+	44,  // 193: plugins.PanelService.StreamConsole:output_type -> plugins.ConsoleLine
+	45,  // 194: plugins.PanelService.GetFullLog:output_type -> plugins.FullLogResponse
+	47,  // 195: plugins.PanelService.SearchLogs:output_type -> plugins.SearchLogsResponse
+	49,  // 196: plugins.PanelService.ListLogFiles:output_type -> plugins.LogFilesResponse
+	45,  // 197: plugins.PanelService.ReadLogFile:output_type -> plugins.FullLogResponse
+	39,  // 198: plugins.PanelService.GetServerStats:output_type -> plugins.ServerStats
+	4,   // 199: plugins.PanelService.AddAllocation:output_type -> plugins.Empty
+	4,   // 200: plugins.PanelService.DeleteAllocation:output_type -> plugins.Empty
+	4,   // 201: plugins.PanelService.SetPrimaryAllocation:output_type -> plugins.Empty
+	4,   // 202: plugins.PanelService.UpdateServerVariables:output_type -> plugins.Empty
+	52,  // 203: plugins.PanelService.GetUser:output_type -> plugins.User
+	52,  // 204: plugins.PanelService.GetUserByEmail:output_type -> plugins.User
+	52,  // 205: plugins.PanelService.GetUserByUsername:output_type -> plugins.User
+	54,  // 206: plugins.PanelService.ListUsers:output_type -> plugins.ListUsersResponse
+	52,  // 207: plugins.PanelService.CreateUser:output_type -> plugins.User
+	4,   // 208: plugins.PanelService.DeleteUser:output_type -> plugins.Empty
+	52,  // 209: plugins.PanelService.UpdateUser:output_type -> plugins.User
+	4,   // 210: plugins.PanelService.BanUser:output_type -> plugins.Empty
+	4,   // 211: plugins.PanelService.UnbanUser:output_type -> plugins.Empty
+	4,   // 212: plugins.PanelService.SetAdmin:output_type -> plugins.Empty
+	4,   // 213: plugins.PanelService.RevokeAdmin:output_type -> plugins.Empty
+	4,   // 214: plugins.PanelService.SetUserResources:output_type -> plugins.Empty
+	4,   // 215: plugins.PanelService.ForcePasswordReset:output_type -> plugins.Empty
+	4,   // 216: plugins.PanelService.RequestPasswordReset:output_type -> plugins.Empty
+	4,   // 217: plugins.PanelService.SendVerificationEmail:output_type -> plugins.Empty
+	11,  // 218: plugins.PanelService.GetUser2FAStatus:output_type -> plugins.TwoFactorStatus
+	4,   // 219: plugins.PanelService.AdminDisable2FA:output_type -> plugins.Empty
+	59,  // 220: plugins.PanelService.ListSubusers:output_type -> plugins.ListSubusersResponse
+	58,  // 221: plugins.PanelService.AddSubuser:output_type -> plugins.Subuser
+	4,   // 222: plugins.PanelService.UpdateSubuser:output_type -> plugins.Empty
+	4,   // 223: plugins.PanelService.RemoveSubuser:output_type -> plugins.Empty
+	64,  // 224: plugins.PanelService.ListDatabases:output_type -> plugins.ListDatabasesResponse
+	63,  // 225: plugins.PanelService.CreateDatabase:output_type -> plugins.Database
+	4,   // 226: plugins.PanelService.DeleteDatabase:output_type -> plugins.Empty
+	63,  // 227: plugins.PanelService.RotateDatabasePassword:output_type -> plugins.Database
+	67,  // 228: plugins.PanelService.ListDatabaseHosts:output_type -> plugins.ListDatabaseHostsResponse
+	66,  // 229: plugins.PanelService.CreateDatabaseHost:output_type -> plugins.DatabaseHost
+	4,   // 230: plugins.PanelService.UpdateDatabaseHost:output_type -> plugins.Empty
+	4,   // 231: plugins.PanelService.DeleteDatabaseHost:output_type -> plugins.Empty
+	71,  // 232: plugins.PanelService.ListFiles:output_type -> plugins.ListFilesResponse
+	73,  // 233: plugins.PanelService.ReadFile:output_type -> plugins.FileContent
+	4,   // 234: plugins.PanelService.WriteFile:output_type -> plugins.Empty
+	4,   // 235: plugins.PanelService.DeleteFile:output_type -> plugins.Empty
+	4,   // 236: plugins.PanelService.CreateFolder:output_type -> plugins.Empty
+	4,   // 237: plugins.PanelService.MoveFile:output_type -> plugins.Empty
+	4,   // 238: plugins.PanelService.CopyFile:output_type -> plugins.Empty
+	4,   // 239: plugins.PanelService.CompressFiles:output_type -> plugins.Empty
+	4,   // 240: plugins.PanelService.DecompressFile:output_type -> plugins.Empty
+	78,  // 241: plugins.PanelService.SearchFileContents:output_type -> plugins.SearchFileContentsResponse
+	80,  // 242: plugins.PanelService.ListBackups:output_type -> plugins.ListBackupsResponse
+	4,   // 243: plugins.PanelService.CreateBackup:output_type -> plugins.Empty
+	4,   // 244: plugins.PanelService.DeleteBackup:output_type -> plugins.Empty
+	86,  // 245: plugins.PanelService.ListScheduleRuns:output_type -> plugins.ListScheduleRunsResponse
+	88,  // 246: plugins.PanelService.ListNodes:output_type -> plugins.ListNodesResponse
+	87,  // 247: plugins.PanelService.GetNode:output_type -> plugins.Node
+	90,  // 248: plugins.PanelService.CreateNode:output_type -> plugins.NodeWithToken
+	4,   // 249: plugins.PanelService.DeleteNode:output_type -> plugins.Empty
+	91,  // 250: plugins.PanelService.ResetNodeToken:output_type -> plugins.NodeToken
+	93,  // 251: plugins.PanelService.ListPackages:output_type -> plugins.ListPackagesResponse
+	92,  // 252: plugins.PanelService.GetPackage:output_type -> plugins.Package
+	92,  // 253: plugins.PanelService.CreatePackage:output_type -> plugins.Package
+	92,  // 254: plugins.PanelService.UpdatePackage:output_type -> plugins.Package
+	4,   // 255: plugins.PanelService.DeletePackage:output_type -> plugins.Empty
+	97,  // 256: plugins.PanelService.ListIPBans:output_type -> plugins.ListIPBansResponse
+	96,  // 257: plugins.PanelService.CreateIPBan:output_type -> plugins.IPBan
+	4,   // 258: plugins.PanelService.DeleteIPBan:output_type -> plugins.Empty
+	100, // 259: plugins.PanelService.ListMounts:output_type -> plugins.ListMountsResponse
+	99,  // 260: plugins.PanelService.GetMount:output_type -> plugins.Mount
+	99,  // 261: plugins.PanelService.CreateMount:output_type -> plugins.Mount
+	99,  // 262: plugins.PanelService.UpdateMount:output_type -> plugins.Mount
+	4,   // 263: plugins.PanelService.DeleteMount:output_type -> plugins.Empty
+	4,   // 264: plugins.PanelService.AddMountToServer:output_type -> plugins.Empty
+	4,   // 265: plugins.PanelService.RemoveMountFromServer:output_type -> plugins.Empty
+	105, // 266: plugins.PanelService.GetServerMounts:output_type -> plugins.ServerMountsResponse
+	4,   // 267: plugins.PanelService.MountServerMount:output_type -> plugins.Empty
+	4,   // 268: plugins.PanelService.UnmountServerMount:output_type -> plugins.Empty
+	106, // 269: plugins.PanelService.GetSettings:output_type -> plugins.Settings
+	4,   // 270: plugins.PanelService.SetRegistrationEnabled:output_type -> plugins.Empty
+	4,   // 271: plugins.PanelService.SetServerCreationEnabled:output_type -> plugins.Empty
+	109, // 272: plugins.PanelService.GetActivityLogs:output_type -> plugins.GetLogsResponse
+	4,   // 273: plugins.PanelService.Log:output_type -> plugins.Empty
+	112, // 274: plugins.PanelService.GetKV:output_type -> plugins.KVResponse
+	4,   // 275: plugins.PanelService.SetKV:output_type -> plugins.Empty
+	4,   // 276: plugins.PanelService.DeleteKV:output_type -> plugins.Empty
+	115, // 277: plugins.PanelService.QueryDB:output_type -> plugins.QueryDBResponse
+	4,   // 278: plugins.PanelService.BroadcastEvent:output_type -> plugins.Empty
+	4,   // 279: plugins.PanelService.SendNotification:output_type -> plugins.Empty
+	119, // 280: plugins.PanelService.HTTPRequest:output_type -> plugins.PluginHTTPResponse
+	121, // 281: plugins.PanelService.CallPlugin:output_type -> plugins.CallPluginResponse
+	4,   // 282: plugins.PanelService.SendEmail:output_type -> plugins.Empty
+	170, // [170:283] is the sub-list for method output_type
+	57,  // [57:170] is the sub-list for method input_type
+	57,  // [57:57] is the sub-list for extension type_name
+	57,  // [57:57] is the sub-list for extension extendee
+	0,   // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_plugin_proto_init() }
@@ -9247,7 +9863,7 @@ func file_plugin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_plugin_proto_rawDesc), len(file_plugin_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   128,
+			NumMessages:   135,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -415,9 +415,11 @@ const (
 	PanelService_CopyFile_FullMethodName                 = "/plugins.PanelService/CopyFile"
 	PanelService_CompressFiles_FullMethodName            = "/plugins.PanelService/CompressFiles"
 	PanelService_DecompressFile_FullMethodName           = "/plugins.PanelService/DecompressFile"
+	PanelService_SearchFileContents_FullMethodName       = "/plugins.PanelService/SearchFileContents"
 	PanelService_ListBackups_FullMethodName              = "/plugins.PanelService/ListBackups"
 	PanelService_CreateBackup_FullMethodName             = "/plugins.PanelService/CreateBackup"
 	PanelService_DeleteBackup_FullMethodName             = "/plugins.PanelService/DeleteBackup"
+	PanelService_ListScheduleRuns_FullMethodName         = "/plugins.PanelService/ListScheduleRuns"
 	PanelService_ListNodes_FullMethodName                = "/plugins.PanelService/ListNodes"
 	PanelService_GetNode_FullMethodName                  = "/plugins.PanelService/GetNode"
 	PanelService_CreateNode_FullMethodName               = "/plugins.PanelService/CreateNode"
@@ -535,10 +537,13 @@ type PanelServiceClient interface {
 	CopyFile(ctx context.Context, in *MoveFileRequest, opts ...grpc.CallOption) (*Empty, error)
 	CompressFiles(ctx context.Context, in *CompressRequest, opts ...grpc.CallOption) (*Empty, error)
 	DecompressFile(ctx context.Context, in *FilePathRequest, opts ...grpc.CallOption) (*Empty, error)
+	SearchFileContents(ctx context.Context, in *SearchFileContentsRequest, opts ...grpc.CallOption) (*SearchFileContentsResponse, error)
 	// Backups
 	ListBackups(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
 	CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Schedules
+	ListScheduleRuns(ctx context.Context, in *ListScheduleRunsRequest, opts ...grpc.CallOption) (*ListScheduleRunsResponse, error)
 	// Nodes
 	ListNodes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListNodesResponse, error)
 	GetNode(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Node, error)
@@ -1248,6 +1253,16 @@ func (c *panelServiceClient) DecompressFile(ctx context.Context, in *FilePathReq
 	return out, nil
 }
 
+func (c *panelServiceClient) SearchFileContents(ctx context.Context, in *SearchFileContentsRequest, opts ...grpc.CallOption) (*SearchFileContentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchFileContentsResponse)
+	err := c.cc.Invoke(ctx, PanelService_SearchFileContents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *panelServiceClient) ListBackups(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListBackupsResponse)
@@ -1278,6 +1293,16 @@ func (c *panelServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupR
 	return out, nil
 }
 
+func (c *panelServiceClient) ListScheduleRuns(ctx context.Context, in *ListScheduleRunsRequest, opts ...grpc.CallOption) (*ListScheduleRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduleRunsResponse)
+	err := c.cc.Invoke(ctx, PanelService_ListScheduleRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *panelServiceClient) ListNodes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListNodesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListNodesResponse)
@@ -1726,10 +1751,13 @@ type PanelServiceServer interface {
 	CopyFile(context.Context, *MoveFileRequest) (*Empty, error)
 	CompressFiles(context.Context, *CompressRequest) (*Empty, error)
 	DecompressFile(context.Context, *FilePathRequest) (*Empty, error)
+	SearchFileContents(context.Context, *SearchFileContentsRequest) (*SearchFileContentsResponse, error)
 	// Backups
 	ListBackups(context.Context, *IDRequest) (*ListBackupsResponse, error)
 	CreateBackup(context.Context, *CreateBackupRequest) (*Empty, error)
 	DeleteBackup(context.Context, *DeleteBackupRequest) (*Empty, error)
+	// Schedules
+	ListScheduleRuns(context.Context, *ListScheduleRunsRequest) (*ListScheduleRunsResponse, error)
 	// Nodes
 	ListNodes(context.Context, *Empty) (*ListNodesResponse, error)
 	GetNode(context.Context, *IDRequest) (*Node, error)
@@ -1979,6 +2007,9 @@ func (UnimplementedPanelServiceServer) CompressFiles(context.Context, *CompressR
 func (UnimplementedPanelServiceServer) DecompressFile(context.Context, *FilePathRequest) (*Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DecompressFile not implemented")
 }
+func (UnimplementedPanelServiceServer) SearchFileContents(context.Context, *SearchFileContentsRequest) (*SearchFileContentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchFileContents not implemented")
+}
 func (UnimplementedPanelServiceServer) ListBackups(context.Context, *IDRequest) (*ListBackupsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListBackups not implemented")
 }
@@ -1988,6 +2019,9 @@ func (UnimplementedPanelServiceServer) CreateBackup(context.Context, *CreateBack
 func (UnimplementedPanelServiceServer) DeleteBackup(context.Context, *DeleteBackupRequest) (*Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteBackup not implemented")
 }
+func (UnimplementedPanelServiceServer) ListScheduleRuns(context.Context, *ListScheduleRunsRequest) (*ListScheduleRunsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScheduleRuns not implemented")
+}
 func (UnimplementedPanelServiceServer) ListNodes(context.Context, *Empty) (*ListNodesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListNodes not implemented")
 }
@@ -3254,6 +3288,24 @@ func _PanelService_DecompressFile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PanelService_SearchFileContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFileContentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PanelServiceServer).SearchFileContents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PanelService_SearchFileContents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PanelServiceServer).SearchFileContents(ctx, req.(*SearchFileContentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PanelService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IDRequest)
 	if err := dec(in); err != nil {
@@ -3308,6 +3360,24 @@ func _PanelService_DeleteBackup_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PanelService_ListScheduleRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduleRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PanelServiceServer).ListScheduleRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PanelService_ListScheduleRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PanelServiceServer).ListScheduleRuns(ctx, req.(*ListScheduleRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PanelService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -4229,6 +4299,10 @@ var PanelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DecompressFile",
 			Handler:    _PanelService_DecompressFile_Handler,
 		},
+		{
+			MethodName: "SearchFileContents",
+			Handler:    _PanelService_SearchFileContents_Handler,
+		},
 		{
 			MethodName: "ListBackups",
 			Handler:    _PanelService_ListBackups_Handler,
@@ -4241,6 +4315,10 @@ var PanelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBackup",
 			Handler:    _PanelService_DeleteBackup_Handler,
 		},
+		{
+			MethodName: "ListScheduleRuns",
+			Handler:    _PanelService_ListScheduleRuns_Handler,
+		},
 		{
 			MethodName: "ListNodes",
 			Handler:    _PanelService_ListNodes_Handler,
@@ -57,6 +57,7 @@ const (
 	MixinNodeDelete MixinTarget = "node.delete"
 	MixinNodeList   MixinTarget = "node.list"
 	MixinNodeGet    MixinTarget = "node.get"
+	MixinNodePlace  MixinTarget = "node.place"
 
 	MixinPackageCreate MixinTarget = "package.create"
 	MixinPackageUpdate MixinTarget = "package.update"
@@ -109,6 +110,8 @@ const (
 	EventServerUnsuspended EventType = "server.unsuspended"
 	EventServerUpdated    EventType = "server.updated"
 	EventServerTransferred EventType = "server.transferred"
+	EventServerCrashed    EventType = "server.crashed"
+	EventServerOOM        EventType = "server.oom"
 
 	EventUserRegistering EventType = "user.registering"
 	EventUserRegistered  EventType = "user.registered"
@@ -158,6 +161,8 @@ const (
 
 	EventSettingsUpdated EventType = "settings.updated"
 
+	EventScheduleEvent EventType = "schedule.event"
+
 	EventSystemStartup  EventType = "system.startup"
 	EventSystemShutdown EventType = "system.shutdown"
 
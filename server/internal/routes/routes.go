@@ -16,6 +16,8 @@ func SetupRoutes(app *fiber.App) {
 	plugins.RegisterUIRoutes(app)
 	plugins.RegisterPluginRoutes(app)
 
+	app.Get("/metrics", middleware.RequireMetricsToken(), handlers.Metrics)
+
 	app.Static("/", "./public")
 
 	api := app.Group("/api/v1", middleware.RequireEmailVerification())
@@ -32,6 +34,10 @@ func SetupRoutes(app *fiber.App) {
 		RequestsPerMinute: 10,
 		BurstLimit:        15,
 	})
+	uploadLimit := middleware.ThousandTHR(middleware.ThousandTHRConfig{
+		RequestsPerMinute: 600,
+		BurstLimit:        100,
+	})
 
 	api.Get("/health", middleware.ThousandTHR(middleware.ThousandTHRConfig{
 		RequestsPerMinute: 120,
@@ -64,6 +70,9 @@ func SetupRoutes(app *fiber.App) {
 	authRoutes.Get("/api-keys", middleware.RequireAuth(), readLimit, auth.GetAPIKeys)
 	authRoutes.Post("/api-keys", middleware.RequireAuth(), writeLimit, auth.CreateAPIKey)
 	authRoutes.Delete("/api-keys/:id", middleware.RequireAuth(), writeLimit, auth.DeleteAPIKey)
+	authRoutes.Get("/ssh-keys", middleware.RequireAuth(), readLimit, auth.GetSSHKeys)
+	authRoutes.Post("/ssh-keys", middleware.RequireAuth(), writeLimit, auth.CreateSSHKey)
+	authRoutes.Delete("/ssh-keys/:id", middleware.RequireAuth(), writeLimit, auth.DeleteSSHKey)
 	authRoutes.Post("/2fa/setup", middleware.RequireAuth(), strictLimit, auth.TwoFactorSetup)
 	authRoutes.Post("/2fa/enable", middleware.RequireAuth(), strictLimit, auth.TwoFactorEnable)
 	authRoutes.Post("/2fa/disable", middleware.RequireAuth(), strictLimit, auth.TwoFactorDisable)
@@ -108,9 +117,14 @@ func SetupRoutes(app *fiber.App) {
 	adminRoutes.Get("/nodes/pairing-code", readLimit, handlers.AdminGeneratePairingCode)
 	adminRoutes.Post("/nodes/refresh", writeLimit, handlers.AdminRefreshNodes)
 	adminRoutes.Get("/nodes/:id", readLimit, handlers.AdminGetNode)
+	adminRoutes.Get("/nodes/:id/metrics", readLimit, handlers.AdminGetNodeMetrics)
 	adminRoutes.Patch("/nodes/:id", writeLimit, handlers.AdminUpdateNode)
 	adminRoutes.Delete("/nodes/:id", strictLimit, handlers.AdminDeleteNode)
 	adminRoutes.Post("/nodes/:id/reset-token", strictLimit, handlers.AdminResetNodeToken)
+	adminRoutes.Get("/nodes/:id/allocations", readLimit, handlers.AdminGetNodeAllocations)
+	adminRoutes.Post("/nodes/:id/allocations", strictLimit, handlers.AdminCreateNodeAllocations)
+	adminRoutes.Patch("/nodes/:id/allocations/:allocationId", writeLimit, handlers.AdminUpdateNodeAllocation)
+	adminRoutes.Delete("/nodes/:id/allocations/:allocationId", strictLimit, handlers.AdminDeleteNodeAllocation)
 
 	adminRoutes.Get("/packages", readLimit, handlers.AdminGetPackages)
 	adminRoutes.Post("/packages", strictLimit, handlers.AdminCreatePackage)
@@ -158,6 +172,16 @@ func SetupRoutes(app *fiber.App) {
 	adminRoutes.Get("/database-hosts/:id/databases", readLimit, admin.AdminGetHostDatabases)
 	adminRoutes.Delete("/database-hosts/:id/databases/:dbId", strictLimit, admin.AdminDeleteDatabase)
 
+	adminRoutes.Get("/backup-targets", readLimit, admin.AdminGetBackupTargets)
+	adminRoutes.Post("/backup-targets", strictLimit, admin.AdminCreateBackupTarget)
+	adminRoutes.Patch("/backup-targets/:id", writeLimit, admin.AdminUpdateBackupTarget)
+	adminRoutes.Delete("/backup-targets/:id", strictLimit, admin.AdminDeleteBackupTarget)
+
+	adminRoutes.Get("/locations", readLimit, admin.AdminGetLocations)
+	adminRoutes.Post("/locations", strictLimit, admin.AdminCreateLocation)
+	adminRoutes.Patch("/locations/:id", writeLimit, admin.AdminUpdateLocation)
+	adminRoutes.Delete("/locations/:id", strictLimit, admin.AdminDeleteLocation)
+
 	adminRoutes.Get("/plugins", readLimit, admin.AdminListPlugins)
 	adminRoutes.Get("/plugins/config", readLimit, admin.AdminGetPluginConfig)
 	adminRoutes.Get("/plugins/files", readLimit, admin.AdminListPluginFiles)
@@ -171,6 +195,7 @@ func SetupRoutes(app *fiber.App) {
 
 	api.Get("/packages", middleware.RequireAuth(), readLimit, handlers.GetAvailablePackages)
 	api.Get("/nodes", middleware.RequireAuth(), readLimit, handlers.GetAvailableNodes)
+	api.Get("/locations", middleware.RequireAuth(), readLimit, handlers.GetAvailableLocations)
 
 	api.Use("/servers/:id/logs", func(c *fiber.Ctx) error {
 		if websocket.IsWebSocketUpgrade(c) {
@@ -191,7 +216,10 @@ func SetupRoutes(app *fiber.App) {
 	servers.Post("/:id/kill", writeLimit, server.KillServer)
 	servers.Post("/:id/command", writeLimit, server.SendCommand)
 	servers.Get("/:id/status", readLimit, server.GetServerStatus)
+	servers.Get("/:id/metrics", readLimit, server.GetServerMetrics)
 	servers.Get("/:id/console", readLimit, server.GetConsoleLogs)
+	servers.Get("/:id/crashes", readLimit, server.GetServerCrashes)
+	servers.Put("/:id/crash-policy", writeLimit, server.UpdateServerCrashPolicy)
 	servers.Delete("/:id", strictLimit, server.DeleteServer)
 	servers.Post("/:id/allocations", strictLimit, server.AddAllocation)
 	servers.Put("/:id/allocations/primary", writeLimit, server.SetPrimaryAllocation)
@@ -203,13 +231,21 @@ func SetupRoutes(app *fiber.App) {
 	servers.Post("/:id/backups", writeLimit, server.CreateBackup)
 	servers.Delete("/:id/backups/:backupId", strictLimit, server.DeleteBackup)
 	servers.Get("/:id/backups/:backupId/download", readLimit, server.DownloadBackup)
+	servers.Get("/:id/backups/:backupId/files", readLimit, server.ListBackupFiles)
 	servers.Post("/:id/backups/:backupId/restore", strictLimit, server.RestoreBackup)
+	servers.Post("/:id/backups/:backupId/lock", writeLimit, server.LockBackup)
+	servers.Post("/:id/backups/:backupId/unlock", writeLimit, server.UnlockBackup)
 	servers.Get("/:id/files", readLimit, server.ListFiles)
 	servers.Get("/:id/files/read", readLimit, server.ReadFile)
 	servers.Get("/:id/files/search", readLimit, server.SearchFiles)
 	servers.Post("/:id/files/folder", writeLimit, server.CreateFolder)
 	servers.Post("/:id/files/write", writeLimit, server.WriteFile)
 	servers.Post("/:id/files/upload", writeLimit, server.UploadFile)
+	servers.Post("/:id/files/uploads", writeLimit, server.CreateUpload)
+	servers.Get("/:id/files/uploads/:uploadId", readLimit, server.GetUpload)
+	servers.Patch("/:id/files/uploads/:uploadId", uploadLimit, server.PatchUpload)
+	servers.Post("/:id/files/uploads/:uploadId/complete", writeLimit, server.CompleteUpload)
+	servers.Delete("/:id/files/uploads/:uploadId", writeLimit, server.CancelUpload)
 	servers.Delete("/:id/files", writeLimit, server.DeleteFile)
 	servers.Post("/:id/files/move", writeLimit, server.MoveFile)
 	servers.Post("/:id/files/copy", writeLimit, server.CopyFile)
@@ -244,9 +280,9 @@ func SetupRoutes(app *fiber.App) {
 	servers.Put("/:id/schedules/:scheduleId", writeLimit, handlers.UpdateSchedule)
 	servers.Delete("/:id/schedules/:scheduleId", writeLimit, handlers.DeleteSchedule)
 	servers.Post("/:id/schedules/:scheduleId/run", writeLimit, handlers.RunScheduleNow)
+	servers.Get("/:id/schedules/:scheduleId/runs", readLimit, handlers.GetScheduleRuns)
 	servers.Get("/:id/activity", readLimit, server.GetServerActivity)
 	servers.Get("/:id/sftp", readLimit, server.GetSFTPDetails)
-	servers.Post("/:id/sftp/password", writeLimit, server.ResetSFTPPassword)
 	servers.Get("/:id/mounts", readLimit, server.GetServerMounts)
 	servers.Post("/:id/mounts/:mountId/mount", writeLimit, server.MountServerMount)
 	servers.Post("/:id/mounts/:mountId/unmount", writeLimit, server.UnmountServerMount)
@@ -254,8 +290,18 @@ func SetupRoutes(app *fiber.App) {
 	internal := api.Group("/internal")
 	nodes := internal.Group("/nodes", middleware.RequireNodeAuth())
 	nodes.Post("/heartbeat", handlers.NodeHeartbeat)
+	nodes.Post("/backups", handlers.NodeBackupReport)
+	nodes.Post("/servers/state", handlers.NodeServerState)
+	nodes.Post("/sftp/activity", handlers.NodeSFTPActivity)
+	nodes.Use("/tunnel", func(c *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+		return fiber.ErrUpgradeRequired
+	})
+	nodes.Get("/tunnel", websocket.New(handlers.NodeTunnel))
 
-	internal.Post("/sftp/auth", middleware.RequireNodeAuth(), handlers.ValidateSFTPAuth)
+	internal.Post("/sftp/auth", middleware.RequireNodeAuth(), middleware.SFTPAuthLimit(), auth.ValidateSFTPAuth)
 
 	app.Get("*", func(c *fiber.Ctx) error {
 		return c.SendFile("./public/index.html")
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAllocationNotFound      = errors.New("allocation not found")
+	ErrAllocationInUse         = errors.New("allocation is assigned to a server")
+	ErrInvalidAllocationIP     = errors.New("invalid allocation IP address")
+	ErrInvalidAllocationPorts  = errors.New("ports must be numbers or ranges between 1 and 65535")
+	ErrAllocationRangeTooLarge = errors.New("at most 1000 ports can be added at once")
+)
+
+const maxAllocationBatch = 1000
+
+func parseAllocationPorts(specs []string) ([]int, error) {
+	seen := make(map[int]bool)
+	var ports []int
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		start, end, isRange := strings.Cut(spec, "-")
+		from, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil {
+			return nil, ErrInvalidAllocationPorts
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(strings.TrimSpace(end)); err != nil {
+				return nil, ErrInvalidAllocationPorts
+			}
+		}
+		if from < 1 || to > 65535 || from > to {
+			return nil, ErrInvalidAllocationPorts
+		}
+		if len(ports)+to-from+1 > maxAllocationBatch {
+			return nil, ErrAllocationRangeTooLarge
+		}
+		for port := from; port <= to; port++ {
+			if !seen[port] {
+				seen[port] = true
+				ports = append(ports, port)
+			}
+		}
+	}
+	if len(ports) == 0 {
+		return nil, ErrInvalidAllocationPorts
+	}
+	return ports, nil
+}
+
+// CreateAllocations adds ports on an IP to a node's pool. Each spec is a
+// single port or a range such as 25565-25600; ports already in the pool are
+// skipped. Ports a server on the node already binds on the same IP are added
+// as assigned to that server.
+func CreateAllocations(nodeID uuid.UUID, ip, alias string, specs []string) ([]models.Allocation, error) {
+	var node models.Node
+	if err := database.DB.Select("id").Where("id = ?", nodeID).First(&node).Error; err != nil {
+		return nil, ErrNodeNotFound
+	}
+
+	ip = strings.TrimSpace(ip)
+	if ip == "" {
+		ip = "0.0.0.0"
+	}
+	if net.ParseIP(ip) == nil {
+		return nil, ErrInvalidAllocationIP
+	}
+
+	ports, err := parseAllocationPorts(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []int
+	database.DB.Model(&models.Allocation{}).Where("node_id = ? AND ip = ? AND port IN ?", nodeID, ip, ports).Pluck("port", &existing)
+	skip := make(map[int]bool, len(existing))
+	for _, port := range existing {
+		skip[port] = true
+	}
+
+	bindings := nodePortBindings(nodeID)
+	allocations := make([]models.Allocation, 0, len(ports))
+	for _, port := range ports {
+		if skip[port] {
+			continue
+		}
+		allocation := models.Allocation{NodeID: nodeID, IP: ip, Port: port, Alias: strings.TrimSpace(alias)}
+		for _, b := range bindings[port] {
+			if bindIP(b.ip) == ip {
+				serverID := b.serverID
+				allocation.ServerID = &serverID
+				break
+			}
+		}
+		allocations = append(allocations, allocation)
+	}
+	if len(allocations) == 0 {
+		return allocations, nil
+	}
+	if err := database.DB.CreateInBatches(&allocations, 200).Error; err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+func GetNodeAllocations(nodeID uuid.UUID) ([]models.Allocation, error) {
+	var allocations []models.Allocation
+	err := database.DB.Where("node_id = ?", nodeID).Order("ip, port").Find(&allocations).Error
+	return allocations, err
+}
+
+func UpdateAllocationAlias(nodeID, id uuid.UUID, alias string) (*models.Allocation, error) {
+	var allocation models.Allocation
+	if err := database.DB.Where("id = ? AND node_id = ?", id, nodeID).First(&allocation).Error; err != nil {
+		return nil, ErrAllocationNotFound
+	}
+	allocation.Alias = strings.TrimSpace(alias)
+	if err := database.DB.Model(&allocation).Update("alias", allocation.Alias).Error; err != nil {
+		return nil, err
+	}
+
+	if allocation.ServerID != nil {
+		var server models.Server
+		if err := database.DB.Where("id = ?", *allocation.ServerID).First(&server).Error; err == nil {
+			var ports []models.ServerPort
+			json.Unmarshal(server.Ports, &ports)
+			for i := range ports {
+				if ports[i].IP == allocation.IP && ports[i].Port == allocation.Port {
+					ports[i].Alias = allocation.Alias
+				}
+			}
+			portsJSON, _ := json.Marshal(ports)
+			database.DB.Model(&server).Update("ports", portsJSON)
+		}
+	}
+
+	return &allocation, nil
+}
+
+func DeleteNodeAllocation(nodeID, id uuid.UUID) error {
+	var allocation models.Allocation
+	if err := database.DB.Where("id = ? AND node_id = ?", id, nodeID).First(&allocation).Error; err != nil {
+		return ErrAllocationNotFound
+	}
+	if allocation.ServerID != nil {
+		return ErrAllocationInUse
+	}
+	return database.DB.Delete(&allocation).Error
+}
+
+func nodeHasAllocationPool(nodeID uuid.UUID) bool {
+	var count int64
+	database.DB.Model(&models.Allocation{}).Where("node_id = ?", nodeID).Count(&count)
+	return count > 0
+}
+
+type portBinding struct {
+	serverID uuid.UUID
+	ip       string
+}
+
+func bindIP(ip string) string {
+	if ip == "" {
+		return "0.0.0.0"
+	}
+	return ip
+}
+
+// nodePortBindings maps each host port the node's servers use to the
+// servers binding it. This includes servers created before the node had an
+// allocation pool.
+func nodePortBindings(nodeID uuid.UUID) map[int][]portBinding {
+	var servers []models.Server
+	database.DB.Select("id", "ports").Where("node_id = ?", nodeID).Find(&servers)
+
+	bindings := make(map[int][]portBinding)
+	for _, s := range servers {
+		var ports []models.ServerPort
+		json.Unmarshal(s.Ports, &ports)
+		for _, p := range ports {
+			bindings[p.Port] = append(bindings[p.Port], portBinding{serverID: s.ID, ip: bindIP(p.IP)})
+		}
+	}
+	return bindings
+}
+
+// freeAllocations returns unassigned allocations that no server on the node
+// already binds. A binding on 0.0.0.0 blocks the port on every IP.
+func freeAllocations(nodeID uuid.UUID) ([]models.Allocation, error) {
+	var candidates []models.Allocation
+	if err := database.DB.Where("node_id = ? AND server_id IS NULL", nodeID).Order("ip, port").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	bindings := nodePortBindings(nodeID)
+	free := candidates[:0]
+	for _, a := range candidates {
+		taken := false
+		for _, b := range bindings[a.Port] {
+			if b.ip == "0.0.0.0" || a.IP == "0.0.0.0" || b.ip == a.IP {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			free = append(free, a)
+		}
+	}
+	return free, nil
+}
+
+func freeNodeAllocations(nodeID uuid.UUID) int {
+	free, _ := freeAllocations(nodeID)
+	return len(free)
+}
+
+// reserveAllocations assigns free allocations from the node's pool to a
+// server. Callers hold portAllocationMu.
+func reserveAllocations(nodeID, serverID uuid.UUID, count int) ([]models.Allocation, error) {
+	allocations, err := freeAllocations(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocations) < count {
+		return nil, ErrNoFreePorts
+	}
+	allocations = allocations[:count]
+
+	ids := make([]uuid.UUID, len(allocations))
+	for i := range allocations {
+		ids[i] = allocations[i].ID
+		allocations[i].ServerID = &serverID
+	}
+	result := database.DB.Model(&models.Allocation{}).Where("id IN ? AND server_id IS NULL", ids).Update("server_id", serverID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if int(result.RowsAffected) != len(ids) {
+		database.DB.Model(&models.Allocation{}).Where("id IN ? AND server_id = ?", ids, serverID).Update("server_id", nil)
+		return nil, ErrNoFreePorts
+	}
+	return allocations, nil
+}
+
+func releaseAllocation(serverID uuid.UUID, ip string, port int) {
+	query := database.DB.Model(&models.Allocation{}).Where("server_id = ? AND port = ?", serverID, port)
+	if ip != "" {
+		query = query.Where("ip = ?", ip)
+	}
+	query.Update("server_id", nil)
+}
+
+func releaseServerAllocations(serverID uuid.UUID) {
+	database.DB.Model(&models.Allocation{}).Where("server_id = ?", serverID).Update("server_id", nil)
+}
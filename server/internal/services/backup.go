@@ -0,0 +1,325 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"birdactyl-panel-backend/internal/config"
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBackupNotFound     = errors.New("backup not found")
+	ErrBackupLocked       = errors.New("backup is locked")
+	ErrBackupNotCompleted = errors.New("backup has not completed")
+	ErrBackupLimitReached = errors.New("backup limit reached for this server")
+	ErrBackupQuotaReached = errors.New("backup quota reached")
+)
+
+const (
+	backupReportGrace    = time.Minute
+	maxBackupHookTimeout = 600
+)
+
+type BackupReport struct {
+	ServerID string   `json:"server_id"`
+	BackupID string   `json:"backup_id"`
+	Status   string   `json:"status"`
+	Size     int64    `json:"size"`
+	Checksum string   `json:"checksum"`
+	Storage  string   `json:"storage"`
+	Error    string   `json:"error"`
+	Warnings []string `json:"warnings"`
+}
+
+type BackupUsage struct {
+	Backups int
+	Size    int64
+}
+
+func GetUserBackupUsage(userID uuid.UUID) BackupUsage {
+	var usage struct {
+		Backups int
+		Size    int64
+	}
+	database.DB.Model(&models.Backup{}).
+		Select("COUNT(*) as backups, COALESCE(SUM(size), 0) as size").
+		Where("status != ? AND server_id IN (?)", models.BackupFailed,
+			database.DB.Model(&models.Server{}).Select("id").Where("user_id = ?", userID)).
+		Scan(&usage)
+	return BackupUsage{Backups: usage.Backups, Size: usage.Size}
+}
+
+func IsBackupLimitError(err error) bool {
+	return errors.Is(err, ErrBackupLimitReached) || errors.Is(err, ErrBackupQuotaReached)
+}
+
+func CheckBackupLimits(server *models.Server) error {
+	return checkBackupLimits(server, 0)
+}
+
+// checkBackupLimits reports whether the server has room for another backup.
+// reserved is the number of pending backups already counted that are the
+// ones being checked for.
+func checkBackupLimits(server *models.Server, reserved int) error {
+	if server.BackupLimit != nil {
+		var count int64
+		database.DB.Model(&models.Backup{}).Where("server_id = ? AND status != ?", server.ID, models.BackupFailed).Count(&count)
+		if count-int64(reserved) >= int64(*server.BackupLimit) {
+			return ErrBackupLimitReached
+		}
+	}
+
+	cfg := config.Get()
+	if !cfg.Resources.Enabled {
+		return nil
+	}
+	var owner models.User
+	if err := database.DB.Where("id = ?", server.UserID).First(&owner).Error; err != nil || owner.IsAdmin {
+		return nil
+	}
+
+	backupLimit := cfg.Resources.MaxBackups
+	storageLimit := cfg.Resources.BackupStorage
+	if owner.BackupLimit != nil {
+		backupLimit = *owner.BackupLimit
+	}
+	if owner.BackupStorageLimit != nil {
+		storageLimit = *owner.BackupStorageLimit
+	}
+
+	used := GetUserBackupUsage(owner.ID)
+	if backupLimit > 0 && used.Backups-reserved >= backupLimit {
+		return fmt.Errorf("%w: maximum of %d backups", ErrBackupQuotaReached, backupLimit)
+	}
+	if storageLimit > 0 && used.Size >= int64(storageLimit)*1024*1024 {
+		return fmt.Errorf("%w: backup storage limit of %d MB used", ErrBackupQuotaReached, storageLimit)
+	}
+	return nil
+}
+
+func backupIgnoreRules(server *models.Server, extra []string) string {
+	var rules []string
+	var pkg models.Package
+	if err := database.DB.Select("backup_ignore").Where("id = ?", server.PackageID).First(&pkg).Error; err == nil && strings.TrimSpace(pkg.BackupIgnore) != "" {
+		rules = append(rules, pkg.BackupIgnore)
+	}
+	for _, rule := range extra {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return strings.Join(rules, "\n")
+}
+
+func ValidateBackupHooks(hooks *models.PackageBackupHooks) error {
+	stages := []struct {
+		name  string
+		hooks []models.PackageBackupHook
+	}{{"pre", hooks.Pre}, {"post", hooks.Post}}
+	for _, stage := range stages {
+		for i, hook := range stage.hooks {
+			if strings.TrimSpace(hook.Command) == "" {
+				return fmt.Errorf("%s backup hook %d has no command", stage.name, i+1)
+			}
+			if hook.WaitFor != "" {
+				if _, err := regexp.Compile(hook.WaitFor); err != nil {
+					return fmt.Errorf("%s backup hook %d has an invalid wait_for pattern: %v", stage.name, i+1, err)
+				}
+			}
+			if hook.Timeout < 0 || hook.Timeout > maxBackupHookTimeout {
+				return fmt.Errorf("%s backup hook %d timeout must be between 0 and %d seconds", stage.name, i+1, maxBackupHookTimeout)
+			}
+		}
+	}
+	return nil
+}
+
+func backupHooks(server *models.Server) *models.PackageBackupHooks {
+	var pkg models.Package
+	if err := database.DB.Select("backup_hooks").Where("id = ?", server.PackageID).First(&pkg).Error; err != nil {
+		return nil
+	}
+	var hooks models.PackageBackupHooks
+	if err := json.Unmarshal(pkg.BackupHooks, &hooks); err != nil || (len(hooks.Pre) == 0 && len(hooks.Post) == 0) {
+		return nil
+	}
+	return &hooks
+}
+
+func CreateServerBackup(server *models.Server, name string, ignore []string, locked bool, createdBy *uuid.UUID) (*models.Backup, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("Backup at %s", time.Now().Format("2006-01-02 15:04:05"))
+	}
+
+	backup := &models.Backup{
+		ServerID:  server.ID,
+		Name:      name,
+		Locked:    locked,
+		Status:    models.BackupPending,
+		CreatedBy: createdBy,
+	}
+	if err := database.DB.Create(backup).Error; err != nil {
+		return nil, err
+	}
+	// Concurrent requests can all pass CheckBackupLimits before any of them
+	// inserts its row, so check again with this backup counted.
+	if err := checkBackupLimits(server, 1); err != nil {
+		database.DB.Delete(backup)
+		return nil, err
+	}
+
+	if err := CreateNodeBackup(server.ID, backup.NodeBackupID, name, backupIgnoreRules(server, ignore), backupHooks(server)); err != nil {
+		database.DB.Model(backup).Updates(map[string]interface{}{"status": models.BackupFailed, "error": err.Error()})
+		return nil, err
+	}
+	return backup, nil
+}
+
+func ListServerBackups(serverID uuid.UUID) ([]models.Backup, error) {
+	nodeBackups, err := ListNodeBackups(serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []models.Backup
+	database.DB.Where("server_id = ?", serverID).Find(&backups)
+
+	known := make(map[string]*models.Backup, len(backups))
+	for i := range backups {
+		known[backups[i].NodeBackupID] = &backups[i]
+	}
+	onNode := make(map[string]bool, len(nodeBackups))
+
+	for _, nb := range nodeBackups {
+		onNode[nb.ID] = true
+		b, ok := known[nb.ID]
+		if !ok {
+			adopted := models.Backup{
+				ServerID:     serverID,
+				NodeBackupID: nb.ID,
+				Name:         nb.Name,
+				Size:         nb.Size,
+				Checksum:     nb.Checksum,
+				Storage:      nb.Storage,
+				Status:       models.BackupPending,
+				CreatedAt:    time.Unix(nb.CreatedAt, 0),
+			}
+			if nb.Completed {
+				now := time.Now()
+				adopted.Status = models.BackupCompleted
+				adopted.CompletedAt = &now
+			}
+			database.DB.Create(&adopted)
+			continue
+		}
+		if b.Status == models.BackupPending && nb.Completed {
+			completeBackup(b, nb.Size, nb.Checksum, nb.Storage)
+		}
+	}
+
+	for _, b := range known {
+		if b.Status == models.BackupPending && !onNode[b.NodeBackupID] && time.Since(b.CreatedAt) > backupReportGrace {
+			database.DB.Model(b).Updates(map[string]interface{}{"status": models.BackupFailed, "error": "backup is missing on the node"})
+		}
+	}
+
+	backups = nil
+	err = database.DB.Where("server_id = ?", serverID).Order("created_at DESC").Find(&backups).Error
+	return backups, err
+}
+
+func completeBackup(b *models.Backup, size int64, checksum, storage string) {
+	now := time.Now()
+	database.DB.Model(b).Updates(map[string]interface{}{
+		"status":       models.BackupCompleted,
+		"size":         size,
+		"checksum":     checksum,
+		"storage":      storage,
+		"completed_at": &now,
+		"error":        "",
+	})
+}
+
+func GetServerBackup(serverID uuid.UUID, backupID string) (*models.Backup, error) {
+	var backup models.Backup
+	query := database.DB.Where("server_id = ? AND node_backup_id = ?", serverID, backupID)
+	if id, err := uuid.Parse(backupID); err == nil {
+		query = database.DB.Where("server_id = ? AND (id = ? OR node_backup_id = ?)", serverID, id, backupID)
+	}
+	if err := query.First(&backup).Error; err != nil {
+		return nil, ErrBackupNotFound
+	}
+	return &backup, nil
+}
+
+func DeleteServerBackup(backup *models.Backup) error {
+	if backup.Locked {
+		return ErrBackupLocked
+	}
+	if backup.Status != models.BackupFailed {
+		if err := DeleteNodeBackup(backup.ServerID, backup.NodeBackupID); err != nil && !strings.Contains(err.Error(), "not found") {
+			return err
+		}
+	}
+	return database.DB.Delete(backup).Error
+}
+
+func ValidateRestorePaths(paths []string) ([]string, error) {
+	cleaned := make([]string, 0, len(paths))
+	for _, p := range paths {
+		c := strings.TrimPrefix(path.Clean("/"+strings.TrimSpace(p)), "/")
+		if c == "" {
+			return nil, fmt.Errorf("paths must not include the server root")
+		}
+		cleaned = append(cleaned, c)
+	}
+	return cleaned, nil
+}
+
+func SetBackupLocked(backup *models.Backup, locked bool) error {
+	backup.Locked = locked
+	return database.DB.Model(backup).Update("locked", locked).Error
+}
+
+func HandleBackupReport(nodeID uuid.UUID, report BackupReport) error {
+	serverID, err := uuid.Parse(report.ServerID)
+	if err != nil {
+		return ErrServerNotFound
+	}
+	var server models.Server
+	if err := database.DB.Where("id = ? AND node_id = ?", serverID, nodeID).First(&server).Error; err != nil {
+		return ErrServerNotFound
+	}
+
+	backup, err := GetServerBackup(server.ID, report.BackupID)
+	if err != nil {
+		return err
+	}
+
+	switch report.Status {
+	case string(models.BackupCompleted):
+		completeBackup(backup, report.Size, report.Checksum, report.Storage)
+	case string(models.BackupFailed):
+		msg := report.Error
+		if msg == "" {
+			msg = "backup failed on the node"
+		}
+		database.DB.Model(backup).Updates(map[string]interface{}{"status": models.BackupFailed, "error": msg})
+	default:
+		return fmt.Errorf("unknown backup status %q", report.Status)
+	}
+	if len(report.Warnings) > 0 {
+		database.DB.Model(backup).Update("warnings", strings.Join(report.Warnings, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBackupTargetNotFound = errors.New("backup target not found")
+	ErrBackupTargetInUse    = errors.New("backup target is assigned to nodes or packages")
+)
+
+type NodeBackupStorage struct {
+	Type string                 `json:"type"`
+	S3   *NodeBackupStorageS3   `json:"s3,omitempty"`
+	SFTP *NodeBackupStorageSFTP `json:"sftp,omitempty"`
+}
+
+type NodeBackupStorageS3 struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	PathStyle bool   `json:"path_style"`
+}
+
+type NodeBackupStorageSFTP struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	PrivateKey string `json:"private_key"`
+	HostKey    string `json:"host_key"`
+	Path       string `json:"path"`
+}
+
+func ValidateBackupTarget(t *models.BackupTarget) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch t.Type {
+	case models.BackupTargetS3:
+		u, err := url.Parse(t.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("endpoint must be an http or https URL")
+		}
+		if t.Bucket == "" {
+			return fmt.Errorf("bucket is required")
+		}
+		if t.AccessKey == "" || t.SecretKey == "" {
+			return fmt.Errorf("access_key and secret_key are required")
+		}
+	case models.BackupTargetSFTP:
+		if t.Host == "" || t.Username == "" {
+			return fmt.Errorf("host and username are required")
+		}
+		if t.Port < 0 || t.Port > 65535 {
+			return fmt.Errorf("port must be between 1 and 65535")
+		}
+		if t.Password == "" && t.PrivateKey == "" {
+			return fmt.Errorf("password or private_key is required")
+		}
+		if t.HostKey == "" {
+			return fmt.Errorf("host_key is required")
+		}
+		if !path.IsAbs(t.Path) {
+			return fmt.Errorf("path must be an absolute path")
+		}
+	default:
+		return fmt.Errorf("type must be s3 or sftp")
+	}
+	return nil
+}
+
+func GetBackupTarget(id uuid.UUID) (*models.BackupTarget, error) {
+	var target models.BackupTarget
+	if err := database.DB.Where("id = ?", id).First(&target).Error; err != nil {
+		return nil, ErrBackupTargetNotFound
+	}
+	return &target, nil
+}
+
+func CreateBackupTarget(target *models.BackupTarget) error {
+	if err := ValidateBackupTarget(target); err != nil {
+		return err
+	}
+	return database.DB.Create(target).Error
+}
+
+func UpdateBackupTarget(target *models.BackupTarget) error {
+	if err := ValidateBackupTarget(target); err != nil {
+		return err
+	}
+	if err := database.DB.Save(target).Error; err != nil {
+		return err
+	}
+	go SyncBackupTargetServers(target.ID)
+	return nil
+}
+
+func DeleteBackupTarget(id uuid.UUID) error {
+	var nodes, packages int64
+	database.DB.Model(&models.Node{}).Where("backup_target_id = ?", id).Count(&nodes)
+	database.DB.Model(&models.Package{}).Where("backup_target_id = ?", id).Count(&packages)
+	if nodes > 0 || packages > 0 {
+		return ErrBackupTargetInUse
+	}
+	result := database.DB.Where("id = ?", id).Delete(&models.BackupTarget{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBackupTargetNotFound
+	}
+	return nil
+}
+
+func CheckBackupTargetID(id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	_, err := GetBackupTarget(*id)
+	return err
+}
+
+func ResolveBackupTarget(server *models.Server) (*models.BackupTarget, error) {
+	var targetID *uuid.UUID
+
+	var pkg models.Package
+	if err := database.DB.Select("backup_target_id").Where("id = ?", server.PackageID).First(&pkg).Error; err == nil && pkg.BackupTargetID != nil {
+		targetID = pkg.BackupTargetID
+	}
+	if targetID == nil {
+		var node models.Node
+		if err := database.DB.Select("backup_target_id").Where("id = ?", server.NodeID).First(&node).Error; err == nil {
+			targetID = node.BackupTargetID
+		}
+	}
+	if targetID == nil {
+		return nil, nil
+	}
+	return GetBackupTarget(*targetID)
+}
+
+func backupStorageFor(target *models.BackupTarget) NodeBackupStorage {
+	if target == nil {
+		return NodeBackupStorage{Type: "local"}
+	}
+	switch target.Type {
+	case models.BackupTargetS3:
+		return NodeBackupStorage{Type: string(target.Type), S3: &NodeBackupStorageS3{
+			Endpoint:  target.Endpoint,
+			Region:    target.Region,
+			Bucket:    target.Bucket,
+			Prefix:    target.Prefix,
+			AccessKey: target.AccessKey,
+			SecretKey: target.SecretKey,
+			PathStyle: target.PathStyle,
+		}}
+	case models.BackupTargetSFTP:
+		return NodeBackupStorage{Type: string(target.Type), SFTP: &NodeBackupStorageSFTP{
+			Host:       target.Host,
+			Port:       target.Port,
+			Username:   target.Username,
+			Password:   target.Password,
+			PrivateKey: target.PrivateKey,
+			HostKey:    target.HostKey,
+			Path:       target.Path,
+		}}
+	}
+	return NodeBackupStorage{Type: "local"}
+}
+
+func SyncServerBackupStorage(server *models.Server) error {
+	target, err := ResolveBackupTarget(server)
+	if err != nil {
+		return err
+	}
+
+	var node models.Node
+	if err := database.DB.Where("id = ?", server.NodeID).First(&node).Error; err != nil {
+		return fmt.Errorf("node not found")
+	}
+	return sendToNode(&node, "PUT", fmt.Sprintf("/api/servers/%s/backups/storage", server.ID), backupStorageFor(target))
+}
+
+func syncBackupStorage(query string, args ...interface{}) {
+	var servers []models.Server
+	database.DB.Where(query, args...).Find(&servers)
+	for i := range servers {
+		if err := SyncServerBackupStorage(&servers[i]); err != nil {
+			log.Printf("[backups] failed to sync backup storage for server %s: %v", servers[i].ID, err)
+		}
+	}
+}
+
+func SyncNodeBackupStorage(nodeID uuid.UUID) {
+	syncBackupStorage("node_id = ?", nodeID)
+}
+
+func SyncPackageBackupStorage(packageID uuid.UUID) {
+	syncBackupStorage("package_id = ?", packageID)
+}
+
+func SyncBackupTargetServers(targetID uuid.UUID) {
+	syncBackupStorage("node_id IN (?) OR package_id IN (?)",
+		database.DB.Model(&models.Node{}).Select("id").Where("backup_target_id = ?", targetID),
+		database.DB.Model(&models.Package{}).Select("id").Where("backup_target_id = ?", targetID))
+}
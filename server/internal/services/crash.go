@@ -0,0 +1,143 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+var ErrInvalidCrashPolicy = errors.New("crash policy needs 0-20 restarts, a window of 1s-24h and a backoff of 0-600s")
+
+const (
+	maxCrashRestarts  = 20
+	maxCrashWindow    = 24 * 60 * 60
+	maxCrashBackoff   = 600
+	crashHistoryLimit = 50
+)
+
+var DefaultCrashPolicy = models.CrashPolicy{MaxRestarts: 3, Window: 600, Backoff: 10}
+
+func ValidateCrashPolicy(policy *models.CrashPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.MaxRestarts < 0 || policy.MaxRestarts > maxCrashRestarts ||
+		policy.Window < 1 || policy.Window > maxCrashWindow ||
+		policy.Backoff < 0 || policy.Backoff > maxCrashBackoff {
+		return ErrInvalidCrashPolicy
+	}
+	return nil
+}
+
+func parseCrashPolicy(raw []byte) (models.CrashPolicy, bool) {
+	var policy models.CrashPolicy
+	if len(raw) == 0 || string(raw) == "null" || json.Unmarshal(raw, &policy) != nil {
+		return policy, false
+	}
+	return policy, true
+}
+
+func ServerCrashPolicy(server *models.Server) models.CrashPolicy {
+	if policy, ok := parseCrashPolicy(server.CrashPolicy); ok {
+		return policy
+	}
+	var pkg models.Package
+	if err := database.DB.Select("crash_policy").Where("id = ?", server.PackageID).First(&pkg).Error; err == nil {
+		if policy, ok := parseCrashPolicy(pkg.CrashPolicy); ok {
+			return policy
+		}
+	}
+	return DefaultCrashPolicy
+}
+
+func SetServerCrashPolicy(serverID uuid.UUID, policy *models.CrashPolicy) error {
+	if err := ValidateCrashPolicy(policy); err != nil {
+		return err
+	}
+	var value interface{}
+	if policy != nil {
+		raw, _ := json.Marshal(policy)
+		value = datatypes.JSON(raw)
+	}
+	return database.DB.Model(&models.Server{}).Where("id = ?", serverID).Update("crash_policy", value).Error
+}
+
+func GetServerCrashes(serverID uuid.UUID) ([]models.ServerCrash, error) {
+	var crashes []models.ServerCrash
+	err := database.DB.Where("server_id = ?", serverID).Order("created_at desc").Limit(crashHistoryLimit).Find(&crashes).Error
+	return crashes, err
+}
+
+// recordServerCrash stores the crash and applies the server's crash policy.
+// When the server should be restarted it returns the delay to wait, and the
+// caller schedules the restart once the stopped status has been saved.
+func recordServerCrash(server *models.Server, report ServerStateReport) (*models.ServerCrash, time.Duration, error) {
+	policy := ServerCrashPolicy(server)
+
+	var recent []models.ServerCrash
+	database.DB.Select("action").
+		Where("server_id = ? AND created_at > ?", server.ID, report.Time.Add(-time.Duration(policy.Window)*time.Second)).
+		Order("created_at desc").Find(&recent)
+	restarts := 0
+	for _, c := range recent {
+		if c.Action != models.CrashActionRestarted {
+			break
+		}
+		restarts++
+	}
+
+	crash := &models.ServerCrash{
+		ServerID:  server.ID,
+		ExitCode:  report.ExitCode,
+		OOM:       report.OOM,
+		Logs:      strings.Join(report.Logs, "\n"),
+		Action:    models.CrashActionNone,
+		CreatedAt: report.Time,
+	}
+	switch {
+	case policy.MaxRestarts == 0:
+	case restarts < policy.MaxRestarts:
+		crash.Action = models.CrashActionRestarted
+	default:
+		crash.Action = models.CrashActionFailed
+	}
+	if err := database.DB.Create(crash).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var delay time.Duration
+	if crash.Action == models.CrashActionRestarted {
+		delay = time.Duration(policy.Backoff) * time.Second << restarts
+		if limit := maxCrashBackoff * time.Second; delay > limit {
+			delay = limit
+		}
+		log.Printf("[crash] restarting server %s in %s (%d/%d)", server.ID, delay, restarts+1, policy.MaxRestarts)
+	} else if crash.Action == models.CrashActionFailed {
+		log.Printf("[crash] server %s crashed %d times within %ds, marking it failed", server.ID, restarts+1, policy.Window)
+	}
+	return crash, delay, nil
+}
+
+func restartStoppedServer(serverID uuid.UUID) {
+	var server models.Server
+	if err := database.DB.Where("id = ?", serverID).First(&server).Error; err != nil {
+		return
+	}
+	if server.Status != models.ServerStatusStopped || server.IsSuspended {
+		return
+	}
+	if err := SendStartServer(serverID); err != nil {
+		log.Printf("[crash] failed to restart server %s: %v", serverID, err)
+		UpdateServerStatus(serverID, models.ServerStatusFailed, "")
+		return
+	}
+	UpdateServerStatus(serverID, models.ServerStatusRunning, "")
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"birdactyl-panel-backend/internal/models"
+)
+
+type FileGrepOptions struct {
+	Pattern       string
+	Regex         bool
+	CaseSensitive bool
+	Path          string
+	Include       string
+	MaxFileSize   int64
+	Limit         int
+}
+
+type FileGrepMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+type FileGrepSummary struct {
+	FilesScanned int    `json:"files_scanned"`
+	FilesSkipped int    `json:"files_skipped"`
+	Matches      int    `json:"matches"`
+	Truncated    bool   `json:"truncated"`
+	Error        string `json:"error"`
+}
+
+// StreamFileGrep starts a content search on the server's node. The response
+// body is newline-delimited JSON: one "match" object per hit followed by a
+// single "done" summary.
+func StreamFileGrep(server *models.Server, opts FileGrepOptions) (*http.Response, error) {
+	q := url.Values{}
+	q.Set("pattern", opts.Pattern)
+	q.Set("regex", strconv.FormatBool(opts.Regex))
+	q.Set("case_sensitive", strconv.FormatBool(opts.CaseSensitive))
+	if opts.Path != "" {
+		q.Set("path", opts.Path)
+	}
+	if opts.Include != "" {
+		q.Set("include", opts.Include)
+	}
+	if opts.MaxFileSize > 0 {
+		q.Set("max_size", strconv.FormatInt(opts.MaxFileSize, 10))
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	return StreamDownloadFromNode(server, "/api/servers/"+server.ID.String()+"/files/grep?"+q.Encode(), nil)
+}
+
+func SearchFileContents(server *models.Server, opts FileGrepOptions) ([]FileGrepMatch, *FileGrepSummary, error) {
+	resp, err := StreamFileGrep(server, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		if errMsg, ok := result["error"].(string); ok {
+			return nil, nil, fmt.Errorf("node error: %s", errMsg)
+		}
+		return nil, nil, fmt.Errorf("node returned status %d", resp.StatusCode)
+	}
+
+	matches := []FileGrepMatch{}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var line struct {
+			Type string `json:"type"`
+			FileGrepMatch
+			FileGrepSummary
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		switch line.Type {
+		case "match":
+			matches = append(matches, line.FileGrepMatch)
+		case "done":
+			summary := line.FileGrepSummary
+			if summary.Error != "" {
+				return matches, &summary, fmt.Errorf("node error: %s", summary.Error)
+			}
+			return matches, &summary, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return matches, nil, err
+	}
+	return matches, nil, fmt.Errorf("search ended before completing")
+}
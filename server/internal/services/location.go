@@ -0,0 +1,133 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLocationNotFound   = errors.New("location not found")
+	ErrLocationNameTaken  = errors.New("location name already exists")
+	ErrLocationInUse      = errors.New("location has nodes assigned")
+	ErrLocationNotAllowed = errors.New("package is not available in this location")
+)
+
+func ValidateLocation(l *models.Location) error {
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	var tags []string
+	if len(l.Tags) > 0 && json.Unmarshal(l.Tags, &tags) != nil {
+		return fmt.Errorf("tags must be a list of strings")
+	}
+	var existing models.Location
+	if err := database.DB.Where("name = ? AND id <> ?", l.Name, l.ID).First(&existing).Error; err == nil {
+		return ErrLocationNameTaken
+	}
+	return nil
+}
+
+func GetLocations() ([]models.Location, error) {
+	var locations []models.Location
+	err := database.DB.Order("name").Find(&locations).Error
+	return locations, err
+}
+
+func GetLocation(id uuid.UUID) (*models.Location, error) {
+	var location models.Location
+	if err := database.DB.Where("id = ?", id).First(&location).Error; err != nil {
+		return nil, ErrLocationNotFound
+	}
+	return &location, nil
+}
+
+func CreateLocation(location *models.Location) error {
+	if err := ValidateLocation(location); err != nil {
+		return err
+	}
+	return database.DB.Create(location).Error
+}
+
+func UpdateLocation(location *models.Location) error {
+	if err := ValidateLocation(location); err != nil {
+		return err
+	}
+	return database.DB.Save(location).Error
+}
+
+func DeleteLocation(id uuid.UUID) error {
+	var nodes int64
+	database.DB.Model(&models.Node{}).Where("location_id = ?", id).Count(&nodes)
+	if nodes > 0 {
+		return ErrLocationInUse
+	}
+	result := database.DB.Where("id = ?", id).Delete(&models.Location{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLocationNotFound
+	}
+	return nil
+}
+
+func CheckLocationIDs(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var count int64
+	database.DB.Model(&models.Location{}).Where("id IN ?", ids).Count(&count)
+	if int(count) != len(ids) {
+		return ErrLocationNotFound
+	}
+	return nil
+}
+
+func SetNodeLocation(id uuid.UUID, locationID *uuid.UUID) (*models.Node, error) {
+	var node models.Node
+	if err := database.DB.Where("id = ?", id).First(&node).Error; err != nil {
+		return nil, ErrNodeNotFound
+	}
+	if locationID != nil {
+		if _, err := GetLocation(*locationID); err != nil {
+			return nil, err
+		}
+	}
+	if err := database.DB.Model(&node).Update("location_id", locationID).Error; err != nil {
+		return nil, err
+	}
+	node.LocationID = locationID
+
+	if node.LastHeartbeat != nil {
+		node.IsOnline = time.Since(*node.LastHeartbeat) < heartbeatTimeout
+	}
+
+	return &node, nil
+}
+
+// PackageAllowsLocation reports whether servers of the package may be placed on
+// a node in the location. Packages without allowed locations run anywhere.
+func PackageAllowsLocation(pkg *models.Package, locationID *uuid.UUID) bool {
+	var allowed []uuid.UUID
+	if len(pkg.AllowedLocations) == 0 || json.Unmarshal(pkg.AllowedLocations, &allowed) != nil || len(allowed) == 0 {
+		return true
+	}
+	if locationID == nil {
+		return false
+	}
+	for _, id := range allowed {
+		if id == *locationID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func GetServerMetrics(server *models.Server, window string) (*NodeResponse, error) {
+	return ProxyToNode(server, "GET", fmt.Sprintf("/api/servers/%s/metrics?range=%s", server.ID, url.QueryEscape(window)), nil)
+}
+
+func GetNodeMetrics(nodeID uuid.UUID, window string) (*NodeResponse, error) {
+	node, err := GetNodeByID(nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", getNodeURL(node)+"/api/system/metrics?range="+url.QueryEscape(window), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
+
+	resp, err := nodeHTTPClient(node).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return &NodeResponse{StatusCode: resp.StatusCode, Body: body}, nil
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
@@ -112,6 +114,9 @@ func DeleteNode(id uuid.UUID) error {
 	if result.RowsAffected == 0 {
 		return ErrNodeNotFound
 	}
+	if result.Error == nil {
+		database.DB.Where("node_id = ?", id).Delete(&models.Allocation{})
+	}
 	return result.Error
 }
 
@@ -138,6 +143,32 @@ func UpdateNode(id uuid.UUID, name, icon string) (*models.Node, error) {
 	return &node, nil
 }
 
+func SetNodeBackupTarget(id uuid.UUID, targetID *uuid.UUID) (*models.Node, error) {
+	var node models.Node
+	if err := database.DB.Where("id = ?", id).First(&node).Error; err != nil {
+		return nil, ErrNodeNotFound
+	}
+	if err := CheckBackupTargetID(targetID); err != nil {
+		return nil, err
+	}
+
+	changed := (node.BackupTargetID == nil) != (targetID == nil) ||
+		(targetID != nil && *node.BackupTargetID != *targetID)
+	if err := database.DB.Model(&node).Update("backup_target_id", targetID).Error; err != nil {
+		return nil, err
+	}
+	node.BackupTargetID = targetID
+	if changed {
+		go SyncNodeBackupStorage(node.ID)
+	}
+
+	if node.LastHeartbeat != nil {
+		node.IsOnline = time.Since(*node.LastHeartbeat) < heartbeatTimeout
+	}
+
+	return &node, nil
+}
+
 func ResetNodeToken(id uuid.UUID) (*NodeToken, error) {
 	var node models.Node
 	if err := database.DB.Where("id = ?", id).First(&node).Error; err != nil {
@@ -174,11 +205,16 @@ func ValidateNodeToken(tokenID, token string) (*models.Node, error) {
 }
 
 func NodeHeartbeat(nodeID uuid.UUID, systemInfo models.SystemInfo, displayIP string) error {
+	return recordNodeHeartbeat(nodeID, systemInfo, displayIP, false)
+}
+
+func recordNodeHeartbeat(nodeID uuid.UUID, systemInfo models.SystemInfo, displayIP string, tunnel bool) error {
 	now := time.Now()
 	updates := map[string]interface{}{
 		"is_online":      true,
 		"last_heartbeat": now,
 		"system_info":    systemInfo,
+		"tunnel":         tunnel,
 	}
 	if displayIP != "" {
 		updates["display_ip"] = displayIP
@@ -207,14 +243,12 @@ func RefreshNodes() ([]models.Node, error) {
 		return nil, err
 	}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-
 	var wg sync.WaitGroup
 	for i := range nodes {
 		wg.Add(1)
 		go func(n *models.Node) {
 			defer wg.Done()
-			pingNode(client, n)
+			pingNode(n)
 		}(&nodes[i])
 	}
 	wg.Wait()
@@ -222,11 +256,13 @@ func RefreshNodes() ([]models.Node, error) {
 	return nodes, nil
 }
 
-func pingNode(client *http.Client, node *models.Node) {
-	url := fmt.Sprintf("http://%s:%d/api/system", node.FQDN, node.Port)
-	req, _ := http.NewRequest("GET", url, nil)
+func pingNode(node *models.Node) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", getNodeURL(node)+"/api/system", nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := client.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		node.IsOnline = false
 		database.DB.Model(node).Updates(map[string]interface{}{"is_online": false, "auth_error": false})
@@ -279,6 +315,7 @@ type PairingResult struct {
 	Success bool   `json:"success"`
 	TokenID string `json:"token_id,omitempty"`
 	Token   string `json:"token,omitempty"`
+	CSR     string `json:"csr,omitempty"`
 	Error   string `json:"error,omitempty"`
 }
 
@@ -288,11 +325,17 @@ func PairWithNode(name, fqdn string, port int, panelURL, code string) (*models.N
 		return nil, nil, ErrNodeNameTaken
 	}
 
+	caCert, err := NodeCACertificate()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	client := &http.Client{Timeout: 90 * time.Second}
 
 	reqBody, _ := json.Marshal(map[string]string{
 		"panel_url": panelURL,
 		"code":      code,
+		"ca_cert":   caCert,
 	})
 
 	url := fmt.Sprintf("http://%s:%d/api/pair", fqdn, port)
@@ -337,6 +380,19 @@ func PairWithNode(name, fqdn string, port int, panelURL, code string) (*models.N
 		TokenID:     result.TokenID,
 		TokenHash:   hashHex,
 		DaemonToken: daemonToken,
+		Scheme:      models.NodeSchemeHTTP,
+	}
+
+	if result.CSR != "" {
+		certPEM, fingerprint, err := SignNodeCertificate(result.CSR, fqdn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrPairingFailed, err)
+		}
+		if err := deliverNodeCertificate(client, fqdn, port, daemonToken, certPEM); err != nil {
+			return nil, nil, fmt.Errorf("%w: could not deliver node certificate: %v", ErrPairingFailed, err)
+		}
+		node.Scheme = models.NodeSchemeHTTPS
+		node.CertFingerprint = fingerprint
 	}
 
 	if err := database.DB.Create(node).Error; err != nil {
@@ -345,3 +401,30 @@ func PairWithNode(name, fqdn string, port int, panelURL, code string) (*models.N
 
 	return node, &NodeToken{TokenID: result.TokenID, Token: result.Token, DaemonToken: daemonToken}, nil
 }
+
+func deliverNodeCertificate(client *http.Client, fqdn string, port int, daemonToken, certPEM string) error {
+	body, _ := json.Marshal(map[string]string{"certificate": certPEM})
+	url := fmt.Sprintf("http://%s:%d/api/pair/certificate", fqdn, port)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+daemonToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var result PairingResult
+		json.NewDecoder(resp.Body).Decode(&result)
+		if result.Error != "" {
+			return errors.New(result.Error)
+		}
+		return fmt.Errorf("node returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidNodeLimits = errors.New("limits must be 0 or more and overallocation -1 or more")
+
+type NodeCapacityError struct {
+	Node      string
+	Resource  string
+	Requested int
+	Available int
+}
+
+func (e *NodeCapacityError) Error() string {
+	return fmt.Sprintf("node %s does not have enough %s: %d requested, %d available", e.Node, e.Resource, e.Requested, e.Available)
+}
+
+func IsNodeCapacityError(err error) bool {
+	var capErr *NodeCapacityError
+	return errors.As(err, &capErr)
+}
+
+type NodeLimits struct {
+	MemoryLimit        *int
+	MemoryOverallocate *int
+	DiskLimit          *int
+	DiskOverallocate   *int
+	CPULimit           *int
+	CPUOverallocate    *int
+}
+
+func (l NodeLimits) IsEmpty() bool {
+	return l.MemoryLimit == nil && l.MemoryOverallocate == nil && l.DiskLimit == nil &&
+		l.DiskOverallocate == nil && l.CPULimit == nil && l.CPUOverallocate == nil
+}
+
+func SetNodeLimits(id uuid.UUID, limits NodeLimits) (*models.Node, error) {
+	var node models.Node
+	if err := database.DB.Where("id = ?", id).First(&node).Error; err != nil {
+		return nil, ErrNodeNotFound
+	}
+
+	updates := map[string]interface{}{}
+	for column, value := range map[string]*int{"memory_limit": limits.MemoryLimit, "disk_limit": limits.DiskLimit, "cpu_limit": limits.CPULimit} {
+		if value == nil {
+			continue
+		}
+		if *value < 0 {
+			return nil, ErrInvalidNodeLimits
+		}
+		updates[column] = *value
+	}
+	for column, value := range map[string]*int{"memory_overallocate": limits.MemoryOverallocate, "disk_overallocate": limits.DiskOverallocate, "cpu_overallocate": limits.CPUOverallocate} {
+		if value == nil {
+			continue
+		}
+		if *value < -1 {
+			return nil, ErrInvalidNodeLimits
+		}
+		updates[column] = *value
+	}
+
+	if len(updates) > 0 {
+		if err := database.DB.Model(&node).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if node.LastHeartbeat != nil {
+		node.IsOnline = time.Since(*node.LastHeartbeat) < heartbeatTimeout
+	}
+
+	return &node, nil
+}
+
+type nodeTotals struct {
+	NodeID  uuid.UUID
+	Servers int
+	Memory  int
+	CPU     int
+	Disk    int
+}
+
+func nodeAllocationTotals(nodeIDs []uuid.UUID, excludeServerID uuid.UUID) (map[uuid.UUID]nodeTotals, error) {
+	var rows []nodeTotals
+	err := database.DB.Model(&models.Server{}).
+		Select("node_id, COUNT(*) AS servers, COALESCE(SUM(memory), 0) AS memory, COALESCE(SUM(cpu), 0) AS cpu, COALESCE(SUM(disk), 0) AS disk").
+		Where("node_id IN ? AND id <> ?", nodeIDs, excludeServerID).
+		Group("node_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[uuid.UUID]nodeTotals, len(rows))
+	for _, row := range rows {
+		totals[row.NodeID] = row
+	}
+	return totals, nil
+}
+
+func resourceAllocation(allocated, limit, overallocate int) models.ResourceAllocation {
+	r := models.ResourceAllocation{Allocated: allocated, Limit: limit, Overallocate: overallocate}
+	if limit > 0 && overallocate >= 0 {
+		r.Max = limit * (100 + overallocate) / 100
+	}
+	return r
+}
+
+func buildNodeAllocation(node *models.Node, totals nodeTotals) *models.NodeAllocation {
+	return &models.NodeAllocation{
+		Servers: totals.Servers,
+		Memory:  resourceAllocation(totals.Memory, node.MemoryLimit, node.MemoryOverallocate),
+		Disk:    resourceAllocation(totals.Disk, node.DiskLimit, node.DiskOverallocate),
+		CPU:     resourceAllocation(totals.CPU, node.CPULimit, node.CPUOverallocate),
+	}
+}
+
+func AttachNodeAllocations(nodes []models.Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(nodes))
+	for i := range nodes {
+		ids[i] = nodes[i].ID
+	}
+	totals, err := nodeAllocationTotals(ids, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	for i := range nodes {
+		nodes[i].Allocation = buildNodeAllocation(&nodes[i], totals[nodes[i].ID])
+	}
+	return nil
+}
+
+// CheckNodeCapacity reports whether a server with the given resources fits on
+// the node alongside everything already placed there. excludeServerID leaves a
+// server out of the totals so resizes are not counted twice, and a resource it
+// is not growing is never rejected. Going past a limit but staying within its
+// overallocation returns warnings instead of an error.
+func CheckNodeCapacity(node *models.Node, memory, cpu, disk int, excludeServerID uuid.UUID) ([]string, error) {
+	totals, err := nodeAllocationTotals([]uuid.UUID{node.ID}, excludeServerID)
+	if err != nil {
+		return nil, err
+	}
+	allocation := buildNodeAllocation(node, totals[node.ID])
+
+	var current models.Server
+	if excludeServerID != uuid.Nil {
+		database.DB.Select("memory", "cpu", "disk").Where("id = ? AND node_id = ?", excludeServerID, node.ID).First(&current)
+	}
+
+	var warnings []string
+	for _, check := range []struct {
+		name      string
+		requested int
+		current   int
+		resource  models.ResourceAllocation
+	}{
+		{"memory", memory, current.Memory, allocation.Memory},
+		{"disk", disk, current.Disk, allocation.Disk},
+		{"cpu", cpu, current.CPU, allocation.CPU},
+	} {
+		r := check.resource
+		if r.Limit == 0 {
+			continue
+		}
+		total := r.Allocated + check.requested
+		if r.Max > 0 && total > r.Max && check.requested > check.current {
+			return nil, &NodeCapacityError{Node: node.Name, Resource: check.name, Requested: check.requested, Available: max(r.Max-r.Allocated, 0)}
+		}
+		if total > r.Limit {
+			warnings = append(warnings, fmt.Sprintf("node %s %s is overallocated: %d of %d committed", node.Name, check.name, total, r.Limit))
+		}
+	}
+	for _, warning := range warnings {
+		log.Printf("[nodes] %s", warning)
+	}
+	return warnings, nil
+}
@@ -7,14 +7,13 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"sync"
-	"time"
 
 	"birdactyl-panel-backend/internal/database"
 	"birdactyl-panel-backend/internal/models"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/websocket"
 )
 
 type NodeServerConfig struct {
@@ -43,16 +42,18 @@ type NodeMountConfig struct {
 }
 
 type NodePortConfig struct {
+	IP        string `json:"ip,omitempty"`
 	Host      int    `json:"host"`
 	Container int    `json:"container"`
 	Protocol  string `json:"protocol"`
 }
 
-var httpClient = &http.Client{Timeout: 2 * time.Minute}
-var transferClient = &http.Client{Timeout: 0}
-
 func getNodeURL(node *models.Node) string {
-	return fmt.Sprintf("http://%s:%d", node.FQDN, node.Port)
+	scheme := node.Scheme
+	if scheme == "" || node.Tunnel {
+		scheme = models.NodeSchemeHTTP
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, node.FQDN, node.Port)
 }
 
 func SendCreateServer(server *models.Server) error {
@@ -75,10 +76,13 @@ func SendCreateServer(server *models.Server) error {
 	ports := make([]NodePortConfig, 0)
 	for i, pp := range pkgPorts {
 		hostPort := pp.Default
+		hostIP := ""
 		if i < len(serverPorts) {
 			hostPort = serverPorts[i].Port
+			hostIP = serverPorts[i].IP
 		}
 		ports = append(ports, NodePortConfig{
+			IP:        hostIP,
 			Host:      hostPort,
 			Container: pp.Default,
 			Protocol:  pp.Protocol,
@@ -162,10 +166,13 @@ func SendStartServer(serverID uuid.UUID) error {
 	ports := make([]NodePortConfig, 0)
 	for i, pp := range pkgPorts {
 		hostPort := pp.Default
+		hostIP := ""
 		if i < len(serverPorts) {
 			hostPort = serverPorts[i].Port
+			hostIP = serverPorts[i].IP
 		}
 		ports = append(ports, NodePortConfig{
+			IP:        hostIP,
 			Host:      hostPort,
 			Container: pp.Default,
 			Protocol:  pp.Protocol,
@@ -295,10 +302,13 @@ func SendReinstallServer(server *models.Server) error {
 	ports := make([]NodePortConfig, 0)
 	for i, pp := range pkgPorts {
 		hostPort := pp.Default
+		hostIP := ""
 		if i < len(serverPorts) {
 			hostPort = serverPorts[i].Port
+			hostIP = serverPorts[i].IP
 		}
 		ports = append(ports, NodePortConfig{
+			IP:        hostIP,
 			Host:      hostPort,
 			Container: pp.Default,
 			Protocol:  pp.Protocol,
@@ -401,7 +411,7 @@ func sendToNode(node *models.Node, method, path string, body interface{}) error
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
 
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect to node: %w", err)
 	}
@@ -421,6 +431,7 @@ func sendToNode(node *models.Node, method, path string, body interface{}) error
 
 type NodeResponse struct {
 	StatusCode int
+	Header     http.Header
 	Body       []byte
 }
 
@@ -448,42 +459,53 @@ func ProxyToNode(server *models.Server, method, path string, body interface{}) (
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
 
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(&node).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to node: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, _ := io.ReadAll(resp.Body)
-	return &NodeResponse{StatusCode: resp.StatusCode, Body: respBody}, nil
+	return &NodeResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}, nil
 }
 
 func ProxyUploadToNode(server *models.Server, path string, body io.Reader, contentType string) (*NodeResponse, error) {
+	return ProxyRawToNode(server, "POST", path, body, http.Header{"Content-Type": {contentType}})
+}
+
+// ProxyRawToNode sends body to the node as is, with the given headers. It is
+// used for endpoints that don't take JSON, such as upload chunks.
+func ProxyRawToNode(server *models.Server, method, path string, body io.Reader, header http.Header) (*NodeResponse, error) {
 	var node models.Node
 	if err := database.DB.Where("id = ?", server.NodeID).First(&node).Error; err != nil {
 		return nil, fmt.Errorf("node not found")
 	}
 
 	url := getNodeURL(&node) + path
-	req, err := http.NewRequest("POST", url, body)
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", contentType)
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
 
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(&node).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to node: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, _ := io.ReadAll(resp.Body)
-	return &NodeResponse{StatusCode: resp.StatusCode, Body: respBody}, nil
+	return &NodeResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}, nil
 }
 
-func StreamDownloadFromNode(server *models.Server, path string) (*http.Response, error) {
+// StreamDownloadFromNode opens a streamed GET on the node. header carries
+// request headers to forward, such as Range and If-Range for resumed
+// downloads; it may be nil.
+func StreamDownloadFromNode(server *models.Server, path string, header http.Header) (*http.Response, error) {
 	var node models.Node
 	if err := database.DB.Where("id = ?", server.NodeID).First(&node).Error; err != nil {
 		return nil, fmt.Errorf("node not found")
@@ -494,9 +516,16 @@ func StreamDownloadFromNode(server *models.Server, path string) (*http.Response,
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
+	if req.Header.Get("Range") != "" {
+		// A transparently decompressed body would not match the byte range.
+		req.Header.Set("Accept-Encoding", "identity")
+	}
 
-	return httpClient.Do(req)
+	return nodeTransferClient(&node).Do(req)
 }
 
 func ProxyGetToNode(server *models.Server, path string) (map[string]interface{}, error) {
@@ -533,14 +562,6 @@ func ProxyDeleteToNode(server *models.Server, path string) (map[string]interface
 	return result, nil
 }
 
-func GetNodeProxyURL(server *models.Server, path string) (string, error) {
-	var node models.Node
-	if err := database.DB.Where("id = ?", server.NodeID).First(&node).Error; err != nil {
-		return "", fmt.Errorf("node not found")
-	}
-	return fmt.Sprintf("%s/api/servers/%s%s?token=%s", getNodeURL(&node), server.ID, path, node.DaemonToken), nil
-}
-
 type ServerStatsResult struct {
 	MemoryBytes int64
 	MemoryLimit int64
@@ -560,7 +581,7 @@ func GetServerStats(serverID uuid.UUID) *ServerStatsResult {
 	url := fmt.Sprintf("%s/api/servers/%s/status", getNodeURL(node), server.ID)
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		log.Printf("[nodeclient] GetServerStats request failed: %v", err)
 		return nil
@@ -600,7 +621,7 @@ func GetConsoleLog(serverID uuid.UUID, lines int) []string {
 	url := fmt.Sprintf("%s/api/servers/%s/logs?lines=%d", getNodeURL(node), server.ID, lines)
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		log.Printf("[nodeclient] GetConsoleLog request failed: %v", err)
 		return nil
@@ -642,7 +663,7 @@ func DownloadServerArchive(serverID uuid.UUID) (*http.Response, error) {
 	}
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
 
-	return transferClient.Do(req)
+	return nodeTransferClient(node).Do(req)
 }
 
 func DeleteServerArchive(serverID uuid.UUID) error {
@@ -653,6 +674,96 @@ func DeleteServerArchive(serverID uuid.UUID) error {
 	return sendToNode(node, "DELETE", fmt.Sprintf("/api/servers/%s/archive", server.ID), nil)
 }
 
+type NodeBackup struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+	Completed bool   `json:"completed"`
+	Storage   string `json:"storage"`
+	Checksum  string `json:"checksum"`
+}
+
+func CreateNodeBackup(serverID uuid.UUID, backupID, name, ignore string, hooks *models.PackageBackupHooks) error {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return err
+	}
+	if err := SyncServerBackupStorage(server); err != nil {
+		return fmt.Errorf("failed to configure backup storage: %w", err)
+	}
+	return sendToNode(node, "POST", fmt.Sprintf("/api/servers/%s/backups", server.ID), map[string]interface{}{"id": backupID, "name": name, "ignore": ignore, "hooks": hooks})
+}
+
+func ListNodeBackups(serverID uuid.UUID) ([]NodeBackup, error) {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/api/servers/%s/backups", getNodeURL(node), server.ID)
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
+	resp, err := nodeHTTPClient(node).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to node: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("node returned status %d", resp.StatusCode)
+	}
+	var result struct {
+		Data []NodeBackup `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid backup list from node: %w", err)
+	}
+	return result.Data, nil
+}
+
+func DeleteNodeBackup(serverID uuid.UUID, backupID string) error {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return err
+	}
+	return sendToNode(node, "DELETE", fmt.Sprintf("/api/servers/%s/backups/%s", server.ID, url.PathEscape(backupID)), nil)
+}
+
+func DeleteServerFile(serverID uuid.UUID, path string) error {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return err
+	}
+	return sendToNode(node, "DELETE", fmt.Sprintf("/api/servers/%s/files?path=%s", server.ID, url.QueryEscape(path)), nil)
+}
+
+func WriteServerFile(serverID uuid.UUID, path, content string) error {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return err
+	}
+	return sendToNode(node, "POST", fmt.Sprintf("/api/servers/%s/files/write", server.ID), map[string]string{"path": path, "content": content})
+}
+
+func CompressServerFiles(serverID uuid.UUID, paths []string, dest, format string) error {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return err
+	}
+	return sendToNode(node, "POST", fmt.Sprintf("/api/servers/%s/files/bulk-compress", server.ID), map[string]interface{}{
+		"paths":  paths,
+		"dest":   dest,
+		"format": format,
+	})
+}
+
+func DecompressServerFile(serverID uuid.UUID, path, dest string) error {
+	server, node, err := getServerAndNode(serverID)
+	if err != nil {
+		return err
+	}
+	return sendToNode(node, "POST", fmt.Sprintf("/api/servers/%s/files/decompress", server.ID), map[string]string{"path": path, "dest": dest})
+}
+
 func ImportServerArchive(targetNode *models.Node, serverID string, sourceNode *models.Node) error {
 	url := getNodeURL(targetNode) + fmt.Sprintf("/api/servers/%s/import", serverID)
 	sourceURL := getNodeURL(sourceNode) + fmt.Sprintf("/api/servers/%s/archive/download", serverID)
@@ -670,7 +781,7 @@ func ImportServerArchive(targetNode *models.Node, serverID string, sourceNode *m
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+targetNode.DaemonToken)
 
-	resp, err := transferClient.Do(req)
+	resp, err := nodeTransferClient(targetNode).Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to connect to target node: %w", err)
 	}
@@ -739,8 +850,7 @@ func streamFromNode(serverID uuid.UUID, ch chan string) {
 	if err != nil {
 		return
 	}
-	url := fmt.Sprintf("ws://%s:%d/api/servers/%s/ws?token=%s", node.FQDN, node.Port, server.ID, node.DaemonToken)
-	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	conn, err := DialNodeWebSocket(node, fmt.Sprintf("/api/servers/%s/ws", server.ID))
 	if err != nil {
 		return
 	}
@@ -772,7 +882,7 @@ func GetFullLog(serverID uuid.UUID) ([]byte, int64) {
 	url := fmt.Sprintf("%s/api/servers/%s/logs/full", getNodeURL(node), server.ID)
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		log.Printf("[nodeclient] GetFullLog request failed: %v", err)
 		return nil, 0
@@ -792,7 +902,7 @@ func SearchLogs(serverID uuid.UUID, pattern string, regex bool, limit int, since
 		getNodeURL(node), server.ID, pattern, regex, limit, since)
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		log.Printf("[nodeclient] SearchLogs request failed: %v", err)
 		return nil
@@ -814,7 +924,7 @@ func ListLogFiles(serverID uuid.UUID) []LogFileInfo {
 	url := fmt.Sprintf("%s/api/servers/%s/logs/files", getNodeURL(node), server.ID)
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		log.Printf("[nodeclient] ListLogFiles request failed: %v", err)
 		return nil
@@ -836,7 +946,7 @@ func ReadLogFile(serverID uuid.UUID, filename string) ([]byte, int64) {
 	url := fmt.Sprintf("%s/api/servers/%s/logs/file/%s", getNodeURL(node), server.ID, filename)
 	req, _ := http.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "Bearer "+node.DaemonToken)
-	resp, err := httpClient.Do(req)
+	resp, err := nodeHTTPClient(node).Do(req)
 	if err != nil {
 		log.Printf("[nodeclient] ReadLogFile request failed: %v", err)
 		return nil, 0
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	ErrInvalidNodeCSR   = errors.New("invalid node certificate request")
+	ErrNodeCertMismatch = errors.New("node certificate does not match the pinned fingerprint")
+)
+
+const (
+	settingNodeCACert = "node_ca_cert"
+	settingNodeCAKey  = "node_ca_key"
+
+	nodeCAValidity     = 20 * 365 * 24 * time.Hour
+	nodeCertValidity   = 10 * 365 * 24 * time.Hour
+	panelCertValidity  = 30 * 24 * time.Hour
+	panelCertRenewal   = 24 * time.Hour
+	nodeRequestTimeout = 2 * time.Minute
+)
+
+type nodeCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	pool    *x509.CertPool
+}
+
+type nodeClients struct {
+	api      *http.Client
+	transfer *http.Client
+}
+
+var (
+	nodeCAMu     sync.Mutex
+	cachedNodeCA *nodeCA
+	panelCert    *tls.Certificate
+
+	nodeClientsMu sync.Mutex
+	nodeClientMap = make(map[string]*nodeClients)
+
+	plainNodeClients = &nodeClients{
+		api:      &http.Client{Timeout: nodeRequestTimeout},
+		transfer: &http.Client{Timeout: 0},
+	}
+)
+
+func loadNodeCA() (*nodeCA, error) {
+	nodeCAMu.Lock()
+	defer nodeCAMu.Unlock()
+	return loadNodeCALocked()
+}
+
+func loadNodeCALocked() (*nodeCA, error) {
+	if cachedNodeCA != nil {
+		return cachedNodeCA, nil
+	}
+
+	certPEM, keyPEM := GetSetting(settingNodeCACert), GetSetting(settingNodeCAKey)
+	if certPEM == "" || keyPEM == "" {
+		var err error
+		if certPEM, keyPEM, err = generateNodeCA(); err != nil {
+			return nil, err
+		}
+		if err := SetSetting(settingNodeCAKey, keyPEM); err != nil {
+			return nil, err
+		}
+		if err := SetSetting(settingNodeCACert, certPEM); err != nil {
+			return nil, err
+		}
+	}
+
+	certBlock, _ := pem.Decode([]byte(certPEM))
+	keyBlock, _ := pem.Decode([]byte(keyPEM))
+	if certBlock == nil || keyBlock == nil {
+		return nil, errors.New("stored node CA is corrupt")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	cachedNodeCA = &nodeCA{cert: cert, key: key, certPEM: certPEM, pool: pool}
+	return cachedNodeCA, nil
+}
+
+func generateNodeCA() (certPEM, keyPEM string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          randomSerial(),
+		Subject:               pkix.Name{CommonName: "Birdactyl Node CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(nodeCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})), nil
+}
+
+func randomSerial() *big.Int {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	return serial
+}
+
+func NodeCACertificate() (string, error) {
+	ca, err := loadNodeCA()
+	if err != nil {
+		return "", err
+	}
+	return ca.certPEM, nil
+}
+
+func CertFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+func SignNodeCertificate(csrPEM, fqdn string) (certPEM, fingerprint string, err error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return "", "", ErrInvalidNodeCSR
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil || csr.CheckSignature() != nil {
+		return "", "", ErrInvalidNodeCSR
+	}
+
+	ca, err := loadNodeCA()
+	if err != nil {
+		return "", "", err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: randomSerial(),
+		Subject:      pkix.Name{CommonName: fqdn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(nodeCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if ip := net.ParseIP(fqdn); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{fqdn}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+	if err != nil {
+		return "", "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), CertFingerprint(der), nil
+}
+
+func panelCertificate() (*tls.Certificate, error) {
+	nodeCAMu.Lock()
+	defer nodeCAMu.Unlock()
+
+	if panelCert != nil && time.Until(panelCert.Leaf.NotAfter) > panelCertRenewal {
+		return panelCert, nil
+	}
+
+	ca, err := loadNodeCALocked()
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: randomSerial(),
+		Subject:      pkix.Name{CommonName: "Birdactyl Panel"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(panelCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	panelCert = &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+	return panelCert, nil
+}
+
+func nodeTLSConfig(node *models.Node) (*tls.Config, error) {
+	ca, err := loadNodeCA()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    ca.pool,
+		ServerName: node.FQDN,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return panelCertificate()
+		},
+	}
+	if fingerprint := node.CertFingerprint; fingerprint != "" {
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 || CertFingerprint(cs.PeerCertificates[0].Raw) != fingerprint {
+				return ErrNodeCertMismatch
+			}
+			return nil
+		}
+	}
+	return cfg, nil
+}
+
+type failedTransport struct{ err error }
+
+func (t failedTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+func clientsForNode(node *models.Node) *nodeClients {
+	if !node.Tunnel && node.Scheme != models.NodeSchemeHTTPS {
+		return plainNodeClients
+	}
+
+	key := node.ID.String() + "|" + node.FQDN + "|" + node.CertFingerprint
+	if node.Tunnel {
+		key = node.ID.String() + "|tunnel"
+	}
+	nodeClientsMu.Lock()
+	defer nodeClientsMu.Unlock()
+	if clients, ok := nodeClientMap[key]; ok {
+		return clients
+	}
+
+	var transport http.RoundTripper
+	if node.Tunnel {
+		transport = newTunnelTransport(node.ID)
+	} else if tlsCfg, err := nodeTLSConfig(node); err != nil {
+		transport = failedTransport{fmt.Errorf("node TLS unavailable: %w", err)}
+	} else {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = tlsCfg
+		transport = t
+	}
+
+	clients := &nodeClients{
+		api:      &http.Client{Transport: transport, Timeout: nodeRequestTimeout},
+		transfer: &http.Client{Transport: transport, Timeout: 0},
+	}
+	nodeClientMap[key] = clients
+	return clients
+}
+
+func nodeHTTPClient(node *models.Node) *http.Client {
+	return clientsForNode(node).api
+}
+
+func nodeTransferClient(node *models.Node) *http.Client {
+	return clientsForNode(node).transfer
+}
+
+func DialNodeWebSocket(node *models.Node, path string) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	if node.Tunnel {
+		nodeID := node.ID
+		dialer.NetDialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialNodeTunnel(nodeID)
+		}
+	} else if node.Scheme == models.NodeSchemeHTTPS {
+		tlsCfg, err := nodeTLSConfig(node)
+		if err != nil {
+			return nil, err
+		}
+		dialer.TLSClientConfig = tlsCfg
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(getNodeURL(node), "http") + path
+	header := http.Header{"Authorization": {"Bearer " + node.DaemonToken}}
+	conn, _, err := dialer.Dial(wsURL, header)
+	return conn, err
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/yamux"
+)
+
+var ErrNodeTunnelDown = errors.New("node tunnel is not connected")
+
+const (
+	tunnelKeepAlive = 30 * time.Second
+	wsBinaryMessage = 2
+)
+
+type tunnelMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+var (
+	nodeTunnels   = make(map[uuid.UUID]*yamux.Session)
+	nodeTunnelsMu sync.RWMutex
+)
+
+func tunnelConfig() *yamux.Config {
+	cfg := yamux.DefaultConfig()
+	cfg.KeepAliveInterval = tunnelKeepAlive
+	cfg.LogOutput = io.Discard
+	return cfg
+}
+
+func ServeNodeTunnel(node *models.Node, conn net.Conn) error {
+	session, err := yamux.Client(conn, tunnelConfig())
+	if err != nil {
+		return err
+	}
+
+	nodeTunnelsMu.Lock()
+	if old := nodeTunnels[node.ID]; old != nil {
+		old.Close()
+	}
+	nodeTunnels[node.ID] = session
+	nodeTunnelsMu.Unlock()
+
+	database.DB.Model(&models.Node{}).Where("id = ?", node.ID).Updates(map[string]interface{}{"tunnel": true, "is_online": true, "auth_error": false})
+	log.Printf("[tunnel] node %s connected from %s", node.Name, conn.RemoteAddr())
+
+	defer func() {
+		session.Close()
+		nodeTunnelsMu.Lock()
+		if nodeTunnels[node.ID] == session {
+			delete(nodeTunnels, node.ID)
+			database.DB.Model(&models.Node{}).Where("id = ?", node.ID).Update("is_online", false)
+			log.Printf("[tunnel] node %s disconnected", node.Name)
+		}
+		nodeTunnelsMu.Unlock()
+	}()
+
+	for {
+		stream, err := session.AcceptStream()
+		if err != nil {
+			return nil
+		}
+		go handleTunnelStream(node.ID, stream)
+	}
+}
+
+func handleTunnelStream(nodeID uuid.UUID, stream net.Conn) {
+	defer stream.Close()
+	stream.SetDeadline(time.Now().Add(30 * time.Second))
+
+	var msg tunnelMessage
+	if err := json.NewDecoder(stream).Decode(&msg); err != nil {
+		return
+	}
+
+	var err error
+	switch msg.Type {
+	case "heartbeat":
+		var hb struct {
+			System    models.SystemInfo `json:"system"`
+			DisplayIP string            `json:"display_ip"`
+		}
+		if err = json.Unmarshal(msg.Data, &hb); err == nil {
+			err = recordNodeHeartbeat(nodeID, hb.System, hb.DisplayIP, true)
+		}
+	case "server_state":
+		var report ServerStateReport
+		if err = json.Unmarshal(msg.Data, &report); err == nil {
+			err = HandleServerStateReport(nodeID, report)
+		}
+	case "sftp_activity":
+		var report SFTPActivityReport
+		if err = json.Unmarshal(msg.Data, &report); err == nil && sftpActivityListener != nil {
+			sftpActivityListener(nodeID, report)
+		}
+	default:
+		err = fmt.Errorf("unknown tunnel message %q", msg.Type)
+	}
+
+	resp := map[string]interface{}{"success": err == nil}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+	json.NewEncoder(stream).Encode(resp)
+}
+
+func dialNodeTunnel(nodeID uuid.UUID) (net.Conn, error) {
+	nodeTunnelsMu.RLock()
+	session := nodeTunnels[nodeID]
+	nodeTunnelsMu.RUnlock()
+	if session == nil || session.IsClosed() {
+		return nil, ErrNodeTunnelDown
+	}
+	return session.Open()
+}
+
+func newTunnelTransport(nodeID uuid.UUID) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialNodeTunnel(nodeID)
+		},
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+type messageConn interface {
+	NextReader() (int, io.Reader, error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+	LocalAddr() net.Addr
+	RemoteAddr() net.Addr
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+type tunnelConn struct {
+	ws     messageConn
+	reader io.Reader
+}
+
+func NewTunnelConn(ws messageConn) net.Conn {
+	return &tunnelConn{ws: ws}
+}
+
+func (c *tunnelConn) Read(p []byte) (int, error) {
+	for {
+		if c.reader == nil {
+			_, r, err := c.ws.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			c.reader = r
+		}
+		n, err := c.reader.Read(p)
+		if err == io.EOF {
+			c.reader = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *tunnelConn) Write(p []byte) (int, error) {
+	if err := c.ws.WriteMessage(wsBinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *tunnelConn) Close() error                       { return c.ws.Close() }
+func (c *tunnelConn) LocalAddr() net.Addr                { return c.ws.LocalAddr() }
+func (c *tunnelConn) RemoteAddr() net.Addr               { return c.ws.RemoteAddr() }
+func (c *tunnelConn) SetReadDeadline(t time.Time) error  { return c.ws.SetReadDeadline(t) }
+func (c *tunnelConn) SetWriteDeadline(t time.Time) error { return c.ws.SetWriteDeadline(t) }
+
+func (c *tunnelConn) SetDeadline(t time.Time) error {
+	if err := c.ws.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.ws.SetWriteDeadline(t)
+}
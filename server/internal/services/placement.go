@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"sort"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PlacementLeastMemory   = "least_memory"
+	PlacementFewestServers = "fewest_servers"
+)
+
+var (
+	ErrNoNodeAvailable          = errors.New("no node in this location can fit the server")
+	ErrUnknownPlacementStrategy = errors.New("unknown placement strategy")
+	ErrNoFreePorts              = errors.New("node has no free ports left")
+)
+
+type PlacementRequest struct {
+	LocationID uuid.UUID
+	Package    *models.Package
+	Memory     int
+	CPU        int
+	Disk       int
+	Ports      int
+	Strategy   string
+}
+
+type PlacementCandidate struct {
+	Node       *models.Node
+	Allocation *models.NodeAllocation
+	FreePorts  int
+}
+
+// PlacementHook lets something outside the services package pick the node, such
+// as a plugin strategy. Returning uuid.Nil leaves the choice to the built-in
+// strategies; any other ID must be one of the candidates.
+type PlacementHook func(req PlacementRequest, candidates []PlacementCandidate) (uuid.UUID, error)
+
+var placementHook PlacementHook
+
+func SetPlacementHook(fn PlacementHook) {
+	placementHook = fn
+}
+
+// PlaceServer picks a node in the location for a new server. Only online nodes
+// the package is allowed on, with room for the resources and ports, are
+// considered.
+func PlaceServer(req PlacementRequest) (uuid.UUID, error) {
+	if _, err := GetLocation(req.LocationID); err != nil {
+		return uuid.Nil, err
+	}
+	if !PackageAllowsLocation(req.Package, &req.LocationID) {
+		return uuid.Nil, ErrLocationNotAllowed
+	}
+	if req.Strategy == "" {
+		req.Strategy = PlacementLeastMemory
+	}
+
+	var nodes []models.Node
+	if err := database.DB.Where("location_id = ? AND is_online = ?", req.LocationID, true).Find(&nodes).Error; err != nil {
+		return uuid.Nil, err
+	}
+	if err := AttachNodeAllocations(nodes); err != nil {
+		return uuid.Nil, err
+	}
+
+	var candidates []PlacementCandidate
+	for i := range nodes {
+		node := &nodes[i]
+		if _, err := CheckNodeCapacity(node, req.Memory, req.CPU, req.Disk, uuid.Nil); err != nil {
+			continue
+		}
+		free := freeNodePorts(node.ID)
+		if free < req.Ports {
+			continue
+		}
+		candidates = append(candidates, PlacementCandidate{Node: node, Allocation: node.Allocation, FreePorts: free})
+	}
+	if len(candidates) == 0 {
+		return uuid.Nil, ErrNoNodeAvailable
+	}
+
+	if placementHook != nil {
+		nodeID, err := placementHook(req, candidates)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if nodeID != uuid.Nil {
+			for _, c := range candidates {
+				if c.Node.ID == nodeID {
+					return nodeID, nil
+				}
+			}
+			return uuid.Nil, ErrNoNodeAvailable
+		}
+	}
+
+	var less func(a, b PlacementCandidate) bool
+	switch req.Strategy {
+	case PlacementLeastMemory:
+		less = func(a, b PlacementCandidate) bool {
+			return memoryShare(a) < memoryShare(b)
+		}
+	case PlacementFewestServers:
+		less = func(a, b PlacementCandidate) bool {
+			return a.Allocation.Servers < b.Allocation.Servers
+		}
+	default:
+		return uuid.Nil, ErrUnknownPlacementStrategy
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return less(candidates[i], candidates[j])
+	})
+	return candidates[0].Node.ID, nil
+}
+
+// memoryShare compares nodes of different sizes by how much of their memory is
+// committed, measured against the memory limit or else the memory the node
+// reported in its last heartbeat.
+func memoryShare(c PlacementCandidate) float64 {
+	capacity := float64(c.Node.MemoryLimit)
+	if capacity == 0 {
+		capacity = float64(c.Node.SystemInfo.Memory.Total / 1024 / 1024)
+	}
+	if capacity == 0 {
+		return float64(c.Allocation.Memory.Allocated)
+	}
+	return float64(c.Allocation.Memory.Allocated) / capacity
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"birdactyl-panel-backend/internal/config"
+	"birdactyl-panel-backend/internal/models"
+)
+
+const (
+	webhookTimeout      = 15 * time.Second
+	webhookMaxRedirects = 3
+)
+
+var (
+	compressFormats  = map[string]bool{"zip": true, "tar": true, "tar.gz": true, "tgz": true}
+	eventNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,64}$`)
+	templateFuncs    = template.FuncMap{"json": templateJSON}
+
+	scheduleEventEmitter func(data map[string]string)
+)
+
+var webhookClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 10 * time.Second, Control: webhookDialControl}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= webhookMaxRedirects {
+			return fmt.Errorf("stopped after %d redirects", webhookMaxRedirects)
+		}
+		return nil
+	},
+}
+
+func SetScheduleEventEmitter(fn func(data map[string]string)) {
+	scheduleEventEmitter = fn
+}
+
+type prunePayload struct {
+	Keep       int `json:"keep"`
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+type compressPayload struct {
+	Paths  []string `json:"paths"`
+	Dest   string   `json:"dest"`
+	Format string   `json:"format"`
+}
+
+type decompressPayload struct {
+	Path string `json:"path"`
+	Dest string `json:"dest"`
+}
+
+type webhookPayload struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+type eventPayload struct {
+	Event string            `json:"event"`
+	Data  map[string]string `json:"data"`
+}
+
+func (c *scheduleTaskContext) templateData() map[string]interface{} {
+	return map[string]interface{}{
+		"ServerID":     c.server.ID.String(),
+		"ServerName":   c.server.Name,
+		"ScheduleID":   c.schedule.ID.String(),
+		"ScheduleName": c.schedule.Name,
+		"Time":         c.startedAt,
+		"Date":         c.startedAt.Format("2006-01-02"),
+		"Timestamp":    c.startedAt.Unix(),
+	}
+}
+
+func (c *scheduleTaskContext) render(text string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("task").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c.templateData()); err != nil {
+		return "", fmt.Errorf("template failed: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func (c *scheduleTaskContext) renderPath(text string) (string, error) {
+	p, err := c.render(text)
+	if err != nil {
+		return "", err
+	}
+	if path.Clean("/"+p) == "/" {
+		return "", fmt.Errorf("path must not be the server root")
+	}
+	return p, nil
+}
+
+func templateJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func validateTemplate(text string) error {
+	if _, err := template.New("task").Funcs(templateFuncs).Parse(text); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
+
+func validatePath(p string) error {
+	if strings.TrimSpace(p) == "" {
+		return fmt.Errorf("path is required")
+	}
+	if path.Clean("/"+p) == "/" {
+		return fmt.Errorf("path must not be the server root")
+	}
+	return validateTemplate(p)
+}
+
+func createScheduledBackup(ctx *scheduleTaskContext, payload string) error {
+	name, err := ctx.render(payload)
+	if err != nil {
+		return err
+	}
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("backup name %q must not contain path separators", name)
+	}
+	if err := CheckBackupLimits(ctx.server); err != nil {
+		return err
+	}
+	_, err = CreateServerBackup(ctx.server, name, nil, false, nil)
+	return err
+}
+
+func parsePrunePayload(payload string) (*prunePayload, error) {
+	var p prunePayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("payload must be JSON with keep and/or max_age_days")
+	}
+	if p.Keep < 0 || p.MaxAgeDays < 0 {
+		return nil, fmt.Errorf("keep and max_age_days must not be negative")
+	}
+	if p.Keep == 0 && p.MaxAgeDays == 0 {
+		return nil, fmt.Errorf("keep or max_age_days is required")
+	}
+	return &p, nil
+}
+
+func pruneBackups(ctx *scheduleTaskContext, payload string) error {
+	p, err := parsePrunePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	backups, err := ListServerBackups(ctx.server.ID)
+	if err != nil {
+		return err
+	}
+
+	completed := make([]models.Backup, 0, len(backups))
+	for _, b := range backups {
+		if b.Status == models.BackupCompleted && !b.Locked {
+			completed = append(completed, b)
+		}
+	}
+	sort.SliceStable(completed, func(i, j int) bool { return completed[i].CreatedAt.After(completed[j].CreatedAt) })
+
+	cutoff := ctx.startedAt.AddDate(0, 0, -p.MaxAgeDays)
+	var firstErr error
+	deleted, failed := 0, 0
+	for i, b := range completed {
+		tooMany := p.Keep > 0 && i >= p.Keep
+		tooOld := p.MaxAgeDays > 0 && b.CreatedAt.Before(cutoff)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := DeleteServerBackup(&b); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Printf("[scheduler] pruned %d backups for server %s", deleted, ctx.server.ID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d backups: %w", failed, failed+deleted, firstErr)
+	}
+	return nil
+}
+
+func parseCompressPayload(payload string) (*compressPayload, error) {
+	var p compressPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("payload must be JSON with paths, dest and format")
+	}
+	if len(p.Paths) == 0 {
+		return nil, fmt.Errorf("at least one path is required")
+	}
+	for _, src := range p.Paths {
+		if err := validateTemplate(src); err != nil {
+			return nil, err
+		}
+	}
+	if err := validatePath(p.Dest); err != nil {
+		return nil, fmt.Errorf("dest: %w", err)
+	}
+	if p.Format == "" {
+		p.Format = "tar.gz"
+	}
+	if !compressFormats[p.Format] {
+		return nil, fmt.Errorf("format must be zip, tar, tar.gz or tgz")
+	}
+	return &p, nil
+}
+
+func compressFiles(ctx *scheduleTaskContext, payload string) error {
+	p, err := parseCompressPayload(payload)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(p.Paths))
+	for _, src := range p.Paths {
+		rendered, err := ctx.render(src)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, rendered)
+	}
+	dest, err := ctx.renderPath(p.Dest)
+	if err != nil {
+		return err
+	}
+	return CompressServerFiles(ctx.server.ID, paths, dest, p.Format)
+}
+
+func parseDecompressPayload(payload string) (*decompressPayload, error) {
+	var p decompressPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("payload must be JSON with path and dest")
+	}
+	if err := validatePath(p.Path); err != nil {
+		return nil, err
+	}
+	if p.Dest == "" {
+		p.Dest = "/"
+	}
+	if err := validateTemplate(p.Dest); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func decompressFile(ctx *scheduleTaskContext, payload string) error {
+	p, err := parseDecompressPayload(payload)
+	if err != nil {
+		return err
+	}
+	src, err := ctx.renderPath(p.Path)
+	if err != nil {
+		return err
+	}
+	dest, err := ctx.render(p.Dest)
+	if err != nil {
+		return err
+	}
+	return DecompressServerFile(ctx.server.ID, src, dest)
+}
+
+func parseWebhookPayload(payload string) (*webhookPayload, error) {
+	var p webhookPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("payload must be JSON with url, headers and body")
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url must be an absolute http or https URL")
+	}
+	if err := validateTemplate(p.Body); err != nil {
+		return nil, err
+	}
+	for _, v := range p.Headers {
+		if err := validateTemplate(v); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func sendWebhook(ctx *scheduleTaskContext, payload string) error {
+	p, err := parseWebhookPayload(payload)
+	if err != nil {
+		return err
+	}
+	body, err := ctx.render(p.Body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", p.URL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Birdactyl-Scheduler")
+	for k, v := range p.Headers {
+		rendered, err := ctx.render(v)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(k, rendered)
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	if cfg := config.Get(); cfg != nil && cfg.Schedules.AllowPrivateWebhooks {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("webhook address %s is not allowed", host)
+	}
+	return nil
+}
+
+func parseEventPayload(payload string) (*eventPayload, error) {
+	var p eventPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("payload must be JSON with event and data")
+	}
+	if !eventNamePattern.MatchString(p.Event) {
+		return nil, fmt.Errorf("event name must be 1-64 letters, digits or _.:-")
+	}
+	for _, v := range p.Data {
+		if err := validateTemplate(v); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func emitScheduleEvent(ctx *scheduleTaskContext, payload string) error {
+	p, err := parseEventPayload(payload)
+	if err != nil {
+		return err
+	}
+	if scheduleEventEmitter == nil {
+		return fmt.Errorf("plugin events are not available")
+	}
+
+	data := make(map[string]string, len(p.Data)+4)
+	for k, v := range p.Data {
+		rendered, err := ctx.render(v)
+		if err != nil {
+			return err
+		}
+		data[k] = rendered
+	}
+	data["event"] = p.Event
+	data["server_id"] = ctx.server.ID.String()
+	data["schedule_id"] = ctx.schedule.ID.String()
+	data["schedule_name"] = ctx.schedule.Name
+
+	scheduleEventEmitter(data)
+	return nil
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	scheduleConsoleLines       = 100
+	scheduleStatePollInterval  = 2 * time.Second
+	scheduleWaitDefaultTimeout = 300
+	scheduleWaitMaxTimeout     = 3600
+)
+
+var scheduleStates = map[string]bool{"running": true, "stopped": true, "offline": true}
+
+type conditionError struct {
+	reason string
+}
+
+func (e *conditionError) Error() string {
+	return "condition not met: " + e.reason
+}
+
+type scheduleTaskContext struct {
+	schedule  *models.Schedule
+	server    *models.Server
+	startedAt time.Time
+}
+
+func executeTask(ctx *scheduleTaskContext, task models.ScheduleTask) error {
+	serverID := ctx.server.ID
+	switch task.Action {
+	case "command":
+		return SendCommand(serverID, task.Payload)
+	case "power":
+		switch task.Payload {
+		case "start":
+			return SendStartServer(serverID)
+		case "stop":
+			return SendStopServer(serverID)
+		case "restart":
+			return SendRestartServer(serverID)
+		case "kill":
+			return SendKillServer(serverID)
+		}
+	case "delay":
+		var seconds int
+		fmt.Sscanf(task.Payload, "%d", &seconds)
+		if seconds > 0 && seconds <= 300 {
+			time.Sleep(time.Duration(seconds) * time.Second)
+		}
+	case "backup":
+		return createScheduledBackup(ctx, task.Payload)
+	case "prune_backups":
+		return pruneBackups(ctx, task.Payload)
+	case "delete_file":
+		path, err := ctx.renderPath(task.Payload)
+		if err != nil {
+			return err
+		}
+		return DeleteServerFile(serverID, path)
+	case "truncate_file":
+		path, err := ctx.renderPath(task.Payload)
+		if err != nil {
+			return err
+		}
+		return WriteServerFile(serverID, path, "")
+	case "compress":
+		return compressFiles(ctx, task.Payload)
+	case "decompress":
+		return decompressFile(ctx, task.Payload)
+	case "webhook":
+		return sendWebhook(ctx, task.Payload)
+	case "emit_event":
+		return emitScheduleEvent(ctx, task.Payload)
+	case "if_state":
+		return checkStateCondition(serverID, task.Payload)
+	case "if_cpu_above":
+		return checkCPUCondition(serverID, task.Payload)
+	case "if_console_match":
+		return checkConsoleCondition(serverID, task.Payload)
+	case "wait_for_state":
+		return waitForState(serverID, task.Payload)
+	}
+	return nil
+}
+
+func ValidateScheduleTasks(tasks []models.ScheduleTask) error {
+	for _, task := range tasks {
+		if err := validateScheduleTask(task); err != nil {
+			return fmt.Errorf("task %d (%s): %w", task.Sequence, task.Action, err)
+		}
+	}
+	return nil
+}
+
+func validateScheduleTask(task models.ScheduleTask) error {
+	switch task.Action {
+	case "command":
+		if strings.TrimSpace(task.Payload) == "" {
+			return fmt.Errorf("command is required")
+		}
+	case "power":
+		switch task.Payload {
+		case "start", "stop", "restart", "kill":
+		default:
+			return fmt.Errorf("power action must be start, stop, restart or kill")
+		}
+	case "delay":
+		seconds, err := strconv.Atoi(task.Payload)
+		if err != nil || seconds <= 0 || seconds > 300 {
+			return fmt.Errorf("delay must be between 1 and 300 seconds")
+		}
+	case "backup":
+		return validateTemplate(task.Payload)
+	case "prune_backups":
+		_, err := parsePrunePayload(task.Payload)
+		return err
+	case "delete_file", "truncate_file":
+		return validatePath(task.Payload)
+	case "compress":
+		_, err := parseCompressPayload(task.Payload)
+		return err
+	case "decompress":
+		_, err := parseDecompressPayload(task.Payload)
+		return err
+	case "webhook":
+		_, err := parseWebhookPayload(task.Payload)
+		return err
+	case "emit_event":
+		_, err := parseEventPayload(task.Payload)
+		return err
+	case "if_state":
+		_, err := parseStateList(task.Payload)
+		return err
+	case "if_cpu_above":
+		if _, err := strconv.ParseFloat(task.Payload, 64); err != nil {
+			return fmt.Errorf("cpu threshold must be a number")
+		}
+	case "if_console_match":
+		if _, err := regexp.Compile(task.Payload); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	case "wait_for_state":
+		_, _, err := parseWaitPayload(task.Payload)
+		return err
+	default:
+		return fmt.Errorf("unknown action")
+	}
+	return nil
+}
+
+func parseStateList(payload string) ([]string, error) {
+	var states []string
+	for _, s := range strings.Split(payload, ",") {
+		s = strings.TrimSpace(strings.ToLower(s))
+		if s == "" {
+			continue
+		}
+		if !scheduleStates[s] {
+			return nil, fmt.Errorf("unknown state %q", s)
+		}
+		states = append(states, s)
+	}
+	if len(states) == 0 {
+		return nil, fmt.Errorf("at least one state is required")
+	}
+	return states, nil
+}
+
+func parseWaitPayload(payload string) (string, time.Duration, error) {
+	state, timeoutStr, hasTimeout := strings.Cut(payload, ":")
+	state = strings.TrimSpace(strings.ToLower(state))
+	if state != "running" && state != "stopped" {
+		return "", 0, fmt.Errorf("state must be running or stopped")
+	}
+
+	timeout := scheduleWaitDefaultTimeout
+	if hasTimeout {
+		t, err := strconv.Atoi(strings.TrimSpace(timeoutStr))
+		if err != nil || t <= 0 || t > scheduleWaitMaxTimeout {
+			return "", 0, fmt.Errorf("timeout must be between 1 and %d seconds", scheduleWaitMaxTimeout)
+		}
+		timeout = t
+	}
+	return state, time.Duration(timeout) * time.Second, nil
+}
+
+func stateMatches(want, actual string) bool {
+	if want == "stopped" {
+		return actual == "stopped" || actual == "offline"
+	}
+	return want == actual
+}
+
+func checkStateCondition(serverID uuid.UUID, payload string) error {
+	states, err := parseStateList(payload)
+	if err != nil {
+		return err
+	}
+	stats := GetServerStats(serverID)
+	if stats == nil {
+		return fmt.Errorf("failed to fetch server state")
+	}
+	for _, s := range states {
+		if stateMatches(s, stats.State) {
+			return nil
+		}
+	}
+	return &conditionError{reason: fmt.Sprintf("server is %s", stats.State)}
+}
+
+func checkCPUCondition(serverID uuid.UUID, payload string) error {
+	threshold, err := strconv.ParseFloat(payload, 64)
+	if err != nil {
+		return fmt.Errorf("invalid cpu threshold %q", payload)
+	}
+	stats := GetServerStats(serverID)
+	if stats == nil {
+		return fmt.Errorf("failed to fetch server stats")
+	}
+	if stats.CPUPercent <= threshold {
+		return &conditionError{reason: fmt.Sprintf("cpu at %.1f%%", stats.CPUPercent)}
+	}
+	return nil
+}
+
+func checkConsoleCondition(serverID uuid.UUID, payload string) error {
+	re, err := regexp.Compile(payload)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	for _, line := range GetConsoleLog(serverID, scheduleConsoleLines) {
+		if re.MatchString(line) {
+			return nil
+		}
+	}
+	return &conditionError{reason: "no console line matched"}
+}
+
+func waitForState(serverID uuid.UUID, payload string) error {
+	state, timeout, err := parseWaitPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	last := "unknown"
+	for {
+		if stats := GetServerStats(serverID); stats != nil {
+			last = stats.State
+			if stateMatches(state, stats.State) {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s (last state: %s)", timeout, state, last)
+		}
+		time.Sleep(scheduleStatePollInterval)
+	}
+}
@@ -2,164 +2,474 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
+	"birdactyl-panel-backend/internal/config"
 	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/metrics"
 	"birdactyl-panel-backend/internal/models"
 
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 )
 
+const (
+	scheduleRunHistoryLimit   = 50
+	scheduleLeaseTTL          = 60 * time.Second
+	scheduleMisfireGrace      = 10 * time.Second
+	scheduleMisfireThreshold  = time.Minute
+	scheduleMisfireMaxRuns    = 10
+	scheduleMisfireCountLimit = 1000
+)
+
 var (
 	scheduler     *cron.Cron
 	schedulerOnce sync.Once
-	entryMap      = make(map[uuid.UUID]cron.EntryID)
+	schedulerStop chan struct{}
+	entryMap      = make(map[uuid.UUID]scheduleEntry)
 	entryMapMu    sync.RWMutex
+
+	cronParser        = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	schedulerInstance = uuid.New().String()
 )
 
+type scheduleEntry struct {
+	id       cron.EntryID
+	spec     string
+	timezone string
+}
+
 func InitScheduler() {
 	schedulerOnce.Do(func() {
-		scheduler = cron.New(cron.WithSeconds())
+		scheduler = cron.New(cron.WithParser(cronParser))
 		scheduler.Start()
-		log.Println("[scheduler] started")
-		go loadExistingSchedules()
+		schedulerStop = make(chan struct{})
+		log.Printf("[scheduler] started (instance %s)", schedulerInstance)
+		go reconcileLoop(schedulerStop)
 	})
 }
 
 func StopScheduler() {
 	if scheduler != nil {
+		close(schedulerStop)
 		scheduler.Stop()
 	}
 }
 
-func loadExistingSchedules() {
+func reconcileLoop(stop chan struct{}) {
+	ReconcileSchedules()
+
+	interval := 30 * time.Second
+	if cfg := config.Get(); cfg != nil && cfg.Schedules.ReconcileInterval > 0 {
+		interval = time.Duration(cfg.Schedules.ReconcileInterval) * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ReconcileSchedules()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func ReconcileSchedules() {
 	var schedules []models.Schedule
-	database.DB.Where("is_active = ?", true).Find(&schedules)
-	for _, s := range schedules {
-		RegisterSchedule(&s)
+	if err := database.DB.Where("is_active = ?", true).Find(&schedules).Error; err != nil {
+		log.Printf("[scheduler] reconcile failed: %v", err)
+		return
+	}
+
+	active := make(map[uuid.UUID]bool, len(schedules))
+	cutoff := time.Now().Add(-scheduleMisfireGrace)
+	for i := range schedules {
+		s := &schedules[i]
+		active[s.ID] = true
+		if err := registerSchedule(s); err != nil {
+			log.Printf("[scheduler] schedule %s cannot be registered: %v", s.ID, err)
+			continue
+		}
+		if s.NextRunAt == nil || s.NextRunAt.Before(cutoff) {
+			go processDueSchedule(s.ID)
+		}
+	}
+
+	var stale []uuid.UUID
+	entryMapMu.RLock()
+	for id := range entryMap {
+		if !active[id] {
+			stale = append(stale, id)
+		}
+	}
+	entryMapMu.RUnlock()
+	for _, id := range stale {
+		UnregisterSchedule(id)
 	}
 }
 
-func RegisterSchedule(s *models.Schedule) error {
-	if scheduler == nil || !s.IsActive {
+func ValidateCronExpression(expr string) error {
+	if _, err := cronParser.Parse(expr); err != nil {
+		return fmt.Errorf("invalid cron expression: %w", err)
+	}
+	return nil
+}
+
+func ValidateTimezone(tz string) error {
+	if tz == "" {
 		return nil
 	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fmt.Errorf("unknown timezone %q", tz)
+	}
+	return nil
+}
 
-	entryMapMu.Lock()
-	if oldID, exists := entryMap[s.ID]; exists {
-		scheduler.Remove(oldID)
+func parseSchedule(s *models.Schedule) (cron.Schedule, error) {
+	sched, err := cronParser.Parse(s.CronExpression)
+	if err != nil {
+		return nil, err
+	}
+	if spec, ok := sched.(*cron.SpecSchedule); ok && s.Timezone != "" {
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", s.Timezone)
+		}
+		spec.Location = loc
 	}
-	entryMapMu.Unlock()
+	return sched, nil
+}
 
-	entryID, err := scheduler.AddFunc(s.CronExpression, func() {
-		executeSchedule(s.ID)
-	})
+func NextScheduleRun(s *models.Schedule, after time.Time) (time.Time, error) {
+	sched, err := parseSchedule(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(after).In(s.Location()), nil
+}
+
+func ValidateMisfirePolicy(policy models.MisfirePolicy) error {
+	switch policy {
+	case "", models.MisfireSkip, models.MisfireRunOnce, models.MisfireRunAll:
+		return nil
+	}
+	return fmt.Errorf("misfire_policy must be skip, run_once or run_all")
+}
+
+func RegisterSchedule(s *models.Schedule) error {
+	if !s.IsActive {
+		return nil
+	}
+	next, err := NextScheduleRun(s, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := registerSchedule(s); err != nil {
+		return err
+	}
+
+	s.NextRunAt = &next
+	return database.DB.Model(&models.Schedule{}).Where("id = ?", s.ID).UpdateColumn("next_run_at", next).Error
+}
+
+func registerSchedule(s *models.Schedule) error {
+	if scheduler == nil || !s.IsActive {
+		return nil
+	}
+	sched, err := parseSchedule(s)
 	if err != nil {
 		return err
 	}
 
 	entryMapMu.Lock()
-	entryMap[s.ID] = entryID
-	entryMapMu.Unlock()
-
-	entry := scheduler.Entry(entryID)
-	nextRun := entry.Next
-	database.DB.Model(&models.Schedule{}).Where("id = ?", s.ID).Update("next_run_at", nextRun)
+	defer entryMapMu.Unlock()
+	if old, exists := entryMap[s.ID]; exists {
+		if old.spec == s.CronExpression && old.timezone == s.Timezone {
+			return nil
+		}
+		scheduler.Remove(old.id)
+	}
 
+	id := s.ID
+	entryID := scheduler.Schedule(sched, cron.FuncJob(func() {
+		processDueSchedule(id)
+	}))
+	entryMap[s.ID] = scheduleEntry{id: entryID, spec: s.CronExpression, timezone: s.Timezone}
 	return nil
 }
 
 func UnregisterSchedule(id uuid.UUID) {
 	entryMapMu.Lock()
 	defer entryMapMu.Unlock()
-	if entryID, exists := entryMap[id]; exists {
-		scheduler.Remove(entryID)
+	if entry, exists := entryMap[id]; exists {
+		scheduler.Remove(entry.id)
 		delete(entryMap, id)
 	}
 }
 
-func executeSchedule(scheduleID uuid.UUID) {
+func processDueSchedule(scheduleID uuid.UUID) {
+	if !acquireScheduleLease(scheduleID) {
+		return
+	}
+	stopRenew := make(chan struct{})
+	go renewScheduleLease(scheduleID, stopRenew)
+	defer func() {
+		close(stopRenew)
+		releaseScheduleLease(scheduleID)
+	}()
+
 	var schedule models.Schedule
-	if err := database.DB.First(&schedule, "id = ?", scheduleID).Error; err != nil {
+	if err := database.DB.First(&schedule, "id = ?", scheduleID).Error; err != nil || !schedule.IsActive {
+		return
+	}
+	sched, err := parseSchedule(&schedule)
+	if err != nil {
 		return
 	}
 
-	if !schedule.IsActive {
+	now := time.Now()
+	if schedule.NextRunAt != nil && schedule.NextRunAt.After(now) {
+		return
+	}
+	database.DB.Model(&models.Schedule{}).Where("id = ?", scheduleID).UpdateColumn("next_run_at", sched.Next(now))
+	if schedule.NextRunAt == nil {
 		return
 	}
 
+	missed := countDueRuns(sched, *schedule.NextRunAt, now)
+	if missed == 1 && now.Sub(*schedule.NextRunAt) < scheduleMisfireThreshold {
+		runSchedule(&schedule, models.ScheduleTriggerCron)
+		return
+	}
+
+	policy := scheduleMisfirePolicy(&schedule)
+	log.Printf("[scheduler] schedule %s missed %d runs since %s (policy %s)", scheduleID, missed, schedule.NextRunAt.Format(time.RFC3339), policy)
+
+	switch policy {
+	case models.MisfireSkip:
+		recordSkippedRun(&schedule, models.ScheduleTriggerCatchUp, fmt.Sprintf("missed %d runs (misfire policy: skip)", missed))
+	case models.MisfireRunAll:
+		runs := missed
+		if runs > scheduleMisfireMaxRuns {
+			runs = scheduleMisfireMaxRuns
+		}
+		for i := 0; i < runs; i++ {
+			runSchedule(&schedule, models.ScheduleTriggerCatchUp)
+		}
+	default:
+		runSchedule(&schedule, models.ScheduleTriggerCatchUp)
+	}
+}
+
+func countDueRuns(sched cron.Schedule, from, now time.Time) int {
+	count := 0
+	for t := from; !t.After(now) && count < scheduleMisfireCountLimit; t = sched.Next(t) {
+		count++
+	}
+	return count
+}
+
+func scheduleMisfirePolicy(s *models.Schedule) models.MisfirePolicy {
+	policy := s.MisfirePolicy
+	if policy == "" {
+		if cfg := config.Get(); cfg != nil {
+			policy = models.MisfirePolicy(cfg.Schedules.MisfirePolicy)
+		}
+	}
+	if policy == "" || ValidateMisfirePolicy(policy) != nil {
+		return models.MisfireRunOnce
+	}
+	return policy
+}
+
+func acquireScheduleLease(scheduleID uuid.UUID) bool {
+	now := time.Now()
+	result := database.DB.Model(&models.Schedule{}).
+		Where("id = ? AND (lease_owner IS NULL OR lease_owner = '' OR lease_expires_at IS NULL OR lease_expires_at < ?)", scheduleID, now).
+		UpdateColumns(map[string]interface{}{
+			"lease_owner":      schedulerInstance,
+			"lease_expires_at": now.Add(scheduleLeaseTTL),
+		})
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+func renewScheduleLease(scheduleID uuid.UUID, stop chan struct{}) {
+	ticker := time.NewTicker(scheduleLeaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			database.DB.Model(&models.Schedule{}).
+				Where("id = ? AND lease_owner = ?", scheduleID, schedulerInstance).
+				UpdateColumn("lease_expires_at", time.Now().Add(scheduleLeaseTTL))
+		case <-stop:
+			return
+		}
+	}
+}
+
+func releaseScheduleLease(scheduleID uuid.UUID) {
+	database.DB.Model(&models.Schedule{}).
+		Where("id = ? AND lease_owner = ?", scheduleID, schedulerInstance).
+		UpdateColumns(map[string]interface{}{"lease_owner": "", "lease_expires_at": nil})
+}
+
+func recordSkippedRun(schedule *models.Schedule, trigger models.ScheduleTrigger, reason string) {
+	run := &models.ScheduleRun{
+		ScheduleID: schedule.ID,
+		ServerID:   schedule.ServerID,
+		Trigger:    trigger,
+		Status:     models.ScheduleRunRunning,
+		StartedAt:  time.Now(),
+	}
+	database.DB.Create(run)
+	finishScheduleRun(run, nil, models.ScheduleRunSkipped, reason)
+}
+
+func runSchedule(schedule *models.Schedule, trigger models.ScheduleTrigger) {
+	scheduleID := schedule.ID
+	run := &models.ScheduleRun{
+		ScheduleID: schedule.ID,
+		ServerID:   schedule.ServerID,
+		Trigger:    trigger,
+		Status:     models.ScheduleRunRunning,
+		StartedAt:  time.Now(),
+	}
+	database.DB.Create(run)
+
 	var server models.Server
 	if err := database.DB.First(&server, "id = ?", schedule.ServerID).Error; err != nil {
+		finishScheduleRun(run, nil, models.ScheduleRunFailed, "server not found")
 		return
 	}
 
 	if schedule.OnlyWhenOnline {
 		stats := GetServerStats(schedule.ServerID)
 		if stats == nil || stats.State != "running" {
-			updateNextRun(scheduleID)
+			finishScheduleRun(run, nil, models.ScheduleRunSkipped, "server is not running")
 			return
 		}
 	}
 
 	var tasks []models.ScheduleTask
 	json.Unmarshal(schedule.Tasks, &tasks)
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Sequence < tasks[j].Sequence })
 
+	ctx := &scheduleTaskContext{schedule: schedule, server: &server, startedAt: run.StartedAt}
+	results := make([]models.ScheduleTaskResult, 0, len(tasks))
+	failed := 0
+	halted := false
 	for _, task := range tasks {
-		executeTask(schedule.ServerID, task)
-	}
-
-	now := time.Now()
-	database.DB.Model(&schedule).Update("last_run_at", now)
-	updateNextRun(scheduleID)
-}
-
-func executeTask(serverID uuid.UUID, task models.ScheduleTask) error {
-	switch task.Action {
-	case "command":
-		return SendCommand(serverID, task.Payload)
-	case "power":
-		switch task.Payload {
-		case "start":
-			return SendStartServer(serverID)
-		case "stop":
-			return SendStopServer(serverID)
-		case "restart":
-			return SendRestartServer(serverID)
-		case "kill":
-			return SendKillServer(serverID)
+		result := models.ScheduleTaskResult{
+			Sequence:  task.Sequence,
+			Action:    task.Action,
+			Payload:   task.Payload,
+			StartedAt: time.Now(),
 		}
-	case "delay":
-		var seconds int
-		fmt.Sscanf(task.Payload, "%d", &seconds)
-		if seconds > 0 && seconds <= 300 {
-			time.Sleep(time.Duration(seconds) * time.Second)
+		if halted {
+			result.Skipped = true
+			result.FinishedAt = result.StartedAt
+			results = append(results, result)
+			continue
 		}
-	case "backup":
-		return CreateServerArchive(serverID)
+
+		err := executeTask(ctx, task)
+		result.FinishedAt = time.Now()
+
+		var condErr *conditionError
+		switch {
+		case errors.As(err, &condErr):
+			result.Success = true
+			result.Message = condErr.Error()
+			result.Halted = true
+			halted = true
+		case err != nil:
+			result.Error = err.Error()
+			failed++
+			log.Printf("[scheduler] schedule %s task %d (%s) failed: %v", scheduleID, task.Sequence, task.Action, err)
+			if !task.ContinueOnFailure {
+				result.Halted = true
+				halted = true
+			}
+		default:
+			result.Success = true
+		}
+		results = append(results, result)
 	}
-	return nil
+
+	if failed > 0 {
+		finishScheduleRun(run, results, models.ScheduleRunFailed, fmt.Sprintf("%d of %d tasks failed", failed, len(tasks)))
+	} else {
+		finishScheduleRun(run, results, models.ScheduleRunSuccess, "")
+	}
+
+	now := time.Now()
+	database.DB.Model(&models.Schedule{}).Where("id = ?", scheduleID).UpdateColumn("last_run_at", now)
 }
 
-func updateNextRun(scheduleID uuid.UUID) {
-	entryMapMu.RLock()
-	entryID, exists := entryMap[scheduleID]
-	entryMapMu.RUnlock()
+func finishScheduleRun(run *models.ScheduleRun, results []models.ScheduleTaskResult, status models.ScheduleRunStatus, errMsg string) {
+	if results == nil {
+		results = []models.ScheduleTaskResult{}
+	}
+	resultsJSON, _ := json.Marshal(results)
+	now := time.Now()
+
+	run.Status = status
+	run.Error = errMsg
+	run.Results = resultsJSON
+	run.FinishedAt = &now
+	database.DB.Model(run).Updates(map[string]interface{}{
+		"status":      status,
+		"error":       errMsg,
+		"results":     resultsJSON,
+		"finished_at": now,
+	})
 
-	if exists && scheduler != nil {
-		entry := scheduler.Entry(entryID)
-		database.DB.Model(&models.Schedule{}).Where("id = ?", scheduleID).Update("next_run_at", entry.Next)
+	metrics.ScheduleRuns.Inc(string(status), string(run.Trigger))
+	metrics.ScheduleRunDuration.Observe(now.Sub(run.StartedAt).Seconds(), string(status))
+	pruneScheduleRuns(run.ScheduleID)
+}
+
+func pruneScheduleRuns(scheduleID uuid.UUID) {
+	var stale []uuid.UUID
+	database.DB.Model(&models.ScheduleRun{}).
+		Where("schedule_id = ?", scheduleID).
+		Order("started_at desc").
+		Offset(scheduleRunHistoryLimit).
+		Pluck("id", &stale)
+	if len(stale) > 0 {
+		database.DB.Delete(&models.ScheduleRun{}, "id IN ?", stale)
 	}
 }
 
 func RunScheduleNow(scheduleID uuid.UUID) error {
-	go executeSchedule(scheduleID)
+	var schedule models.Schedule
+	if err := database.DB.First(&schedule, "id = ?", scheduleID).Error; err != nil {
+		return err
+	}
+	if !schedule.IsActive {
+		return nil
+	}
+	go runSchedule(&schedule, models.ScheduleTriggerManual)
 	return nil
 }
 
+func GetScheduleRuns(scheduleID uuid.UUID, limit int) ([]models.ScheduleRun, error) {
+	if limit <= 0 || limit > scheduleRunHistoryLimit {
+		limit = scheduleRunHistoryLimit
+	}
+	var runs []models.ScheduleRun
+	err := database.DB.Where("schedule_id = ?", scheduleID).Order("started_at desc").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
 func GetSchedulesByServer(serverID uuid.UUID) ([]models.Schedule, error) {
 	var schedules []models.Schedule
 	err := database.DB.Where("server_id = ?", serverID).Order("created_at desc").Find(&schedules).Error
@@ -207,5 +517,6 @@ func UpdateSchedule(id uuid.UUID, updates map[string]interface{}) (*models.Sched
 
 func DeleteSchedule(id uuid.UUID) error {
 	UnregisterSchedule(id)
+	database.DB.Delete(&models.ScheduleRun{}, "schedule_id = ?", id)
 	return database.DB.Delete(&models.Schedule{}, "id = ?", id).Error
 }
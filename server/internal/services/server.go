@@ -52,6 +52,8 @@ type CreateServerRequest struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description"`
 	NodeID      uuid.UUID         `json:"node_id"`
+	LocationID  *uuid.UUID        `json:"location_id"`
+	Strategy    string            `json:"strategy"`
 	PackageID   uuid.UUID         `json:"package_id"`
 	Memory      int               `json:"memory"`
 	CPU         int               `json:"cpu"`
@@ -60,10 +62,12 @@ type CreateServerRequest struct {
 	Variables   map[string]string `json:"variables"`
 }
 
-func allocatePort(nodeID uuid.UUID) int {
-	portAllocationMu.Lock()
-	defer portAllocationMu.Unlock()
+const (
+	portRangeStart = 25565
+	portRangeEnd   = 30000
+)
 
+func usedNodePorts(nodeID uuid.UUID) map[int]bool {
 	var servers []models.Server
 	database.DB.Where("node_id = ?", nodeID).Find(&servers)
 	
@@ -75,22 +79,89 @@ func allocatePort(nodeID uuid.UUID) int {
 			usedPorts[p.Port] = true
 		}
 	}
-	
+	return usedPorts
+}
+
+func freeNodePorts(nodeID uuid.UUID) int {
+	if nodeHasAllocationPool(nodeID) {
+		return freeNodeAllocations(nodeID)
+	}
+	free := portRangeEnd - portRangeStart + 1
+	for port := range usedNodePorts(nodeID) {
+		if port >= portRangeStart && port <= portRangeEnd {
+			free--
+		}
+	}
+	return free
+}
+
+func pickFreePort(usedPorts map[int]bool) int {
 	for attempts := 0; attempts < 1000; attempts++ {
-		port := 25565 + rand.Intn(4436)
+		port := portRangeStart + rand.Intn(portRangeEnd-portRangeStart+1)
 		if !usedPorts[port] {
 			return port
 		}
 	}
-	for port := 25565; port <= 30000; port++ {
+	for port := portRangeStart; port <= portRangeEnd; port++ {
 		if !usedPorts[port] {
 			return port
 		}
 	}
-	return 25565
+	return portRangeStart
+}
+
+// assignNodePorts fills in host ports for a server. Nodes with an allocation
+// pool hand out their free allocations; nodes without one fall back to random
+// ports in the default range bound on every interface.
+func assignNodePorts(nodeID, serverID uuid.UUID, ports []models.ServerPort) ([]models.ServerPort, error) {
+	portAllocationMu.Lock()
+	defer portAllocationMu.Unlock()
+
+	if !nodeHasAllocationPool(nodeID) {
+		usedPorts := usedNodePorts(nodeID)
+		for i := range ports {
+			ports[i].Port = pickFreePort(usedPorts)
+			ports[i].IP = ""
+			ports[i].Alias = ""
+			usedPorts[ports[i].Port] = true
+		}
+		return ports, nil
+	}
+
+	allocations, err := reserveAllocations(nodeID, serverID, len(ports))
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range allocations {
+		ports[i].Port = a.Port
+		ports[i].IP = a.IP
+		ports[i].Alias = a.Alias
+	}
+	return ports, nil
 }
 
 func CreateServer(userID uuid.UUID, req CreateServerRequest) (*models.Server, error) {
+	var pkg models.Package
+	if err := database.DB.Where("id = ?", req.PackageID).First(&pkg).Error; err != nil {
+		return nil, ErrPackageNotFound
+	}
+
+	if req.NodeID == uuid.Nil && req.LocationID != nil {
+		nodeID, err := PlaceServer(PlacementRequest{
+			LocationID: *req.LocationID,
+			Package:    &pkg,
+			Memory:     req.Memory,
+			CPU:        req.CPU,
+			Disk:       req.Disk,
+			Ports:      len(req.Ports),
+			Strategy:   req.Strategy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		req.NodeID = nodeID
+	}
+
 	var node models.Node
 	if err := database.DB.Where("id = ?", req.NodeID).First(&node).Error; err != nil {
 		return nil, ErrNodeNotFound
@@ -98,20 +169,27 @@ func CreateServer(userID uuid.UUID, req CreateServerRequest) (*models.Server, er
 	if !node.IsOnline {
 		return nil, ErrNodeOffline
 	}
-
-	var pkg models.Package
-	if err := database.DB.Where("id = ?", req.PackageID).First(&pkg).Error; err != nil {
-		return nil, ErrPackageNotFound
+	if !PackageAllowsLocation(&pkg, node.LocationID) {
+		return nil, ErrLocationNotAllowed
+	}
+	if _, err := CheckNodeCapacity(&node, req.Memory, req.CPU, req.Disk, uuid.Nil); err != nil {
+		return nil, err
+	}
+	if freeNodePorts(node.ID) < len(req.Ports) {
+		return nil, ErrNoFreePorts
 	}
 
-	for i := range req.Ports {
-		req.Ports[i].Port = allocatePort(req.NodeID)
+	serverID := uuid.New()
+	ports, err := assignNodePorts(req.NodeID, serverID, req.Ports)
+	if err != nil {
+		return nil, err
 	}
 
-	portsJSON, _ := json.Marshal(req.Ports)
+	portsJSON, _ := json.Marshal(ports)
 	varsJSON, _ := json.Marshal(req.Variables)
 
 	server := &models.Server{
+		ID:          serverID,
 		Name:        req.Name,
 		Description: req.Description,
 		UserID:      userID,
@@ -126,6 +204,7 @@ func CreateServer(userID uuid.UUID, req CreateServerRequest) (*models.Server, er
 	}
 
 	if err := database.DB.Create(server).Error; err != nil {
+		releaseServerAllocations(serverID)
 		return nil, err
 	}
 
@@ -217,6 +296,10 @@ func DeleteServer(serverID, userID uuid.UUID, isAdmin bool) error {
 	database.DB.Where("server_id = ?", serverID).Delete(&models.ServerDatabase{})
 	database.DB.Where("server_id = ?", serverID).Delete(&models.Subuser{})
 	database.DB.Where("server_id = ?", serverID).Delete(&models.Schedule{})
+	database.DB.Where("server_id = ?", serverID).Delete(&models.Backup{})
+	database.DB.Where("server_id = ?", serverID).Delete(&models.ScheduleRun{})
+	database.DB.Where("server_id = ?", serverID).Delete(&models.ServerCrash{})
+	releaseServerAllocations(serverID)
 	
 	result := database.DB.Where("id = ?", serverID).Delete(&models.Server{})
 	return result.Error
@@ -231,13 +314,17 @@ func AddAllocation(serverID, userID uuid.UUID, isAdmin bool) (*models.Server, er
 	var ports []models.ServerPort
 	json.Unmarshal(server.Ports, &ports)
 
-	newPort := allocatePort(server.NodeID)
-	ports = append(ports, models.ServerPort{Port: newPort, Primary: false})
+	newPorts, err := assignNodePorts(server.NodeID, server.ID, []models.ServerPort{{}})
+	if err != nil {
+		return nil, err
+	}
+	ports = append(ports, newPorts...)
 
 	portsJSON, _ := json.Marshal(ports)
 	server.Ports = portsJSON
 
 	if err := database.DB.Save(server).Error; err != nil {
+		releaseAllocation(server.ID, newPorts[0].IP, newPorts[0].Port)
 		return nil, err
 	}
 
@@ -301,6 +388,7 @@ func DeleteAllocation(serverID, userID uuid.UUID, port int, isAdmin bool) (*mode
 		return nil, errors.New("port not found")
 	}
 
+	removed := ports[idx]
 	ports = append(ports[:idx], ports[idx+1:]...)
 
 	portsJSON, _ := json.Marshal(ports)
@@ -309,54 +397,84 @@ func DeleteAllocation(serverID, userID uuid.UUID, port int, isAdmin bool) (*mode
 	if err := database.DB.Save(server).Error; err != nil {
 		return nil, err
 	}
+	releaseAllocation(server.ID, removed.IP, removed.Port)
 
 	return server, nil
 }
 
-func UpdateServerResources(serverID, userID uuid.UUID, memory, cpu, disk int, isAdmin bool) (*models.Server, error) {
+// UpdateServerResources resizes a server the user can access. A value of 0
+// keeps the current amount. The returned warnings come from the node capacity
+// check.
+func UpdateServerResources(serverID, userID uuid.UUID, memory, cpu, disk int, isAdmin bool) (*models.Server, []string, error) {
 	server, err := GetServerByID(serverID, userID, isAdmin)
 	if err != nil {
-		return nil, err
-	}
-
-	if memory > 0 {
-		server.Memory = memory
-	}
-	if cpu > 0 {
-		server.CPU = cpu
+		return nil, nil, err
 	}
-	if disk > 0 {
-		server.Disk = disk
-	}
-
-	if err := database.DB.Save(server).Error; err != nil {
-		return nil, err
+	warnings, err := resizeServer(server, memory, cpu, disk)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return server, nil
+	return server, warnings, nil
 }
 
-func UpdateServerResourcesAdmin(serverID uuid.UUID, memory, cpu, disk int) (*models.Server, error) {
+func UpdateServerResourcesAdmin(serverID uuid.UUID, memory, cpu, disk int) (*models.Server, []string, error) {
 	var server models.Server
 	if err := database.DB.Preload("Node").Preload("Package").Where("id = ?", serverID).First(&server).Error; err != nil {
-		return nil, ErrServerNotFound
+		return nil, nil, ErrServerNotFound
+	}
+	warnings, err := resizeServer(&server, memory, cpu, disk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &server, warnings, nil
+}
+
+func resizeServer(server *models.Server, memory, cpu, disk int) ([]string, error) {
+	warnings, err := CheckServerResize(server, memory, cpu, disk)
+	if err != nil {
+		return nil, err
 	}
 
+	updates := map[string]interface{}{}
 	if memory > 0 {
 		server.Memory = memory
+		updates["memory"] = memory
 	}
 	if cpu > 0 {
 		server.CPU = cpu
+		updates["cpu"] = cpu
 	}
 	if disk > 0 {
 		server.Disk = disk
+		updates["disk"] = disk
+	}
+	if len(updates) == 0 {
+		return warnings, nil
 	}
 
-	if err := database.DB.Save(&server).Error; err != nil {
+	if err := database.DB.Model(&models.Server{}).Where("id = ?", server.ID).Updates(updates).Error; err != nil {
 		return nil, err
 	}
+	return warnings, nil
+}
 
-	return &server, nil
+// CheckServerResize runs the node capacity check for new server resources,
+// where a value of 0 keeps the server's current amount.
+func CheckServerResize(server *models.Server, memory, cpu, disk int) ([]string, error) {
+	node, err := GetNodeByID(server.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if memory <= 0 {
+		memory = server.Memory
+	}
+	if cpu <= 0 {
+		cpu = server.CPU
+	}
+	if disk <= 0 {
+		disk = server.Disk
+	}
+	return CheckNodeCapacity(node, memory, cpu, disk, server.ID)
 }
 
 func UpdateServerName(serverID, userID uuid.UUID, name string, isAdmin bool) (*models.Server, error) {
@@ -401,6 +519,9 @@ func DeleteServerAdmin(serverID uuid.UUID) error {
 	if result.RowsAffected == 0 {
 		return ErrServerNotFound
 	}
+	if result.Error == nil {
+		releaseServerAllocations(serverID)
+	}
 	return result.Error
 }
 
@@ -416,14 +537,23 @@ func IsServerSuspended(serverID uuid.UUID) (bool, error) {
 	return server.IsSuspended, nil
 }
 
-func AllocatePortsForNode(nodeID uuid.UUID, existingPorts datatypes.JSON) datatypes.JSON {
+// AllocatePortsForNode assigns a server the same number of ports on the node
+// it is moving to and frees the allocations it held anywhere else.
+func AllocatePortsForNode(serverID, nodeID uuid.UUID, existingPorts datatypes.JSON) (datatypes.JSON, error) {
 	var ports []models.ServerPort
 	json.Unmarshal(existingPorts, &ports)
 
-	for i := range ports {
-		ports[i].Port = allocatePort(nodeID)
+	var previous []uuid.UUID
+	database.DB.Model(&models.Allocation{}).Where("server_id = ? AND node_id <> ?", serverID, nodeID).Pluck("id", &previous)
+
+	ports, err := assignNodePorts(nodeID, serverID, ports)
+	if err != nil {
+		return nil, err
+	}
+	if len(previous) > 0 {
+		database.DB.Model(&models.Allocation{}).Where("id IN ?", previous).Update("server_id", nil)
 	}
 
 	newPorts, _ := json.Marshal(ports)
-	return newPorts
+	return newPorts, nil
 }
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ServerStateRunning = "running"
+	ServerStateStopped = "stopped"
+	ServerStateCrashed = "crashed"
+)
+
+type ServerStateReport struct {
+	ServerID string    `json:"server_id"`
+	State    string    `json:"state"`
+	ExitCode int       `json:"exit_code"`
+	OOM      bool      `json:"oom"`
+	Health   string    `json:"health"`
+	Logs     []string  `json:"logs"`
+	Sync     bool      `json:"sync"`
+	Time     time.Time `json:"time"`
+}
+
+var serverStateListener func(server *models.Server, report ServerStateReport, crash *models.ServerCrash)
+
+func SetServerStateListener(fn func(server *models.Server, report ServerStateReport, crash *models.ServerCrash)) {
+	serverStateListener = fn
+}
+
+func HandleServerStateReport(nodeID uuid.UUID, report ServerStateReport) error {
+	serverID, err := uuid.Parse(report.ServerID)
+	if err != nil {
+		return ErrServerNotFound
+	}
+	var server models.Server
+	if err := database.DB.Where("id = ? AND node_id = ?", serverID, nodeID).First(&server).Error; err != nil {
+		return ErrServerNotFound
+	}
+
+	status := models.ServerStatusStopped
+	switch report.State {
+	case ServerStateRunning:
+		status = models.ServerStatusRunning
+	case ServerStateStopped, ServerStateCrashed:
+		report.Health = ""
+	default:
+		return fmt.Errorf("unknown server state %q", report.State)
+	}
+	if report.Time.IsZero() {
+		report.Time = time.Now()
+	}
+	if server.StateAt != nil && report.Time.Before(*server.StateAt) {
+		return nil
+	}
+
+	previous := server.Status
+	var crash *models.ServerCrash
+	var restartDelay time.Duration
+	if report.State == ServerStateCrashed {
+		log.Printf("[state] server %s crashed on node %s (exit code %d, oom %v)", server.ID, nodeID, report.ExitCode, report.OOM)
+		if crash, restartDelay, err = recordServerCrash(&server, report); err != nil {
+			return err
+		}
+		if crash.Action == models.CrashActionFailed {
+			status = models.ServerStatusFailed
+		}
+	}
+
+	updates := map[string]interface{}{"status": status, "state_at": report.Time}
+	if report.Health != "" || status != models.ServerStatusRunning {
+		updates["health"] = report.Health
+	}
+	if status == models.ServerStatusStopped && (previous == models.ServerStatusInstalling || previous == models.ServerStatusFailed) {
+		delete(updates, "status")
+	}
+	if err := database.DB.Model(&server).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	if crash != nil && crash.Action == models.CrashActionRestarted {
+		time.AfterFunc(restartDelay, func() { restartStoppedServer(server.ID) })
+	}
+
+	if report.Sync && status == models.ServerStatusStopped && previous == models.ServerStatusRunning && !server.IsSuspended {
+		if ServerCrashPolicy(&server).MaxRestarts > 0 {
+			log.Printf("[state] server %s was running before node %s restarted, starting it again", server.ID, nodeID)
+			go restartStoppedServer(server.ID)
+		}
+	}
+	if serverStateListener != nil {
+		serverStateListener(&server, report, crash)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SFTPEvent struct {
+	ServerID string    `json:"server_id"`
+	UserID   string    `json:"user_id"`
+	Action   string    `json:"action"`
+	Path     string    `json:"path"`
+	Target   string    `json:"target"`
+	Bytes    int64     `json:"bytes"`
+	IP       string    `json:"ip"`
+	Client   string    `json:"client"`
+	Time     time.Time `json:"time"`
+}
+
+type SFTPActivityReport struct {
+	Events []SFTPEvent `json:"events"`
+}
+
+var sftpActivityListener func(nodeID uuid.UUID, report SFTPActivityReport) int
+
+// SetSFTPActivityListener sets the function that records SFTP activity reports
+// arriving over a node tunnel.
+func SetSFTPActivityListener(fn func(nodeID uuid.UUID, report SFTPActivityReport) int) {
+	sftpActivityListener = fn
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"sync"
@@ -85,6 +86,20 @@ func StartTransfer(serverID, targetNodeID uuid.UUID) (string, error) {
 		return "", fmt.Errorf("source node is offline")
 	}
 
+	if server.Node != nil && server.Node.Tunnel {
+		return "", fmt.Errorf("servers cannot be transferred off a tunnel node")
+	}
+
+	if _, err := CheckNodeCapacity(&targetNode, server.Memory, server.CPU, server.Disk, server.ID); err != nil {
+		return "", err
+	}
+
+	var ports []models.ServerPort
+	json.Unmarshal(server.Ports, &ports)
+	if freeNodePorts(targetNode.ID) < len(ports) {
+		return "", ErrNoFreePorts
+	}
+
 	transferID := uuid.New().String()[:8]
 
 	status := &TransferStatus{
@@ -163,12 +178,21 @@ func runTransfer(status *TransferStatus, server *models.Server, targetNode *mode
 	}
 	log.Printf("[Transfer] Transfer completed in %v", time.Since(start))
 
+	newPorts, err := AllocatePortsForNode(serverID, targetNode.ID, server.Ports)
+	if err != nil {
+		DeleteServerArchive(serverID)
+		if delErr := sendToNode(targetNode, "DELETE", fmt.Sprintf("/api/servers/%s", serverID), nil); delErr != nil {
+			log.Printf("[Transfer] Failed to remove imported files for %s from node %s: %v", serverID, targetNode.Name, delErr)
+		}
+		failTransfer(status, fmt.Errorf("failed to allocate ports: %w", err))
+		return
+	}
+
 	updateTransfer(status, TransferStageCleanup, 85)
 	DeleteServerArchive(serverID)
 	SendDeleteServer(serverID)
 
 	updateTransfer(status, TransferStageImporting, 95)
-	newPorts := AllocatePortsForNode(uuid.MustParse(status.ToNodeID), server.Ports)
 	database.DB.Model(&models.Server{}).Where("id = ?", serverID).Updates(map[string]interface{}{
 		"node_id": status.ToNodeID,
 		"ports":   newPorts,
@@ -97,6 +97,20 @@ func fetchServerInfoCmd(query string) tea.Cmd {
 			infoItem{"Updated At", server.UpdatedAt.Format("2006-01-02 15:04:05"), nil},
 		}
 
+		var schedules []models.Schedule
+		database.DB.Where("server_id = ?", server.ID).Order("name asc").Find(&schedules)
+		for _, s := range schedules {
+			tz := s.Timezone
+			if tz == "" {
+				tz = "host time"
+			}
+			next := "not scheduled"
+			if s.IsActive && s.NextRunAt != nil {
+				next = s.NextRunAt.Format("2006-01-02 15:04:05 MST (-07:00)")
+			}
+			items = append(items, infoItem{"Schedule: " + s.Name, fmt.Sprintf("%s [%s] | next: %s", s.CronExpression, tz, next), nil})
+		}
+
 		return showServerInfoMsg{
 			title: "Server Info: " + server.Name,
 			items: items,
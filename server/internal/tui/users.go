@@ -85,6 +85,8 @@ func fetchUserInfoCmd(query string) tea.Cmd {
 			infoItem{"RAM Limit", formatLimit(user.RAMLimit, " MB"), nil},
 			infoItem{"CPU Limit", formatLimit(user.CPULimit, " %"), nil},
 			infoItem{"Disk Limit", formatLimit(user.DiskLimit, " MB"), nil},
+			infoItem{"Backup Limit", formatLimit(user.BackupLimit, ""), nil},
+			infoItem{"Backup Storage Limit", formatLimit(user.BackupStorageLimit, " MB"), nil},
 			infoItem{"Servers Owned", fmt.Sprintf("%d", serverCount), nil},
 			infoItem{"Subuser Accesses", fmt.Sprintf("%d", subuserCount), nil},
 			infoItem{"Active API Keys", fmt.Sprintf("%d", apiKeyCount), nil},
@@ -171,6 +173,8 @@ func fetchAdminLimitsCmd(user models.User) tea.Cmd {
 			infoItem{"Edit Limits [CPU]", "Format: 200 (%) or 0 (unlimited)", promptAdminEditCmd("Edit CPU Limit (0 = Unlimited, %)", "cpu_limit", fmtIntPtr(user.CPULimit))},
 			infoItem{"Edit Limits [Disk]", "Format: 5000 (MB) or 0 (unlimited)", promptAdminEditCmd("Edit Disk Limit (0 = Unlimited, MB)", "disk_limit", fmtIntPtr(user.DiskLimit))},
 			infoItem{"Edit Limits [Servers]", "Format: 3 or 0 (unlimited)", promptAdminEditCmd("Edit Server Limit (0 = Unlimited)", "server_limit", fmtIntPtr(user.ServerLimit))},
+			infoItem{"Edit Limits [Backups]", "Format: 10 or 0 (unlimited)", promptAdminEditCmd("Edit Backup Limit (0 = Unlimited)", "backup_limit", fmtIntPtr(user.BackupLimit))},
+			infoItem{"Edit Limits [Backup Storage]", "Format: 10240 (MB) or 0 (unlimited)", promptAdminEditCmd("Edit Backup Storage Limit (0 = Unlimited, MB)", "backup_storage_limit", fmtIntPtr(user.BackupStorageLimit))},
 		}
 
 		return showAdminLimitsMsg{
@@ -284,7 +288,7 @@ func executeAdminPromptConfig(user models.User, field string, val string) tea.Cm
 				return actionDoneMsg("Edit failed: Hash error.")
 			}
 			updates["password_hash"] = hash
-		case "ram_limit", "cpu_limit", "disk_limit", "server_limit":
+		case "ram_limit", "cpu_limit", "disk_limit", "server_limit", "backup_limit", "backup_storage_limit":
 			i, err := strconv.Atoi(val)
 			if err != nil {
 				return actionDoneMsg("Edit failed: Value must be an integer.")
@@ -5,11 +5,13 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"syscall"
 	"time"
 
 	"birdactyl-panel-backend/internal/config"
 	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
 	"birdactyl-panel-backend/internal/logger"
 	"birdactyl-panel-backend/internal/middleware"
 	"birdactyl-panel-backend/internal/models"
@@ -85,6 +87,29 @@ func runServer() {
 		}
 	}
 
+	services.SetScheduleEventEmitter(func(data map[string]string) {
+		plugins.Emit(plugins.EventScheduleEvent, data)
+	})
+	services.SetServerStateListener(func(server *models.Server, report services.ServerStateReport, crash *models.ServerCrash) {
+		if crash == nil {
+			return
+		}
+		data := map[string]string{
+			"server_id": server.ID.String(),
+			"name":      server.Name,
+			"node_id":   server.NodeID.String(),
+			"crash_id":  crash.ID.String(),
+			"exit_code": strconv.Itoa(crash.ExitCode),
+			"oom":       strconv.FormatBool(crash.OOM),
+			"action":    string(crash.Action),
+		}
+		plugins.Emit(plugins.EventServerCrashed, data)
+		if crash.OOM {
+			plugins.Emit(plugins.EventServerOOM, data)
+		}
+	})
+	services.SetSFTPActivityListener(handlers.HandleSFTPActivityReport)
+	services.SetPlacementHook(plugins.PlaceNode)
 	services.InitScheduler()
 
 	if err := plugins.StartServer(cfg.Plugins.Address); err != nil {
@@ -111,6 +136,7 @@ func runServer() {
 	})
 
 	app.Use(recover.New())
+	app.Use(middleware.RequestMetrics())
 	plugins.RegisterPluginRoutes(app)
 	middleware.CleanupRateLimitStore()
 
@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestAllocationPool(t *testing.T) {
+	requireDB(t)
+
+	admin := &models.User{ID: uuid.New(), Username: "test_allocation_pool", Email: "test_allocation_pool@test.com", IsAdmin: true}
+	database.DB.Create(admin)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - Allocations", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "allocations", IsOnline: true}
+	database.DB.Create(node)
+	pkg := &models.Package{ID: uuid.New(), Name: "Allocation Package", DockerImage: "alpine", Startup: "true"}
+	database.DB.Create(pkg)
+
+	defer func() {
+		database.DB.Where("node_id = ?", node.ID).Delete(&models.Server{})
+		database.DB.Where("node_id = ?", node.ID).Delete(&models.Allocation{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Where("id = ?", node.ID).Delete(&models.Node{})
+		database.DB.Where("id = ?", admin.ID).Delete(&models.User{})
+	}()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", admin)
+		return c.Next()
+	})
+	app.Get("/admin/nodes/:id/allocations", handlers.AdminGetNodeAllocations)
+	app.Post("/admin/nodes/:id/allocations", handlers.AdminCreateNodeAllocations)
+	app.Delete("/admin/nodes/:id/allocations/:allocationId", handlers.AdminDeleteNodeAllocation)
+
+	request := func(method, path string, body interface{}) (*http.Response, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp, parseJSONResponse(resp)
+	}
+	path := fmt.Sprintf("/admin/nodes/%s/allocations", node.ID)
+
+	t.Run("Create Range", func(t *testing.T) {
+		if resp, _ := request("POST", path, map[string]interface{}{"ip": "not-an-ip", "ports": []string{"30000"}}); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for an invalid IP, got %d", resp.StatusCode)
+		}
+		if resp, _ := request("POST", path, map[string]interface{}{"ip": "10.0.0.5", "ports": []string{"30010-30000"}}); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for a reversed range, got %d", resp.StatusCode)
+		}
+		resp, data := request("POST", path, map[string]interface{}{"ip": "10.0.0.5", "ports": []string{"30000-30001", "30001"}, "alias": "play.test"})
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %v", resp.StatusCode, data)
+		}
+		if created, _ := data["data"].([]interface{}); len(created) != 2 {
+			t.Errorf("Expected 2 allocations, got %v", data["data"])
+		}
+		_, data = request("POST", path, map[string]interface{}{"ip": "10.0.0.5", "ports": []string{"30001-30002"}})
+		if created, _ := data["data"].([]interface{}); len(created) != 1 {
+			t.Errorf("Expected existing ports to be skipped, got %v", data["data"])
+		}
+	})
+
+	var server *models.Server
+	t.Run("Create Server From Pool", func(t *testing.T) {
+		var err error
+		server, err = services.CreateServer(admin.ID, services.CreateServerRequest{
+			Name: "Allocation Server", NodeID: node.ID, PackageID: pkg.ID, Memory: 512, CPU: 100, Disk: 1024,
+			Ports: []models.ServerPort{{Port: 25565, Primary: true}, {Port: 25575}},
+		})
+		if err != nil {
+			t.Fatalf("Expected the server to be created, got %v", err)
+		}
+		var ports []models.ServerPort
+		json.Unmarshal(server.Ports, &ports)
+		if len(ports) != 2 || ports[0].Port != 30000 || ports[0].IP != "10.0.0.5" || ports[0].Alias != "play.test" || !ports[0].Primary || ports[1].Port != 30001 {
+			t.Errorf("Expected ports from the pool, got %+v", ports)
+		}
+		_, err = services.CreateServer(admin.ID, services.CreateServerRequest{
+			Name: "Allocation Overflow", NodeID: node.ID, PackageID: pkg.ID, Memory: 512, CPU: 100, Disk: 1024,
+			Ports: []models.ServerPort{{Port: 1}, {Port: 2}},
+		})
+		if err != services.ErrNoFreePorts {
+			t.Errorf("Expected the pool to run out, got %v", err)
+		}
+	})
+
+	t.Run("Server Allocations", func(t *testing.T) {
+		updated, err := services.AddAllocation(server.ID, admin.ID, true)
+		if err != nil {
+			t.Fatalf("Expected an allocation to be added, got %v", err)
+		}
+		var ports []models.ServerPort
+		json.Unmarshal(updated.Ports, &ports)
+		if len(ports) != 3 || ports[2].Port != 30002 {
+			t.Errorf("Expected the last free allocation, got %+v", ports)
+		}
+		if _, err := services.AddAllocation(server.ID, admin.ID, true); err != services.ErrNoFreePorts {
+			t.Errorf("Expected no free ports, got %v", err)
+		}
+
+		var assigned models.Allocation
+		database.DB.Where("node_id = ? AND port = ?", node.ID, 30002).First(&assigned)
+		if resp, _ := request("DELETE", fmt.Sprintf("%s/%s", path, assigned.ID), nil); resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409 when deleting an assigned allocation, got %d", resp.StatusCode)
+		}
+
+		if _, err := services.SetPrimaryAllocation(server.ID, admin.ID, 30002, true); err != nil {
+			t.Errorf("Expected the primary allocation to change, got %v", err)
+		}
+		if _, err := services.DeleteAllocation(server.ID, admin.ID, 30000, true); err != nil {
+			t.Errorf("Expected the allocation to be removed, got %v", err)
+		}
+		var free int64
+		database.DB.Model(&models.Allocation{}).Where("node_id = ? AND server_id IS NULL", node.ID).Count(&free)
+		if free != 1 {
+			t.Errorf("Expected the removed allocation to return to the pool, got %d free", free)
+		}
+	})
+
+	t.Run("Delete Server Releases", func(t *testing.T) {
+		if err := services.DeleteServer(server.ID, admin.ID, true); err != nil {
+			t.Fatalf("Expected the server to be deleted, got %v", err)
+		}
+		_, data := request("GET", path, nil)
+		for _, a := range data["data"].([]interface{}) {
+			if a.(map[string]interface{})["server_id"] != nil {
+				t.Errorf("Expected every allocation to be free, got %v", a)
+			}
+		}
+	})
+}
+
+func TestAllocationPoolExistingServers(t *testing.T) {
+	requireDB(t)
+
+	admin := &models.User{ID: uuid.New(), Username: "test_allocation_existing", Email: "test_allocation_existing@test.com", IsAdmin: true}
+	database.DB.Create(admin)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - Existing Ports", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "allocations-existing", IsOnline: true}
+	database.DB.Create(node)
+	pkg := &models.Package{ID: uuid.New(), Name: "Allocation Existing Package", DockerImage: "alpine", Startup: "true"}
+	database.DB.Create(pkg)
+
+	defer func() {
+		database.DB.Where("node_id = ?", node.ID).Delete(&models.Server{})
+		database.DB.Where("node_id = ?", node.ID).Delete(&models.Allocation{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Where("id = ?", node.ID).Delete(&models.Node{})
+		database.DB.Where("id = ?", admin.ID).Delete(&models.User{})
+	}()
+
+	create := func(name string) (*models.Server, error) {
+		return services.CreateServer(admin.ID, services.CreateServerRequest{
+			Name: name, NodeID: node.ID, PackageID: pkg.ID, Memory: 512, CPU: 100, Disk: 1024,
+			Ports: []models.ServerPort{{Port: 1, Primary: true}},
+		})
+	}
+
+	existing, err := create("Existing Server")
+	if err != nil {
+		t.Fatalf("Expected the server to be created, got %v", err)
+	}
+	var ports []models.ServerPort
+	json.Unmarshal(existing.Ports, &ports)
+	bound := ports[0].Port
+	spare := bound - 1
+
+	all, err := services.CreateAllocations(node.ID, "0.0.0.0", "", []string{fmt.Sprint(bound), fmt.Sprint(spare)})
+	if err != nil {
+		t.Fatalf("Expected allocations to be created, got %v", err)
+	}
+	for _, a := range all {
+		if a.Port == bound && (a.ServerID == nil || *a.ServerID != existing.ID) {
+			t.Errorf("Expected port %d to be assigned to the server already using it, got %v", bound, a.ServerID)
+		}
+		if a.Port == spare && a.ServerID != nil {
+			t.Errorf("Expected port %d to be free, got %v", spare, a.ServerID)
+		}
+	}
+	if _, err := services.CreateAllocations(node.ID, "10.0.0.9", "", []string{fmt.Sprint(bound)}); err != nil {
+		t.Fatalf("Expected allocations to be created, got %v", err)
+	}
+
+	server, err := create("Pool Server")
+	if err != nil {
+		t.Fatalf("Expected the server to be created, got %v", err)
+	}
+	json.Unmarshal(server.Ports, &ports)
+	if ports[0].Port != spare {
+		t.Errorf("Expected the only port no server binds, got %+v", ports)
+	}
+	if _, err := create("Overflow Server"); err != services.ErrNoFreePorts {
+		t.Errorf("Expected ports bound on every interface to stay unavailable, got %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/handlers/admin"
+	"birdactyl-panel-backend/internal/handlers/server"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type storageRecorder struct {
+	mu       sync.Mutex
+	requests []string
+	storage  map[string]services.NodeBackupStorage
+}
+
+func (r *storageRecorder) get(serverID uuid.UUID) (services.NodeBackupStorage, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.storage[serverID.String()]
+	return s, ok
+}
+
+func (r *storageRecorder) waitFor(t *testing.T, serverID uuid.UUID, storageType string) services.NodeBackupStorage {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		if s, ok := r.get(serverID); ok && s.Type == storageType {
+			return s
+		}
+		if time.Now().After(deadline) {
+			s, _ := r.get(serverID)
+			t.Fatalf("Expected %s storage for server %s, got %q", storageType, serverID, s.Type)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestBackupTargets(t *testing.T) {
+	requireDB(t)
+
+	rec := &storageRecorder{storage: map[string]services.NodeBackupStorage{}}
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, r.Method+" "+r.URL.Path)
+		if r.Method == "PUT" && strings.HasSuffix(r.URL.Path, "/backups/storage") {
+			var s services.NodeBackupStorage
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &s)
+			parts := strings.Split(r.URL.Path, "/")
+			rec.storage[parts[3]] = s
+		}
+		rec.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	adminUser := &models.User{ID: uuid.New(), Username: "test_backup_targets", Email: "test_backup_targets@test.com", IsAdmin: true}
+	database.DB.Create(adminUser)
+	node := &models.Node{ID: uuid.New(), Name: "Backup Target Node", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "mock"}
+	database.DB.Create(node)
+	plainPkg := &models.Package{ID: uuid.New(), Name: "Backup Target Package"}
+	database.DB.Create(plainPkg)
+	overridePkg := &models.Package{ID: uuid.New(), Name: "Backup Target Override Package"}
+	database.DB.Create(overridePkg)
+	srv := &models.Server{ID: uuid.New(), Name: "Backup Target Server", NodeID: node.ID, UserID: adminUser.ID, PackageID: plainPkg.ID}
+	database.DB.Create(srv)
+	overrideSrv := &models.Server{ID: uuid.New(), Name: "Backup Target Override Server", NodeID: node.ID, UserID: adminUser.ID, PackageID: overridePkg.ID}
+	database.DB.Create(overrideSrv)
+
+	defer func() {
+		database.DB.Where("node_id = ?", node.ID).Delete(&models.Server{})
+		database.DB.Where("id IN ?", []uuid.UUID{plainPkg.ID, overridePkg.ID}).Delete(&models.Package{})
+		database.DB.Where("id = ?", node.ID).Delete(&models.Node{})
+		database.DB.Where("name LIKE ?", "test-target-%").Delete(&models.BackupTarget{})
+		database.DB.Where("id = ?", adminUser.ID).Delete(&models.User{})
+	}()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", adminUser)
+		return c.Next()
+	})
+	app.Get("/admin/backup-targets", admin.AdminGetBackupTargets)
+	app.Post("/admin/backup-targets", admin.AdminCreateBackupTarget)
+	app.Patch("/admin/backup-targets/:id", admin.AdminUpdateBackupTarget)
+	app.Delete("/admin/backup-targets/:id", admin.AdminDeleteBackupTarget)
+	app.Patch("/admin/nodes/:id", handlers.AdminUpdateNode)
+	app.Post("/servers/:id/backups", server.CreateBackup)
+
+	request := func(method, path string, body interface{}) (*http.Response, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		return resp, parseJSONResponse(resp)
+	}
+
+	createTarget := func(body map[string]interface{}) uuid.UUID {
+		resp, data := request("POST", "/admin/backup-targets", body)
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201 creating target, got %d: %v", resp.StatusCode, data)
+		}
+		target := data["data"].(map[string]interface{})
+		if _, leaked := target["secret_key"]; leaked {
+			t.Error("secret_key must not be returned")
+		}
+		if _, leaked := target["password"]; leaked {
+			t.Error("password must not be returned")
+		}
+		return uuid.MustParse(target["id"].(string))
+	}
+
+	s3Target := createTarget(map[string]interface{}{
+		"name": "test-target-s3", "type": "s3", "endpoint": "http://minio.local:9000",
+		"bucket": "backups", "access_key": "key", "secret_key": "secret", "path_style": true,
+	})
+	sftpTarget := createTarget(map[string]interface{}{
+		"name": "test-target-sftp", "type": "sftp", "host": "backup.local",
+		"username": "birdactyl", "password": "hunter2", "path": "/srv/backups",
+		"host_key": "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8",
+	})
+
+	t.Run("Invalid targets are rejected", func(t *testing.T) {
+		for _, body := range []map[string]interface{}{
+			{"name": "test-target-bad", "type": "ftp"},
+			{"name": "test-target-bad", "type": "s3", "endpoint": "minio:9000", "bucket": "b", "access_key": "a", "secret_key": "s"},
+			{"name": "test-target-bad", "type": "sftp", "host": "h", "username": "u", "path": "/x"},
+			{"name": "test-target-bad", "type": "sftp", "host": "h", "username": "u", "password": "p", "path": "relative", "host_key": "SHA256:x"},
+			{"name": "test-target-bad", "type": "sftp", "host": "h", "username": "u", "password": "p", "path": "/x"},
+		} {
+			if resp, _ := request("POST", "/admin/backup-targets", body); resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("Expected 400 for %v, got %d", body, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("Creating a backup pushes local storage by default", func(t *testing.T) {
+		resp, _ := request("POST", fmt.Sprintf("/servers/%s/backups", srv.ID), map[string]string{"name": "first"})
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201, got %d", resp.StatusCode)
+		}
+		if s, _ := rec.get(srv.ID); s.Type != "local" {
+			t.Errorf("Expected local storage, got %q", s.Type)
+		}
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		n := len(rec.requests)
+		if n < 2 || !strings.HasPrefix(rec.requests[n-2], "PUT ") || !strings.HasPrefix(rec.requests[n-1], "POST ") {
+			t.Errorf("Expected storage sync before backup creation, got %v", rec.requests)
+		}
+	})
+
+	t.Run("Node target applies to its servers", func(t *testing.T) {
+		resp, data := request("PATCH", "/admin/nodes/"+node.ID.String(), map[string]interface{}{"name": node.Name, "backup_target_id": s3Target.String()})
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", resp.StatusCode, data)
+		}
+
+		s := rec.waitFor(t, srv.ID, "s3")
+		if s.S3 == nil || s.S3.Bucket != "backups" || s.S3.SecretKey != "secret" || !s.S3.PathStyle {
+			t.Errorf("Unexpected s3 storage: %+v", s.S3)
+		}
+		rec.waitFor(t, overrideSrv.ID, "s3")
+	})
+
+	t.Run("Package target overrides node target", func(t *testing.T) {
+		database.DB.Model(overridePkg).Update("backup_target_id", sftpTarget)
+
+		target, err := services.ResolveBackupTarget(overrideSrv)
+		if err != nil || target == nil || target.ID != sftpTarget {
+			t.Fatalf("Expected sftp target for override server, got %v (%v)", target, err)
+		}
+		if err := services.SyncServerBackupStorage(overrideSrv); err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		s := rec.waitFor(t, overrideSrv.ID, "sftp")
+		if s.SFTP == nil || s.SFTP.Path != "/srv/backups" || s.SFTP.Password != "hunter2" {
+			t.Errorf("Unexpected sftp storage: %+v", s.SFTP)
+		}
+
+		target, _ = services.ResolveBackupTarget(srv)
+		if target == nil || target.ID != s3Target {
+			t.Errorf("Expected node s3 target for plain server, got %v", target)
+		}
+	})
+
+	t.Run("Updating a target resyncs its servers", func(t *testing.T) {
+		resp, _ := request("PATCH", "/admin/backup-targets/"+s3Target.String(), map[string]interface{}{
+			"name": "test-target-s3", "type": "s3", "endpoint": "http://minio.local:9000",
+			"bucket": "backups-v2", "access_key": "key",
+		})
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+
+		deadline := time.Now().Add(3 * time.Second)
+		for {
+			s, _ := rec.get(srv.ID)
+			if s.S3 != nil && s.S3.Bucket == "backups-v2" {
+				if s.S3.SecretKey != "secret" {
+					t.Errorf("Expected secret to be kept when omitted, got %q", s.S3.SecretKey)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected resync with new bucket, got %+v", s.S3)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	})
+
+	t.Run("Targets in use cannot be deleted", func(t *testing.T) {
+		if resp, _ := request("DELETE", "/admin/backup-targets/"+s3Target.String(), nil); resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Clearing the node target falls back to local", func(t *testing.T) {
+		resp, _ := request("PATCH", "/admin/nodes/"+node.ID.String(), map[string]interface{}{"name": node.Name, "backup_target_id": ""})
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		rec.waitFor(t, srv.ID, "local")
+
+		if resp, _ := request("DELETE", "/admin/backup-targets/"+s3Target.String(), nil); resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 deleting unused target, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Unknown target is rejected", func(t *testing.T) {
+		resp, _ := request("PATCH", "/admin/nodes/"+node.ID.String(), map[string]interface{}{"backup_target_id": uuid.New().String()})
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", resp.StatusCode)
+		}
+	})
+}
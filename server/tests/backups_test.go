@@ -1,18 +1,26 @@
 package tests
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"birdactyl-panel-backend/internal/config"
 	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
 	"birdactyl-panel-backend/internal/handlers/server"
 	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -79,6 +87,7 @@ func setupBackupMockNodeAndServer() (*fiber.App, *models.User, *models.Server, *
 
 	cleanup := func() {
 		mockDaemon.Close()
+		database.DB.Where("server_id = ?", testServer.ID).Delete(&models.Backup{})
 		database.DB.Where("id = ?", testServer.ID).Delete(&models.Server{})
 		database.DB.Where("id = ?", testPkg.ID).Delete(&models.Package{})
 		database.DB.Where("id = ?", testNode.ID).Delete(&models.Node{})
@@ -88,6 +97,12 @@ func setupBackupMockNodeAndServer() (*fiber.App, *models.User, *models.Server, *
 	return app, adminUser, testServer, mockDaemon, cleanup
 }
 
+func seedBackup(serverID uuid.UUID, status models.BackupStatus, locked bool) *models.Backup {
+	backup := &models.Backup{ServerID: serverID, Name: "seeded", Status: status, Locked: locked}
+	database.DB.Create(backup)
+	return backup
+}
+
 func TestBackupsRoutes(t *testing.T) {
 	requireDB(t)
 
@@ -123,7 +138,7 @@ func TestBackupsRoutes(t *testing.T) {
 	})
 
 	t.Run("Delete Backup", func(t *testing.T) {
-		mockBackupID := uuid.New().String()
+		mockBackupID := seedBackup(testServer.ID, models.BackupCompleted, false).NodeBackupID
 		req := httptest.NewRequest("DELETE", fmt.Sprintf("/servers/%s/backups/%s", testServer.ID.String(), mockBackupID), nil)
 		resp, err := app.Test(req, -1)
 		if err != nil {
@@ -135,7 +150,7 @@ func TestBackupsRoutes(t *testing.T) {
 	})
 
 	t.Run("Restore Backup", func(t *testing.T) {
-		mockBackupID := uuid.New().String()
+		mockBackupID := seedBackup(testServer.ID, models.BackupCompleted, false).NodeBackupID
 		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/backups/%s/restore", testServer.ID.String(), mockBackupID), nil)
 		resp, err := app.Test(req, -1)
 		if err != nil {
@@ -147,15 +162,301 @@ func TestBackupsRoutes(t *testing.T) {
 	})
 
 	t.Run("Download Backup Option", func(t *testing.T) {
-		mockBackupID := uuid.New().String()
+		mockBackupID := seedBackup(testServer.ID, models.BackupCompleted, false).NodeBackupID
 		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/backups/%s/download", testServer.ID.String(), mockBackupID), nil)
 		resp, err := app.Test(req, -1)
 		if err != nil {
 			t.Fatalf("Failed to test download backup: %v", err)
 		}
 		
-		if resp.StatusCode != fiber.StatusFound {
-			t.Errorf("Expected status 302, got %d", resp.StatusCode)
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "mock backup content" {
+			t.Errorf("Expected backup to be streamed through the panel, got %q", body)
+		}
+	})
+}
+
+func TestBackupRecords(t *testing.T) {
+	requireDB(t)
+
+	var (
+		mu          sync.Mutex
+		nodeBackups []map[string]interface{}
+		deleted     []string
+		lastCreate  map[string]interface{}
+		lastRestore map[string]interface{}
+	)
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/backups"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": nodeBackups})
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/backups"):
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			lastCreate = body
+			nodeBackups = append(nodeBackups, map[string]interface{}{"id": body["id"], "name": body["name"], "created_at": time.Now().Unix(), "completed": false})
+			w.Write([]byte(`{"success": true}`))
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/restore"):
+			json.NewDecoder(r.Body).Decode(&lastRestore)
+			w.Write([]byte(`{"success": true}`))
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/files"):
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": []map[string]interface{}{{"name": r.URL.Query().Get("path"), "is_dir": true}}})
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.Write([]byte(`{"success": true}`))
+		default:
+			w.Write([]byte(`{"success": true}`))
+		}
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	owner := &models.User{ID: uuid.New(), Username: "test_backup_records", Email: "test_backup_records@test.com"}
+	database.DB.Create(owner)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - Backup Records", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "mock"}
+	database.DB.Create(node)
+	pkg := &models.Package{ID: uuid.New(), Name: "Backup Records Package", BackupIgnore: "logs/\ncache/",
+		BackupHooks: []byte(`{"pre":[{"command":"save-off"},{"command":"save-all flush","wait_for":"Saved the game","timeout":60}],"post":[{"command":"save-on"}]}`)}
+	database.DB.Create(pkg)
+	limit := 2
+	srv := &models.Server{ID: uuid.New(), Name: "Backup Records", NodeID: node.ID, UserID: owner.ID, PackageID: pkg.ID, BackupLimit: &limit}
+	database.DB.Create(srv)
+	otherNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Backup Records Other", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "other"}
+	database.DB.Create(otherNode)
+
+	defer func() {
+		database.DB.Where("server_id = ?", srv.ID).Delete(&models.Backup{})
+		database.DB.Where("id = ?", srv.ID).Delete(&models.Server{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Where("id IN ?", []uuid.UUID{node.ID, otherNode.ID}).Delete(&models.Node{})
+		database.DB.Where("id = ?", owner.ID).Delete(&models.User{})
+	}()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", owner)
+		if token := c.Get("X-Node"); token != "" {
+			if token == otherNode.DaemonToken {
+				c.Locals("node", otherNode)
+			} else {
+				c.Locals("node", node)
+			}
+		}
+		return c.Next()
+	})
+	app.Get("/servers/:id/backups", server.ListBackups)
+	app.Post("/servers/:id/backups", server.CreateBackup)
+	app.Delete("/servers/:id/backups/:backupId", server.DeleteBackup)
+	app.Post("/servers/:id/backups/:backupId/lock", server.LockBackup)
+	app.Post("/servers/:id/backups/:backupId/unlock", server.UnlockBackup)
+	app.Post("/servers/:id/backups/:backupId/restore", server.RestoreBackup)
+	app.Get("/servers/:id/backups/:backupId/files", server.ListBackupFiles)
+	app.Post("/internal/nodes/backups", handlers.NodeBackupReport)
+
+	request := func(method, path string, body interface{}, headers ...string) (*http.Response, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		return resp, parseJSONResponse(resp)
+	}
+	base := fmt.Sprintf("/servers/%s/backups", srv.ID)
+
+	var first string
+	t.Run("Create stores a pending record with its name and creator", func(t *testing.T) {
+		resp, data := request("POST", base, map[string]interface{}{"name": "before update", "locked": true, "ignore": []string{"*.log", " "}})
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %v", resp.StatusCode, data)
+		}
+		b := data["data"].(map[string]interface{})
+		first = b["id"].(string)
+		if b["name"] != "before update" || b["status"] != "pending" || b["locked"] != true || b["created_by"] != owner.ID.String() {
+			t.Errorf("Unexpected backup: %v", b)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if lastCreate["id"] != first || lastCreate["ignore"] != "logs/\ncache/\n*.log" {
+			t.Errorf("Expected package and request ignore rules, got %v", lastCreate)
+		}
+		hooks, _ := lastCreate["hooks"].(map[string]interface{})
+		pre, _ := hooks["pre"].([]interface{})
+		post, _ := hooks["post"].([]interface{})
+		if len(pre) != 2 || len(post) != 1 || pre[1].(map[string]interface{})["wait_for"] != "Saved the game" {
+			t.Errorf("Expected package backup hooks to be forwarded, got %v", lastCreate["hooks"])
+		}
+	})
+
+	t.Run("Node report completes the backup", func(t *testing.T) {
+		report := map[string]interface{}{"server_id": srv.ID.String(), "backup_id": first, "status": "completed", "size": 2048, "checksum": "abc", "storage": "local"}
+		if resp, _ := request("POST", "/internal/nodes/backups", report, "X-Node", otherNode.DaemonToken); resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected 404 for a report from another node, got %d", resp.StatusCode)
+		}
+		if resp, data := request("POST", "/internal/nodes/backups", report, "X-Node", node.DaemonToken); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", resp.StatusCode, data)
+		}
+
+		backup, err := services.GetServerBackup(srv.ID, first)
+		if err != nil || backup.Status != models.BackupCompleted || backup.Size != 2048 || backup.Checksum != "abc" || backup.CompletedAt == nil {
+			t.Errorf("Expected completed backup, got %+v (%v)", backup, err)
+		}
+
+		report["warnings"] = []string{`pre-backup command "save-all flush": pattern did not appear`}
+		if resp, _ := request("POST", "/internal/nodes/backups", report, "X-Node", node.DaemonToken); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		_, data := request("GET", base, nil)
+		for _, b := range data["data"].([]interface{}) {
+			if b := b.(map[string]interface{}); b["id"] == first && !strings.Contains(fmt.Sprint(b["warnings"]), "save-all flush") {
+				t.Errorf("Expected hook warnings on the backup, got %v", b["warnings"])
+			}
+		}
+	})
+
+	t.Run("Partial restore and file listing", func(t *testing.T) {
+		resp, data := request("POST", base+"/"+first+"/restore", map[string]interface{}{"paths": []string{"world/", "/plugins/../config.yml"}})
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", resp.StatusCode, data)
+		}
+		mu.Lock()
+		paths := fmt.Sprint(lastRestore["paths"])
+		mu.Unlock()
+		if paths != "[world config.yml]" {
+			t.Errorf("Expected cleaned paths, got %s", paths)
+		}
+
+		if resp, _ := request("POST", base+"/"+first+"/restore", map[string]interface{}{"paths": []string{"../"}}); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for the server root, got %d", resp.StatusCode)
+		}
+
+		resp, data = request("GET", base+"/"+first+"/files?path=/world", nil)
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		files, _ := data["data"].([]interface{})
+		if len(files) != 1 || files[0].(map[string]interface{})["name"] != "/world" {
+			t.Errorf("Expected path to be forwarded, got %v", data)
+		}
+	})
+
+	t.Run("Locked backups cannot be deleted", func(t *testing.T) {
+		if resp, _ := request("DELETE", base+"/"+first, nil); resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409, got %d", resp.StatusCode)
+		}
+		if resp, _ := request("POST", base+"/"+first+"/unlock", nil); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 unlocking, got %d", resp.StatusCode)
+		}
+		if resp, _ := request("POST", base+"/"+first+"/lock", nil); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 locking, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Server backup limit", func(t *testing.T) {
+		if resp, _ := request("POST", base, map[string]string{"name": "second"}); resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201, got %d", resp.StatusCode)
+		}
+		if resp, data := request("POST", base, map[string]string{"name": "third"}); resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("Expected 403 over server limit, got %d: %v", resp.StatusCode, data)
+		}
+
+		var before int64
+		database.DB.Model(&models.Backup{}).Where("server_id = ?", srv.ID).Count(&before)
+		if _, err := services.CreateServerBackup(srv, "raced", nil, false, nil); !errors.Is(err, services.ErrBackupLimitReached) {
+			t.Errorf("Expected a create that skipped the pre-check to hit the limit, got %v", err)
+		}
+		var after int64
+		database.DB.Model(&models.Backup{}).Where("server_id = ?", srv.ID).Count(&after)
+		if after != before {
+			t.Errorf("Expected the over-limit backup row to be removed, had %d now %d", before, after)
+		}
+	})
+
+	t.Run("Pending backups cannot be restored", func(t *testing.T) {
+		backups, _ := services.ListServerBackups(srv.ID)
+		for _, b := range backups {
+			if b.Status == models.BackupPending {
+				if resp, _ := request("POST", base+"/"+b.NodeBackupID+"/restore", nil); resp.StatusCode != fiber.StatusConflict {
+					t.Errorf("Expected 409, got %d", resp.StatusCode)
+				}
+				return
+			}
+		}
+		t.Fatal("Expected a pending backup")
+	})
+
+	t.Run("Failed report frees the slot", func(t *testing.T) {
+		backups, _ := services.ListServerBackups(srv.ID)
+		for _, b := range backups {
+			if b.Status == models.BackupPending {
+				report := map[string]interface{}{"server_id": srv.ID.String(), "backup_id": b.NodeBackupID, "status": "failed", "error": "disk full"}
+				if resp, _ := request("POST", "/internal/nodes/backups", report, "X-Node", node.DaemonToken); resp.StatusCode != fiber.StatusOK {
+					t.Fatalf("Expected 200, got %d", resp.StatusCode)
+				}
+			}
+		}
+		if err := services.CheckBackupLimits(srv); err != nil {
+			t.Errorf("Expected failed backups not to count, got %v", err)
+		}
+	})
+
+	t.Run("User quota", func(t *testing.T) {
+		cfg := config.Get()
+		enabled := cfg.Resources.Enabled
+		cfg.Resources.Enabled = true
+		defer func() { cfg.Resources.Enabled = enabled }()
+
+		quota := 1
+		database.DB.Model(owner).Update("backup_limit", quota)
+		defer database.DB.Model(owner).Update("backup_limit", nil)
+
+		if err := services.CheckBackupLimits(srv); !errors.Is(err, services.ErrBackupQuotaReached) {
+			t.Errorf("Expected quota error, got %v", err)
+		}
+		if resp, _ := request("POST", base, map[string]string{"name": "over quota"}); resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("Expected 403 over user quota, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Unknown node backups are adopted", func(t *testing.T) {
+		mu.Lock()
+		nodeBackups = append(nodeBackups, map[string]interface{}{"id": "legacy", "name": "legacy", "size": 10, "created_at": time.Now().Add(-time.Hour).Unix(), "completed": true})
+		mu.Unlock()
+
+		resp, data := request("GET", base, nil)
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		var found bool
+		for _, item := range data["data"].([]interface{}) {
+			b := item.(map[string]interface{})
+			if b["id"] == "legacy" {
+				found = b["status"] == "completed" && b["completed"] == true
+			}
+		}
+		if !found {
+			t.Errorf("Expected legacy backup to be adopted as completed, got %v", data["data"])
+		}
+
+		if resp, _ := request("DELETE", base+"/legacy", nil); resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 deleting adopted backup, got %d", resp.StatusCode)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(deleted) != 1 || deleted[0] != "legacy" {
+			t.Errorf("Expected node delete for legacy, got %v", deleted)
 		}
 	})
 }
@@ -1,7 +1,9 @@
 package tests
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +15,7 @@ import (
 	"birdactyl-panel-backend/internal/database"
 	"birdactyl-panel-backend/internal/handlers/server"
 	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -22,9 +25,46 @@ func setupFileMockNodeAndServer() (*fiber.App, *models.User, *models.Server, *ht
 	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
+		if strings.Contains(r.URL.Path, "/files/uploads") {
+			switch {
+			case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/uploads"):
+				w.Header().Set("Upload-Offset", "0")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"success":true,"data":{"id":"abc","path":"/world.zip","size":11,"offset":0}}`))
+			case r.Method == "PATCH":
+				if r.Header.Get("Content-Type") != "application/offset+octet-stream" || r.Header.Get("Upload-Offset") != "0" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				w.Header().Set("Upload-Offset", strconv.Itoa(len(body)))
+				w.WriteHeader(http.StatusNoContent)
+			case r.Method == "GET":
+				w.Header().Set("Upload-Offset", "5")
+				w.Header().Set("Upload-Length", "11")
+				w.Write([]byte(`{"success":true,"data":{"id":"abc","offset":5}}`))
+			case strings.HasSuffix(r.URL.Path, "/complete"):
+				w.Write([]byte(`{"success":true,"data":{"id":"abc","path":"/world.zip","size":11}}`))
+			default:
+				w.Write([]byte(`{"success":true}`))
+			}
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/download") && r.Header.Get("Range") == "bytes=5-8" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Range", "bytes 5-8/17")
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("file"))
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 
-		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/search") {
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/grep") {
+			w.Write([]byte(fmt.Sprintf("{\"type\":\"match\",\"path\":\"/server.properties\",\"line\":3,\"text\":\"motd=%s\"}\n", r.URL.Query().Get("pattern"))))
+			w.Write([]byte(`{"type":"done","files_scanned":4,"files_skipped":1,"matches":1,"truncated":false}` + "\n"))
+		} else if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/search") {
 			w.Write([]byte(`{"success":true, "data": []}`))
 		} else if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/files") {
 			w.Write([]byte(`{"success":true, "data": []}`))
@@ -114,6 +154,11 @@ func TestFilesRoutes(t *testing.T) {
 	app.Post("/servers/:id/files/bulk-delete", server.BulkDeleteFiles)
 	app.Post("/servers/:id/files/bulk-copy", server.BulkCopyFiles)
 	app.Post("/servers/:id/files/bulk-compress", server.BulkCompressFiles)
+	app.Post("/servers/:id/files/uploads", server.CreateUpload)
+	app.Get("/servers/:id/files/uploads/:uploadId", server.GetUpload)
+	app.Patch("/servers/:id/files/uploads/:uploadId", server.PatchUpload)
+	app.Post("/servers/:id/files/uploads/:uploadId/complete", server.CompleteUpload)
+	app.Delete("/servers/:id/files/uploads/:uploadId", server.CancelUpload)
 
 	t.Run("List Files", func(t *testing.T) {
 		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/files?path=/", testServer.ID.String()), nil)
@@ -148,6 +193,49 @@ func TestFilesRoutes(t *testing.T) {
 		}
 	})
 
+	t.Run("Search File Contents", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/files/search?mode=content&q=hello", testServer.ID.String()), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test content search: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("Expected ndjson content type, got %q", ct)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+		if len(lines) != 2 || !strings.Contains(lines[0], "motd=hello") || !strings.Contains(lines[1], `"type":"done"`) {
+			t.Errorf("Unexpected stream body: %s", body)
+		}
+	})
+
+	t.Run("Search File Contents Requires Query", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/files/search?mode=content", testServer.ID.String()), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test content search: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Search File Contents Service", func(t *testing.T) {
+		matches, summary, err := services.SearchFileContents(testServer, services.FileGrepOptions{Pattern: "world"})
+		if err != nil {
+			t.Fatalf("SearchFileContents failed: %v", err)
+		}
+		if len(matches) != 1 || matches[0].Path != "/server.properties" || matches[0].Line != 3 || matches[0].Text != "motd=world" {
+			t.Errorf("Unexpected matches: %+v", matches)
+		}
+		if summary == nil || summary.FilesScanned != 4 || summary.FilesSkipped != 1 || summary.Matches != 1 {
+			t.Errorf("Unexpected summary: %+v", summary)
+		}
+	})
+
 	t.Run("Create Folder", func(t *testing.T) {
 		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/files/folder", testServer.ID.String()), toJSONBody(map[string]string{"path": "/new_folder"}))
 		req.Header.Set("Content-Type", "application/json")
@@ -277,4 +365,109 @@ func TestFilesRoutes(t *testing.T) {
 			t.Errorf("Expected status 200, got %d", resp.StatusCode)
 		}
 	})
+
+	t.Run("Download File Range", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/files/download?path=/file.txt", testServer.ID.String()), nil)
+		req.Header.Set("Range", "bytes=5-8")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test ranged download: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusPartialContent {
+			t.Errorf("Expected status 206, got %d", resp.StatusCode)
+		}
+		if got := resp.Header.Get("Content-Range"); got != "bytes 5-8/17" {
+			t.Errorf("Expected Content-Range bytes 5-8/17, got %q", got)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "file" {
+			t.Errorf("Expected partial body, got %q", body)
+		}
+	})
+
+	t.Run("Create Upload", func(t *testing.T) {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/files/uploads", testServer.ID.String()), toJSONBody(map[string]interface{}{"path": "/world.zip", "size": 11}))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test create upload: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+		}
+		var result struct {
+			Data struct {
+				ID        string `json:"id"`
+				ChunkSize int    `json:"chunk_size"`
+			} `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&result)
+		if result.Data.ID != "abc" || result.Data.ChunkSize != server.UploadChunkSize {
+			t.Errorf("Expected upload abc with chunk size %d, got %+v", server.UploadChunkSize, result.Data)
+		}
+	})
+
+	t.Run("Create Upload Requires Path", func(t *testing.T) {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/files/uploads", testServer.ID.String()), toJSONBody(map[string]interface{}{"size": 11}))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test create upload: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Get Upload Offset", func(t *testing.T) {
+		req := httptest.NewRequest("HEAD", fmt.Sprintf("/servers/%s/files/uploads/abc", testServer.ID.String()), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test get upload: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+		if resp.Header.Get("Upload-Offset") != "5" || resp.Header.Get("Upload-Length") != "11" {
+			t.Errorf("Expected upload offset headers, got %v", resp.Header)
+		}
+	})
+
+	t.Run("Patch Upload", func(t *testing.T) {
+		req := httptest.NewRequest("PATCH", fmt.Sprintf("/servers/%s/files/uploads/abc", testServer.ID.String()), strings.NewReader("hello world"))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		req.Header.Set("Upload-Offset", "0")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test patch upload: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusNoContent {
+			t.Errorf("Expected status 204, got %d", resp.StatusCode)
+		}
+		if got := resp.Header.Get("Upload-Offset"); got != "11" {
+			t.Errorf("Expected Upload-Offset 11, got %q", got)
+		}
+	})
+
+	t.Run("Complete Upload", func(t *testing.T) {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/files/uploads/abc/complete", testServer.ID.String()), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test complete upload: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Cancel Upload", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", fmt.Sprintf("/servers/%s/files/uploads/abc", testServer.ID.String()), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to test cancel upload: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+	})
 }
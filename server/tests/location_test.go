@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers/admin"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestLocationPlacement(t *testing.T) {
+	requireDB(t)
+
+	user := &models.User{ID: uuid.New(), Username: "test_location", Email: "test_location@test.com", IsAdmin: true}
+	database.DB.Create(user)
+	location := &models.Location{Name: "Test Location - Placement", Region: "eu"}
+	database.DB.Create(location)
+	other := &models.Location{Name: "Test Location - Other"}
+	database.DB.Create(other)
+	busy := &models.Node{ID: uuid.New(), Name: "Mock Node - Busy", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "busy", IsOnline: true, LocationID: &location.ID, MemoryLimit: 8192}
+	database.DB.Create(busy)
+	idle := &models.Node{ID: uuid.New(), Name: "Mock Node - Idle", FQDN: "127.0.0.1", Port: 2, TokenID: uuid.New().String(), DaemonToken: "idle", IsOnline: true, LocationID: &location.ID, MemoryLimit: 4096}
+	database.DB.Create(idle)
+	pkg := &models.Package{ID: uuid.New(), Name: "Location Package", DockerImage: "alpine", Startup: "true"}
+	database.DB.Create(pkg)
+	for i, size := range []int{1024, 1024} {
+		database.DB.Create(&models.Server{ID: uuid.New(), Name: fmt.Sprintf("Location Busy %d", i), NodeID: busy.ID, UserID: user.ID, PackageID: pkg.ID, Status: models.ServerStatusStopped, Memory: size})
+	}
+	database.DB.Create(&models.Server{ID: uuid.New(), Name: "Location Idle", NodeID: idle.ID, UserID: user.ID, PackageID: pkg.ID, Status: models.ServerStatusStopped, Memory: 1536})
+
+	defer func() {
+		services.SetPlacementHook(nil)
+		database.DB.Where("node_id IN ?", []uuid.UUID{busy.ID, idle.ID}).Delete(&models.Server{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Where("id IN ?", []uuid.UUID{busy.ID, idle.ID}).Delete(&models.Node{})
+		database.DB.Where("id IN ?", []uuid.UUID{location.ID, other.ID}).Delete(&models.Location{})
+		database.DB.Where("name = ?", "Test Location - Created").Delete(&models.Location{})
+		database.DB.Where("id = ?", user.ID).Delete(&models.User{})
+	}()
+
+	place := func(strategy string, memory int) (uuid.UUID, error) {
+		return services.PlaceServer(services.PlacementRequest{LocationID: location.ID, Package: pkg, Memory: memory, CPU: 100, Disk: 1024, Ports: 1, Strategy: strategy})
+	}
+
+	t.Run("Admin CRUD", func(t *testing.T) {
+		app := fiber.New(fiber.Config{DisableStartupMessage: true})
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		})
+		app.Get("/admin/locations", admin.AdminGetLocations)
+		app.Post("/admin/locations", admin.AdminCreateLocation)
+		app.Delete("/admin/locations/:id", admin.AdminDeleteLocation)
+		request := func(method, path string, body interface{}) (*http.Response, map[string]interface{}) {
+			req := httptest.NewRequest(method, path, toJSONBody(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			return resp, parseJSONResponse(resp)
+		}
+
+		if resp, _ := request("POST", "/admin/locations", map[string]interface{}{"name": location.Name}); resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409 for a duplicate name, got %d", resp.StatusCode)
+		}
+		resp, data := request("POST", "/admin/locations", map[string]interface{}{"name": "Test Location - Created", "region": "us", "tags": []string{"ssd"}})
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %v", resp.StatusCode, data)
+		}
+		created := data["data"].(map[string]interface{})
+		if tags, _ := created["tags"].([]interface{}); len(tags) != 1 || tags[0] != "ssd" {
+			t.Errorf("Expected tags to be stored, got %v", created["tags"])
+		}
+		if resp, _ := request("DELETE", fmt.Sprintf("/admin/locations/%s", location.ID), nil); resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409 when deleting a location with nodes, got %d", resp.StatusCode)
+		}
+		if resp, _ := request("DELETE", fmt.Sprintf("/admin/locations/%s", created["id"]), nil); resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 when deleting an empty location, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Strategies", func(t *testing.T) {
+		if nodeID, err := place(services.PlacementLeastMemory, 512); err != nil || nodeID != busy.ID {
+			t.Errorf("Expected least_memory to pick the busy node, got %v, %v", nodeID, err)
+		}
+		if nodeID, err := place(services.PlacementFewestServers, 512); err != nil || nodeID != idle.ID {
+			t.Errorf("Expected fewest_servers to pick the idle node, got %v, %v", nodeID, err)
+		}
+		if _, err := place("random", 512); err != services.ErrUnknownPlacementStrategy {
+			t.Errorf("Expected an unknown strategy error, got %v", err)
+		}
+	})
+
+	t.Run("Capacity", func(t *testing.T) {
+		if nodeID, err := place(services.PlacementFewestServers, 4096); err != nil || nodeID != busy.ID {
+			t.Errorf("Expected the only node with room to be picked, got %v, %v", nodeID, err)
+		}
+		if _, err := place(services.PlacementLeastMemory, 16384); err != services.ErrNoNodeAvailable {
+			t.Errorf("Expected no node to fit, got %v", err)
+		}
+	})
+
+	t.Run("Plugin Hook", func(t *testing.T) {
+		services.SetPlacementHook(func(req services.PlacementRequest, candidates []services.PlacementCandidate) (uuid.UUID, error) {
+			return idle.ID, nil
+		})
+		if nodeID, err := place(services.PlacementLeastMemory, 512); err != nil || nodeID != idle.ID {
+			t.Errorf("Expected the hook to pick the idle node, got %v, %v", nodeID, err)
+		}
+		services.SetPlacementHook(func(req services.PlacementRequest, candidates []services.PlacementCandidate) (uuid.UUID, error) {
+			return uuid.New(), nil
+		})
+		if _, err := place(services.PlacementLeastMemory, 512); err != services.ErrNoNodeAvailable {
+			t.Errorf("Expected a node outside the candidates to be rejected, got %v", err)
+		}
+		services.SetPlacementHook(nil)
+	})
+
+	t.Run("Package Restriction", func(t *testing.T) {
+		allowed, _ := datatypes.NewJSONType([]uuid.UUID{other.ID}).MarshalJSON()
+		pkg.AllowedLocations = allowed
+		database.DB.Model(pkg).Update("allowed_locations", allowed)
+
+		if _, err := place(services.PlacementLeastMemory, 512); err != services.ErrLocationNotAllowed {
+			t.Errorf("Expected the location to be rejected, got %v", err)
+		}
+		_, err := services.CreateServer(user.ID, services.CreateServerRequest{Name: "Location Direct", NodeID: idle.ID, PackageID: pkg.ID, Memory: 512, CPU: 100, Disk: 1024})
+		if err != services.ErrLocationNotAllowed {
+			t.Errorf("Expected direct node creation to be rejected, got %v", err)
+		}
+
+		allowed, _ = datatypes.NewJSONType([]uuid.UUID{location.ID}).MarshalJSON()
+		pkg.AllowedLocations = allowed
+		database.DB.Model(pkg).Update("allowed_locations", allowed)
+	})
+
+	t.Run("Create In Location", func(t *testing.T) {
+		srv, err := services.CreateServer(user.ID, services.CreateServerRequest{
+			Name: "Location Placed", LocationID: &location.ID, Strategy: services.PlacementFewestServers, PackageID: pkg.ID,
+			Memory: 512, CPU: 100, Disk: 1024, Ports: []models.ServerPort{{Port: 25565, Primary: true}},
+		})
+		if err != nil {
+			t.Fatalf("Expected the server to be placed, got %v", err)
+		}
+		if srv.NodeID != idle.ID {
+			t.Errorf("Expected the server on the idle node, got %v", srv.NodeID)
+		}
+	})
+}
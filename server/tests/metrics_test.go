@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"birdactyl-panel-backend/internal/config"
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/handlers/server"
+	"birdactyl-panel-backend/internal/middleware"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestMetricsProxy(t *testing.T) {
+	requireDB(t)
+
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer metrics" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("range") == "99y" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success": false, "error": "invalid range"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"success": true, "data": {"path": %q, "range": %q, "resolution": 60, "samples": [{"time": 1, "memory_usage": 512}]}}`, r.URL.Path, r.URL.Query().Get("range"))
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	owner := &models.User{ID: uuid.New(), Username: "test_metrics_owner", Email: "test_metrics_owner@test.com"}
+	stranger := &models.User{ID: uuid.New(), Username: "test_metrics_stranger", Email: "test_metrics_stranger@test.com"}
+	database.DB.Create(owner)
+	database.DB.Create(stranger)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - Metrics", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "metrics"}
+	database.DB.Create(node)
+	srv := &models.Server{ID: uuid.New(), Name: "Metrics", NodeID: node.ID, UserID: owner.ID, Status: models.ServerStatusRunning}
+	database.DB.Create(srv)
+
+	defer func() {
+		database.DB.Where("id = ?", srv.ID).Delete(&models.Server{})
+		database.DB.Where("id = ?", node.ID).Delete(&models.Node{})
+		database.DB.Where("id IN ?", []uuid.UUID{owner.ID, stranger.ID}).Delete(&models.User{})
+	}()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if c.Get("X-User") == "stranger" {
+			c.Locals("user", stranger)
+		} else {
+			c.Locals("user", owner)
+		}
+		return c.Next()
+	})
+	app.Get("/servers/:id/metrics", server.GetServerMetrics)
+	app.Get("/admin/nodes/:id/metrics", handlers.AdminGetNodeMetrics)
+
+	get := func(path, user string) (*http.Response, map[string]interface{}) {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("X-User", user)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp, parseJSONResponse(resp)
+	}
+
+	t.Run("Server Metrics", func(t *testing.T) {
+		resp, data := get(fmt.Sprintf("/servers/%s/metrics?range=6h", srv.ID), "")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		result := data["data"].(map[string]interface{})
+		if result["path"] != fmt.Sprintf("/api/servers/%s/metrics", srv.ID) || result["range"] != "6h" {
+			t.Errorf("Expected request to be proxied with its range, got %v", result)
+		}
+		if samples := result["samples"].([]interface{}); len(samples) != 1 {
+			t.Errorf("Expected samples to be passed through, got %v", samples)
+		}
+	})
+
+	t.Run("Default Range", func(t *testing.T) {
+		_, data := get(fmt.Sprintf("/servers/%s/metrics", srv.ID), "")
+		if result := data["data"].(map[string]interface{}); result["range"] != "1h" {
+			t.Errorf("Expected default range of 1h, got %v", result["range"])
+		}
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		if resp, _ := get(fmt.Sprintf("/servers/%s/metrics?range=99y", srv.ID), ""); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected node error status to be passed through, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Requires Access", func(t *testing.T) {
+		if resp, _ := get(fmt.Sprintf("/servers/%s/metrics", srv.ID), "stranger"); resp.StatusCode == fiber.StatusOK {
+			t.Error("Expected metrics to be hidden from users without access")
+		}
+	})
+
+	t.Run("Node Metrics", func(t *testing.T) {
+		resp, data := get(fmt.Sprintf("/admin/nodes/%s/metrics?range=7d", node.ID), "")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		if result := data["data"].(map[string]interface{}); result["path"] != "/api/system/metrics" || result["range"] != "7d" {
+			t.Errorf("Expected node metrics to be proxied, got %v", result)
+		}
+		if resp, _ := get(fmt.Sprintf("/admin/nodes/%s/metrics", uuid.New()), ""); resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected 404 for an unknown node, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestOpenMetrics(t *testing.T) {
+	requireDB(t)
+
+	cfg := config.Get()
+	cfg.Metrics.Token = "scrape-token"
+	defer func() { cfg.Metrics.Token = "" }()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(middleware.RequestMetrics())
+	app.Get("/metrics", middleware.RequireMetricsToken(), handlers.Metrics)
+	app.Get("/limited/:id", middleware.ThousandTHR(middleware.ThousandTHRConfig{
+		RequestsPerMinute: 1,
+		BurstLimit:        1,
+	}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	scrape := func(token string) (*http.Response, string) {
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	t.Run("Requires Scrape Token", func(t *testing.T) {
+		if resp, _ := scrape(""); resp.StatusCode != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 without a token, got %d", resp.StatusCode)
+		}
+		if resp, _ := scrape("wrong"); resp.StatusCode != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 with the wrong token, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Disabled Without Token", func(t *testing.T) {
+		cfg.Metrics.Token = ""
+		defer func() { cfg.Metrics.Token = "scrape-token" }()
+		if resp, _ := scrape(""); resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected 404 when no scrape token is configured, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Exposition", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			app.Test(httptest.NewRequest("GET", fmt.Sprintf("/limited/%d", i), nil), -1)
+			app.Test(httptest.NewRequest("GET", fmt.Sprintf("/limited/%d", i), nil), -1)
+		}
+
+		resp, body := scrape("scrape-token")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/openmetrics-text") {
+			t.Errorf("Expected OpenMetrics content type, got %s", resp.Header.Get("Content-Type"))
+		}
+		for _, want := range []string{
+			`birdactyl_http_request_duration_seconds_count{method="GET",route="/limited/:id",status="200"} 3`,
+			`birdactyl_http_request_duration_seconds_count{method="GET",route="/limited/:id",status="429"} 3`,
+			`birdactyl_rate_limit_rejections_total{route="/limited/:id"} 3`,
+			`# TYPE birdactyl_nodes gauge`,
+			`birdactyl_nodes{state="online"}`,
+			`# TYPE birdactyl_schedule_runs counter`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("Expected exposition to contain %q", want)
+			}
+		}
+		if !strings.HasSuffix(body, "# EOF\n") {
+			t.Error("Expected exposition to end with # EOF")
+		}
+	})
+}
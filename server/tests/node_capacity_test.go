@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/handlers/server"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestNodeCapacity(t *testing.T) {
+	requireDB(t)
+
+	admin := &models.User{ID: uuid.New(), Username: "test_node_capacity", Email: "test_node_capacity@test.com", IsAdmin: true}
+	database.DB.Create(admin)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - Capacity", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "capacity", IsOnline: true}
+	database.DB.Create(node)
+	pkg := &models.Package{ID: uuid.New(), Name: "Capacity Package", DockerImage: "alpine", Startup: "true"}
+	database.DB.Create(pkg)
+	existing := &models.Server{ID: uuid.New(), Name: "Capacity Existing", NodeID: node.ID, UserID: admin.ID, PackageID: pkg.ID, Status: models.ServerStatusStopped, Memory: 3072, CPU: 100, Disk: 4096}
+	database.DB.Create(existing)
+
+	defer func() {
+		database.DB.Where("node_id = ?", node.ID).Delete(&models.Server{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Where("id = ?", node.ID).Delete(&models.Node{})
+		database.DB.Where("id = ?", admin.ID).Delete(&models.User{})
+	}()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", admin)
+		return c.Next()
+	})
+	app.Get("/admin/nodes/:id", handlers.AdminGetNode)
+	app.Patch("/admin/nodes/:id", handlers.AdminUpdateNode)
+	app.Patch("/admin/servers/:id/resources", server.AdminUpdateServerResources)
+
+	request := func(method, path string, body interface{}) (*http.Response, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp, parseJSONResponse(resp)
+	}
+	nodePath := fmt.Sprintf("/admin/nodes/%s", node.ID)
+
+	t.Run("Set Limits", func(t *testing.T) {
+		if resp, _ := request("PATCH", nodePath, map[string]interface{}{"memory_overallocate": -2}); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for an invalid overallocation, got %d", resp.StatusCode)
+		}
+		resp, data := request("PATCH", nodePath, map[string]interface{}{"name": node.Name, "memory_limit": 4096, "memory_overallocate": 25, "disk_limit": 8192})
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", resp.StatusCode, data)
+		}
+		stored, _ := services.GetNodeByID(node.ID)
+		if stored.MemoryLimit != 4096 || stored.MemoryOverallocate != 25 || stored.DiskLimit != 8192 || stored.DiskOverallocate != 0 {
+			t.Errorf("Expected limits to be stored, got %+v", stored)
+		}
+		*node = *stored
+	})
+
+	t.Run("Allocation Summary", func(t *testing.T) {
+		resp, data := request("GET", nodePath, nil)
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		allocation := data["data"].(map[string]interface{})["allocation"].(map[string]interface{})
+		memory := allocation["memory"].(map[string]interface{})
+		if allocation["servers"] != float64(1) || memory["allocated"] != float64(3072) || memory["max"] != float64(5120) {
+			t.Errorf("Unexpected allocation summary: %v", allocation)
+		}
+	})
+
+	t.Run("Create Checks Capacity", func(t *testing.T) {
+		warnings, err := services.CheckNodeCapacity(node, 1536, 100, 1024, uuid.Nil)
+		if err != nil || len(warnings) != 1 {
+			t.Errorf("Expected an overallocation warning, got %v, %v", warnings, err)
+		}
+		_, err = services.CreateServer(admin.ID, services.CreateServerRequest{Name: "Capacity Too Big", NodeID: node.ID, PackageID: pkg.ID, Memory: 4096, CPU: 100, Disk: 1024})
+		if !services.IsNodeCapacityError(err) {
+			t.Errorf("Expected a capacity error, got %v", err)
+		}
+		_, err = services.CreateServer(admin.ID, services.CreateServerRequest{Name: "Capacity Disk", NodeID: node.ID, PackageID: pkg.ID, Memory: 512, CPU: 100, Disk: 8192})
+		if !services.IsNodeCapacityError(err) {
+			t.Errorf("Expected disk without overallocation to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Resize Checks Capacity", func(t *testing.T) {
+		path := fmt.Sprintf("/admin/servers/%s/resources", existing.ID)
+		if resp, _ := request("PATCH", path, map[string]interface{}{"memory": 6144}); resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409 when growing past the node, got %d", resp.StatusCode)
+		}
+		resp, data := request("PATCH", path, map[string]interface{}{"memory": 4608})
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", resp.StatusCode, data)
+		}
+		if warnings, _ := data["warnings"].([]interface{}); len(warnings) != 1 {
+			t.Errorf("Expected an overallocation warning, got %v", data["warnings"])
+		}
+
+		database.DB.Model(&models.Node{}).Where("id = ?", node.ID).Update("memory_limit", 1024)
+		node.MemoryLimit = 1024
+		if _, _, err := services.UpdateServerResourcesAdmin(existing.ID, 2048, 0, 0); err != nil {
+			t.Errorf("Expected shrinking an overallocated server to be allowed, got %v", err)
+		}
+	})
+}
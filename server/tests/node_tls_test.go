@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/google/uuid"
+)
+
+func hostPort(t *testing.T, rawURL string) (string, int) {
+	t.Helper()
+	u, _ := url.Parse(rawURL)
+	host, portStr, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", rawURL, err)
+	}
+	port, _ := strconv.Atoi(portStr)
+	return host, port
+}
+
+func TestNodePairingTLS(t *testing.T) {
+	requireDB(t)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	csrDER, _ := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "axis"}}, key)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	var caPEM, certPEM, certAuth string
+	pairing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/pair":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			caPEM = body["ca_cert"]
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success":  true,
+				"token_id": "tlstest",
+				"token":    uuid.New().String(),
+				"csr":      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})),
+			})
+		case "/api/pair/certificate":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			certPEM = body["certificate"]
+			certAuth = r.Header.Get("Authorization")
+			w.Write([]byte(`{"success":true}`))
+		}
+	}))
+	defer pairing.Close()
+
+	host, port := hostPort(t, pairing.URL)
+	node, token, err := services.PairWithNode("test_tls_node_"+uuid.New().String()[:8], host, port, "http://panel.test", "123456")
+	if err != nil {
+		t.Fatalf("Pairing failed: %v", err)
+	}
+	defer database.DB.Unscoped().Where("id = ?", node.ID).Delete(&models.Node{})
+
+	if caPEM == "" {
+		t.Fatal("Expected the panel CA to be sent with the pairing request")
+	}
+	if certAuth != "Bearer "+token.DaemonToken {
+		t.Errorf("Expected certificate delivery to authenticate with the new token, got %q", certAuth)
+	}
+	if node.Scheme != models.NodeSchemeHTTPS {
+		t.Errorf("Expected paired node to use https, got %q", node.Scheme)
+	}
+
+	pair, err := tls.X509KeyPair([]byte(certPEM), keyPEM)
+	if err != nil {
+		t.Fatalf("Issued certificate does not match the node key: %v", err)
+	}
+	if node.CertFingerprint != services.CertFingerprint(pair.Certificate[0]) {
+		t.Errorf("Expected fingerprint of the issued certificate to be pinned")
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(caPEM))
+	axis := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token.DaemonToken || r.URL.Query().Get("token") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"hostname":"tls-node"}}`))
+	}))
+	axis.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	axis.StartTLS()
+	defer axis.Close()
+
+	_, tlsPort := hostPort(t, axis.URL)
+	database.DB.Model(node).Update("port", tlsPort)
+
+	findNode := func() *models.Node {
+		nodes, err := services.RefreshNodes()
+		if err != nil {
+			t.Fatalf("Failed to refresh nodes: %v", err)
+		}
+		for i := range nodes {
+			if nodes[i].ID == node.ID {
+				return &nodes[i]
+			}
+		}
+		t.Fatal("Paired node not found")
+		return nil
+	}
+
+	t.Run("Mutual TLS Ping", func(t *testing.T) {
+		if n := findNode(); !n.IsOnline || n.SystemInfo.Hostname != "tls-node" {
+			t.Errorf("Expected node to be reachable over mutual TLS, online=%v", n.IsOnline)
+		}
+	})
+
+	t.Run("Fingerprint Mismatch", func(t *testing.T) {
+		database.DB.Model(node).Update("cert_fingerprint", services.CertFingerprint([]byte("other")))
+		if n := findNode(); n.IsOnline {
+			t.Error("Expected node with a mismatched certificate to be offline")
+		}
+	})
+}
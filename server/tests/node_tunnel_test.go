@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/middleware"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/hashicorp/yamux"
+)
+
+func TestNodeTunnel(t *testing.T) {
+	requireDB(t)
+
+	node, token, err := services.CreateNode("test_tunnel_node_"+uuid.New().String()[:8], "nat.local", 8443)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+	defer database.DB.Unscoped().Where("id = ?", node.ID).Delete(&models.Node{})
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	nodes := app.Group("/nodes", middleware.RequireNodeAuth())
+	nodes.Use("/tunnel", func(c *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+		return fiber.ErrUpgradeRequired
+	})
+	nodes.Get("/tunnel", websocket.New(handlers.NodeTunnel))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go app.Listener(ln)
+	defer app.Shutdown()
+
+	ws, _, err := gorilla.DefaultDialer.Dial("ws://"+ln.Addr().String()+"/nodes/tunnel", http.Header{"Authorization": {"Bearer " + token.DaemonToken}})
+	if err != nil {
+		t.Fatalf("Failed to open tunnel: %v", err)
+	}
+	session, err := yamux.Server(services.NewTunnelConn(ws), nil)
+	if err != nil {
+		t.Fatalf("Failed to start tunnel session: %v", err)
+	}
+
+	upgrader := gorilla.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/system", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token.DaemonToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"hostname":"tunnel-node"}}`))
+	})
+	mux.HandleFunc("/api/servers/console/ws", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token.DaemonToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.WriteMessage(gorilla.TextMessage, []byte("hello"))
+		conn.Close()
+	})
+	go http.Serve(session, mux)
+
+	t.Run("Heartbeat Over Tunnel", func(t *testing.T) {
+		stream, err := session.Open()
+		if err != nil {
+			t.Fatalf("Failed to open stream: %v", err)
+		}
+		defer stream.Close()
+		json.NewEncoder(stream).Encode(map[string]interface{}{
+			"type": "heartbeat",
+			"data": map[string]interface{}{"system": map[string]interface{}{"hostname": "nat-node"}},
+		})
+		var resp struct {
+			Success bool `json:"success"`
+		}
+		if err := json.NewDecoder(stream).Decode(&resp); err != nil || !resp.Success {
+			t.Fatalf("Expected heartbeat to be accepted, err=%v", err)
+		}
+
+		var stored models.Node
+		database.DB.Where("id = ?", node.ID).First(&stored)
+		if !stored.Tunnel || !stored.IsOnline || stored.SystemInfo.Hostname != "nat-node" {
+			t.Errorf("Expected node to be online over the tunnel, got tunnel=%v online=%v", stored.Tunnel, stored.IsOnline)
+		}
+	})
+
+	findNode := func() *models.Node {
+		nodes, err := services.RefreshNodes()
+		if err != nil {
+			t.Fatalf("Failed to refresh nodes: %v", err)
+		}
+		for i := range nodes {
+			if nodes[i].ID == node.ID {
+				return &nodes[i]
+			}
+		}
+		t.Fatal("Tunnel node not found")
+		return nil
+	}
+
+	t.Run("Requests Routed Over Tunnel", func(t *testing.T) {
+		if n := findNode(); !n.IsOnline || n.SystemInfo.Hostname != "tunnel-node" {
+			t.Errorf("Expected node to answer over the tunnel, online=%v", n.IsOnline)
+		}
+	})
+
+	t.Run("WebSocket Over Tunnel", func(t *testing.T) {
+		var stored models.Node
+		database.DB.Where("id = ?", node.ID).First(&stored)
+		conn, err := services.DialNodeWebSocket(&stored, "/api/servers/console/ws")
+		if err != nil {
+			t.Fatalf("Failed to dial websocket over tunnel: %v", err)
+		}
+		defer conn.Close()
+		if _, msg, err := conn.ReadMessage(); err != nil || string(msg) != "hello" {
+			t.Errorf("Expected message over tunnel, got %q (%v)", msg, err)
+		}
+	})
+
+	t.Run("Disconnect", func(t *testing.T) {
+		session.Close()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			var stored models.Node
+			database.DB.Where("id = ?", node.ID).First(&stored)
+			if !stored.IsOnline {
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		if n := findNode(); n.IsOnline {
+			t.Error("Expected node to go offline when the tunnel closes")
+		}
+	})
+}
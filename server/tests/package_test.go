@@ -70,4 +70,28 @@ func TestPackageRoutes(t *testing.T) {
 			t.Errorf("Expected status 201, got %d. error: %v", resp.StatusCode, body["error"])
 		}
 	})
+
+	t.Run("Create Package With Invalid Backup Hooks", func(t *testing.T) {
+		for _, hooks := range []map[string]interface{}{
+			{"pre": []map[string]interface{}{{"command": " "}}},
+			{"pre": []map[string]interface{}{{"command": "save-all", "wait_for": "Saved ("}}},
+			{"post": []map[string]interface{}{{"command": "save-on", "timeout": -1}}},
+		} {
+			req := httptest.NewRequest("POST", "/packages", toJSONBody(map[string]interface{}{
+				"name":         fmt.Sprintf("test_pkg_%s", uuid.New().String()[:8]),
+				"docker_image": "debian:latest",
+				"startup":      "./start.sh",
+				"backup_hooks": hooks,
+			}))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("Failed to create package: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("Expected status 400 for hooks %v, got %d", hooks, resp.StatusCode)
+			}
+		}
+	})
 }
@@ -1,13 +1,23 @@
 package tests
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"birdactyl-panel-backend/internal/config"
 	"birdactyl-panel-backend/internal/database"
 	"birdactyl-panel-backend/internal/handlers"
 	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -63,3 +73,699 @@ func TestScheduleRoutes(t *testing.T) {
 		}
 	})
 }
+
+func TestScheduleRunHistory(t *testing.T) {
+	requireDB(t)
+
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/kill") {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"success": false, "error": "container not found"}`))
+			return
+		}
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	app, adminUser := mockScheduleApp()
+	defer database.DB.Where("id = ?", adminUser.ID).Delete(&models.User{})
+
+	testNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Schedules", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "mock_token_123"}
+	database.DB.Create(testNode)
+	defer database.DB.Where("id = ?", testNode.ID).Delete(&models.Node{})
+
+	testServer := &models.Server{ID: uuid.New(), Name: "Mock Server Schedules", NodeID: testNode.ID, UserID: adminUser.ID, PackageID: uuid.New()}
+	database.DB.Create(testServer)
+	defer database.DB.Where("id = ?", testServer.ID).Delete(&models.Server{})
+
+	tasks, _ := json.Marshal([]models.ScheduleTask{
+		{Sequence: 1, Action: "command", Payload: "say restarting"},
+		{Sequence: 2, Action: "power", Payload: "kill"},
+	})
+	schedule := &models.Schedule{ServerID: testServer.ID, Name: "Nightly", CronExpression: "0 0 4 * * *", IsActive: true, Tasks: tasks}
+	database.DB.Create(schedule)
+	defer services.DeleteSchedule(schedule.ID)
+
+	app.Get("/servers/:id/schedules/:scheduleId/runs", handlers.GetScheduleRuns)
+
+	services.RunScheduleNow(schedule.ID)
+
+	var run models.ScheduleRun
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		runs, _ := services.GetScheduleRuns(schedule.ID, 1)
+		if len(runs) == 1 && runs[0].FinishedAt != nil {
+			run = runs[0]
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if run.FinishedAt == nil {
+		t.Fatal("Schedule run did not finish")
+	}
+	if run.Trigger != models.ScheduleTriggerManual {
+		t.Errorf("Expected trigger manual, got %s", run.Trigger)
+	}
+	if run.Status != models.ScheduleRunFailed {
+		t.Errorf("Expected status failed, got %s", run.Status)
+	}
+
+	var results []models.ScheduleTaskResult
+	json.Unmarshal(run.Results, &results)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 task results, got %d", len(results))
+	}
+	if !results[0].Success {
+		t.Errorf("Expected command task to succeed, got error %q", results[0].Error)
+	}
+	if results[1].Success || !strings.Contains(results[1].Error, "container not found") {
+		t.Errorf("Expected power task to fail with node error, got %+v", results[1])
+	}
+
+	t.Run("Get Runs", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/schedules/%s/runs", testServer.ID, schedule.ID), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to get schedule runs: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+		body := parseJSONResponse(resp)
+		if data, ok := body["data"].([]interface{}); !ok || len(data) != 1 {
+			t.Errorf("Expected 1 run in response, got %v", body["data"])
+		}
+	})
+
+	t.Run("Get Runs - Invalid Schedule", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/servers/%s/schedules/invalid-uuid/runs", testServer.ID), nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to get schedule runs: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func runScheduleTasks(t *testing.T, serverID uuid.UUID, tasks []models.ScheduleTask) models.ScheduleRun {
+	t.Helper()
+	tasksJSON, _ := json.Marshal(tasks)
+	schedule := &models.Schedule{ServerID: serverID, Name: "Chain", CronExpression: "0 0 4 * * *", IsActive: true, Tasks: tasksJSON}
+	database.DB.Create(schedule)
+	t.Cleanup(func() { services.DeleteSchedule(schedule.ID) })
+
+	services.RunScheduleNow(schedule.ID)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		runs, _ := services.GetScheduleRuns(schedule.ID, 1)
+		if len(runs) == 1 && runs[0].FinishedAt != nil {
+			return runs[0]
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("Schedule run did not finish")
+	return models.ScheduleRun{}
+}
+
+func TestScheduleConditionalChain(t *testing.T) {
+	requireDB(t)
+
+	var commands []string
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			w.Write([]byte(`{"success": true, "data": {"status": "stopped", "stats": {"cpu": 12.5}}}`))
+		case strings.HasSuffix(r.URL.Path, "/start"):
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"success": false, "error": "image pull failed"}`))
+		case strings.HasSuffix(r.URL.Path, "/command"):
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			commands = append(commands, body["command"])
+			w.Write([]byte(`{"success": true}`))
+		default:
+			w.Write([]byte(`{"success": true}`))
+		}
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	app, adminUser := mockScheduleApp()
+	defer database.DB.Where("id = ?", adminUser.ID).Delete(&models.User{})
+
+	testNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Schedule Chains", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "mock_token_123"}
+	database.DB.Create(testNode)
+	defer database.DB.Where("id = ?", testNode.ID).Delete(&models.Node{})
+
+	testServer := &models.Server{ID: uuid.New(), Name: "Mock Server Schedule Chains", NodeID: testNode.ID, UserID: adminUser.ID, PackageID: uuid.New()}
+	database.DB.Create(testServer)
+	defer database.DB.Where("id = ?", testServer.ID).Delete(&models.Server{})
+
+	runSchedule := func(t *testing.T, tasks []models.ScheduleTask) models.ScheduleRun {
+		return runScheduleTasks(t, testServer.ID, tasks)
+	}
+
+	t.Run("Unmet Condition Halts Chain", func(t *testing.T) {
+		commands = nil
+		run := runSchedule(t, []models.ScheduleTask{
+			{Sequence: 1, Action: "if_state", Payload: "running"},
+			{Sequence: 2, Action: "command", Payload: "say hello"},
+		})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Errorf("Expected status success, got %s (%s)", run.Status, run.Error)
+		}
+		var results []models.ScheduleTaskResult
+		json.Unmarshal(run.Results, &results)
+		if len(results) != 2 || !results[0].Halted || !results[1].Skipped {
+			t.Errorf("Expected condition to halt and command to be skipped, got %+v", results)
+		}
+		if len(commands) != 0 {
+			t.Errorf("Expected no commands sent, got %v", commands)
+		}
+	})
+
+	t.Run("Met Conditions Continue", func(t *testing.T) {
+		commands = nil
+		run := runSchedule(t, []models.ScheduleTask{
+			{Sequence: 1, Action: "if_state", Payload: "stopped"},
+			{Sequence: 2, Action: "if_cpu_above", Payload: "10"},
+			{Sequence: 3, Action: "wait_for_state", Payload: "stopped:5"},
+			{Sequence: 4, Action: "command", Payload: "say hello"},
+		})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Errorf("Expected status success, got %s (%s)", run.Status, run.Error)
+		}
+		if len(commands) != 1 || commands[0] != "say hello" {
+			t.Errorf("Expected command to be sent, got %v", commands)
+		}
+	})
+
+	t.Run("Continue On Failure", func(t *testing.T) {
+		commands = nil
+		run := runSchedule(t, []models.ScheduleTask{
+			{Sequence: 1, Action: "power", Payload: "start", ContinueOnFailure: true},
+			{Sequence: 2, Action: "command", Payload: "say after"},
+			{Sequence: 3, Action: "power", Payload: "start"},
+			{Sequence: 4, Action: "command", Payload: "say never"},
+		})
+		if run.Status != models.ScheduleRunFailed {
+			t.Errorf("Expected status failed, got %s", run.Status)
+		}
+		if len(commands) != 1 || commands[0] != "say after" {
+			t.Errorf("Expected only the first command to be sent, got %v", commands)
+		}
+	})
+
+	t.Run("Stored Tasks Continue By Default", func(t *testing.T) {
+		var tasks []models.ScheduleTask
+		if err := json.Unmarshal([]byte(`[{"sequence":1,"action":"power","payload":"start"},{"sequence":2,"action":"command","payload":"say after"}]`), &tasks); err != nil {
+			t.Fatal(err)
+		}
+		if !tasks[0].ContinueOnFailure {
+			t.Fatal("Expected a task without continue_on_failure to continue")
+		}
+		commands = nil
+		runSchedule(t, tasks)
+		if len(commands) != 1 || commands[0] != "say after" {
+			t.Errorf("Expected the chain to continue past the failure, got %v", commands)
+		}
+	})
+
+	t.Run("Reject Invalid Tasks", func(t *testing.T) {
+		app.Post("/servers/:id/schedules", handlers.CreateSchedule)
+		body := toJSONBody(map[string]interface{}{
+			"name":            "Invalid",
+			"cron_expression": "0 0 4 * * *",
+			"tasks":           []models.ScheduleTask{{Sequence: 1, Action: "wait_for_state", Payload: "exploded"}},
+		})
+		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/schedules", testServer.ID), body)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to create schedule: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestScheduleMaintenanceTasks(t *testing.T) {
+	requireDB(t)
+
+	type nodeRequest struct {
+		Method string
+		Path   string
+		Query  string
+		Body   map[string]interface{}
+	}
+	var (
+		mu       sync.Mutex
+		requests []nodeRequest
+	)
+	now := time.Now()
+	backupList := fmt.Sprintf(`{"success": true, "data": [
+		{"id": "in-progress", "name": "in-progress", "created_at": %d, "completed": false},
+		{"id": "fresh", "name": "fresh", "created_at": %d, "completed": true},
+		{"id": "day-old", "name": "day-old", "created_at": %d, "completed": true},
+		{"id": "ten-days", "name": "ten-days", "created_at": %d, "completed": true},
+		{"id": "twenty-days", "name": "twenty-days", "created_at": %d, "completed": true}
+	]}`, now.Unix(), now.Add(-time.Hour).Unix(), now.AddDate(0, 0, -1).Unix(), now.AddDate(0, 0, -10).Unix(), now.AddDate(0, 0, -20).Unix())
+
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		requests = append(requests, nodeRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query().Get("path"), Body: body})
+		mu.Unlock()
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/backups") {
+			w.Write([]byte(backupList))
+			return
+		}
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer mockDaemon.Close()
+
+	takeRequests := func() []nodeRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		reqs := requests
+		requests = nil
+		return reqs
+	}
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	_, adminUser := mockScheduleApp()
+	defer database.DB.Where("id = ?", adminUser.ID).Delete(&models.User{})
+
+	testNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Schedule Maintenance", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "mock_token_123"}
+	database.DB.Create(testNode)
+	defer database.DB.Where("id = ?", testNode.ID).Delete(&models.Node{})
+
+	testServer := &models.Server{ID: uuid.New(), Name: "Maintenance", NodeID: testNode.ID, UserID: adminUser.ID, PackageID: uuid.New()}
+	database.DB.Create(testServer)
+	defer database.DB.Where("id = ?", testServer.ID).Delete(&models.Server{})
+
+	t.Run("Named Backup", func(t *testing.T) {
+		takeRequests()
+		run := runScheduleTasks(t, testServer.ID, []models.ScheduleTask{
+			{Sequence: 1, Action: "backup", Payload: "{{.ServerName}}-{{.Date}}"},
+		})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Fatalf("Expected status success, got %s (%s)", run.Status, run.Error)
+		}
+		reqs := takeRequests()
+		want := "Maintenance-" + run.StartedAt.Format("2006-01-02")
+		if len(reqs) != 2 || !strings.HasSuffix(reqs[0].Path, "/backups/storage") ||
+			reqs[1].Method != "POST" || !strings.HasSuffix(reqs[1].Path, "/backups") || reqs[1].Body["name"] != want {
+			t.Errorf("Expected backup named %q, got %+v", want, reqs)
+		}
+	})
+
+	t.Run("Prune Backups", func(t *testing.T) {
+		takeRequests()
+		run := runScheduleTasks(t, testServer.ID, []models.ScheduleTask{
+			{Sequence: 1, Action: "prune_backups", Payload: `{"keep": 3, "max_age_days": 5}`},
+		})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Fatalf("Expected status success, got %s (%s)", run.Status, run.Error)
+		}
+		var deleted []string
+		for _, r := range takeRequests() {
+			if r.Method == "DELETE" {
+				deleted = append(deleted, r.Path[strings.LastIndex(r.Path, "/")+1:])
+			}
+		}
+		if strings.Join(deleted, ",") != "ten-days,twenty-days" {
+			t.Errorf("Expected ten-days and twenty-days to be pruned, got %v", deleted)
+		}
+	})
+
+	t.Run("File Tasks", func(t *testing.T) {
+		takeRequests()
+		run := runScheduleTasks(t, testServer.ID, []models.ScheduleTask{
+			{Sequence: 1, Action: "delete_file", Payload: "logs/old.log"},
+			{Sequence: 2, Action: "truncate_file", Payload: "logs/latest.log"},
+			{Sequence: 3, Action: "compress", Payload: `{"paths": ["world"], "dest": "archives/world-{{.Date}}.zip", "format": "zip"}`},
+			{Sequence: 4, Action: "decompress", Payload: `{"path": "plugins.zip"}`},
+		})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Fatalf("Expected status success, got %s (%s)", run.Status, run.Error)
+		}
+		reqs := takeRequests()
+		if len(reqs) != 4 {
+			t.Fatalf("Expected 4 node requests, got %+v", reqs)
+		}
+		if reqs[0].Method != "DELETE" || reqs[0].Query != "logs/old.log" {
+			t.Errorf("Unexpected delete request: %+v", reqs[0])
+		}
+		if !strings.HasSuffix(reqs[1].Path, "/files/write") || reqs[1].Body["path"] != "logs/latest.log" || reqs[1].Body["content"] != "" {
+			t.Errorf("Unexpected truncate request: %+v", reqs[1])
+		}
+		wantDest := "archives/world-" + run.StartedAt.Format("2006-01-02") + ".zip"
+		if !strings.HasSuffix(reqs[2].Path, "/files/bulk-compress") || reqs[2].Body["dest"] != wantDest || reqs[2].Body["format"] != "zip" {
+			t.Errorf("Unexpected compress request: %+v", reqs[2])
+		}
+		if !strings.HasSuffix(reqs[3].Path, "/files/decompress") || reqs[3].Body["path"] != "plugins.zip" || reqs[3].Body["dest"] != "/" {
+			t.Errorf("Unexpected decompress request: %+v", reqs[3])
+		}
+	})
+
+	t.Run("Webhook", func(t *testing.T) {
+		received := make(chan map[string]string, 1)
+		hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			body["auth"] = r.Header.Get("X-Token")
+			received <- body
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer hook.Close()
+
+		task := models.ScheduleTask{Sequence: 1, Action: "webhook", Payload: fmt.Sprintf(
+			`{"url": %q, "headers": {"X-Token": "secret"}, "body": "{\"server\": {{json .ServerName}}, \"id\": \"{{.ServerID}}\"}"}`, hook.URL)}
+
+		cfg := config.Get()
+		cfg.Schedules.AllowPrivateWebhooks = false
+		run := runScheduleTasks(t, testServer.ID, []models.ScheduleTask{task})
+		if run.Status != models.ScheduleRunFailed || !strings.Contains(run.Results.String(), "not allowed") {
+			t.Errorf("Expected loopback webhook to be blocked, got %s %s", run.Status, run.Results)
+		}
+
+		cfg.Schedules.AllowPrivateWebhooks = true
+		defer func() { cfg.Schedules.AllowPrivateWebhooks = false }()
+		run = runScheduleTasks(t, testServer.ID, []models.ScheduleTask{task})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Fatalf("Expected status success, got %s (%s) %s", run.Status, run.Error, run.Results)
+		}
+		select {
+		case body := <-received:
+			if body["server"] != "Maintenance" || body["id"] != testServer.ID.String() || body["auth"] != "secret" {
+				t.Errorf("Unexpected webhook payload: %v", body)
+			}
+		default:
+			t.Error("Expected webhook to be delivered")
+		}
+	})
+
+	t.Run("Emit Event", func(t *testing.T) {
+		events := make(chan map[string]string, 1)
+		services.SetScheduleEventEmitter(func(data map[string]string) { events <- data })
+		defer services.SetScheduleEventEmitter(nil)
+
+		run := runScheduleTasks(t, testServer.ID, []models.ScheduleTask{
+			{Sequence: 1, Action: "emit_event", Payload: `{"event": "maintenance.done", "data": {"note": "{{.ScheduleName}}", "server_id": "spoofed"}}`},
+		})
+		if run.Status != models.ScheduleRunSuccess {
+			t.Fatalf("Expected status success, got %s (%s)", run.Status, run.Error)
+		}
+		select {
+		case data := <-events:
+			if data["event"] != "maintenance.done" || data["note"] != "Chain" || data["server_id"] != testServer.ID.String() {
+				t.Errorf("Unexpected event data: %v", data)
+			}
+		default:
+			t.Error("Expected event to be emitted")
+		}
+	})
+
+	t.Run("Reject Invalid Tasks", func(t *testing.T) {
+		invalid := []models.ScheduleTask{
+			{Action: "backup", Payload: "{{.ServerName"},
+			{Action: "prune_backups", Payload: `{}`},
+			{Action: "prune_backups", Payload: `{"keep": -1}`},
+			{Action: "delete_file", Payload: "/"},
+			{Action: "truncate_file", Payload: ""},
+			{Action: "compress", Payload: `{"paths": ["world"], "dest": "world.rar", "format": "rar"}`},
+			{Action: "decompress", Payload: `{"dest": "/"}`},
+			{Action: "webhook", Payload: `{"url": "ftp://example.com"}`},
+			{Action: "emit_event", Payload: `{"event": "bad event name"}`},
+		}
+		for _, task := range invalid {
+			if err := services.ValidateScheduleTasks([]models.ScheduleTask{task}); err == nil {
+				t.Errorf("Expected %s task with payload %q to be rejected", task.Action, task.Payload)
+			}
+		}
+	})
+}
+
+func TestScheduleMisfirePolicy(t *testing.T) {
+	requireDB(t)
+
+	var (
+		mu       sync.Mutex
+		commands int
+	)
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/command") {
+			mu.Lock()
+			commands++
+			mu.Unlock()
+		}
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	_, adminUser := mockScheduleApp()
+	defer database.DB.Where("id = ?", adminUser.ID).Delete(&models.User{})
+
+	testNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Schedule Misfires", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "mock_token_123"}
+	database.DB.Create(testNode)
+	defer database.DB.Where("id = ?", testNode.ID).Delete(&models.Node{})
+
+	testServer := &models.Server{ID: uuid.New(), Name: "Mock Server Schedule Misfires", NodeID: testNode.ID, UserID: adminUser.ID, PackageID: uuid.New()}
+	database.DB.Create(testServer)
+	defer database.DB.Where("id = ?", testServer.ID).Delete(&models.Server{})
+
+	missedSince := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	createMissed := func(t *testing.T, policy models.MisfirePolicy) *models.Schedule {
+		t.Helper()
+		tasks, _ := json.Marshal([]models.ScheduleTask{{Sequence: 1, Action: "command", Payload: "say catch up"}})
+		schedule := &models.Schedule{ServerID: testServer.ID, Name: "Missed", CronExpression: "0 0 * * * *", IsActive: true, MisfirePolicy: policy, NextRunAt: &missedSince, Tasks: tasks}
+		database.DB.Create(schedule)
+		t.Cleanup(func() { services.DeleteSchedule(schedule.ID) })
+		mu.Lock()
+		commands = 0
+		mu.Unlock()
+		return schedule
+	}
+
+	waitForRuns := func(t *testing.T, scheduleID uuid.UUID, want int) []models.ScheduleRun {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			runs, _ := services.GetScheduleRuns(scheduleID, 0)
+			done := len(runs) >= want
+			for _, r := range runs {
+				if r.FinishedAt == nil {
+					done = false
+				}
+			}
+			if done {
+				time.Sleep(200 * time.Millisecond)
+				runs, _ = services.GetScheduleRuns(scheduleID, 0)
+				return runs
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		runs, _ := services.GetScheduleRuns(scheduleID, 0)
+		return runs
+	}
+
+	t.Run("Skip", func(t *testing.T) {
+		schedule := createMissed(t, models.MisfireSkip)
+		services.ReconcileSchedules()
+		runs := waitForRuns(t, schedule.ID, 1)
+		if len(runs) != 1 || runs[0].Status != models.ScheduleRunSkipped || runs[0].Trigger != models.ScheduleTriggerCatchUp {
+			t.Fatalf("Expected one skipped catch-up run, got %+v", runs)
+		}
+		if !strings.Contains(runs[0].Error, "missed 4 runs") {
+			t.Errorf("Expected missed run count in error, got %q", runs[0].Error)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if commands != 0 {
+			t.Errorf("Expected no commands, got %d", commands)
+		}
+		updated, _ := services.GetScheduleByID(schedule.ID)
+		if updated.NextRunAt == nil || !updated.NextRunAt.After(time.Now()) {
+			t.Errorf("Expected next run to move into the future, got %v", updated.NextRunAt)
+		}
+	})
+
+	t.Run("Run Once Across Replicas", func(t *testing.T) {
+		schedule := createMissed(t, models.MisfireRunOnce)
+		services.ReconcileSchedules()
+		services.ReconcileSchedules()
+		runs := waitForRuns(t, schedule.ID, 1)
+		if len(runs) != 1 || runs[0].Status != models.ScheduleRunSuccess || runs[0].Trigger != models.ScheduleTriggerCatchUp {
+			t.Fatalf("Expected exactly one catch-up run, got %+v", runs)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if commands != 1 {
+			t.Errorf("Expected 1 command, got %d", commands)
+		}
+	})
+
+	t.Run("Run All", func(t *testing.T) {
+		schedule := createMissed(t, models.MisfireRunAll)
+		services.ReconcileSchedules()
+		runs := waitForRuns(t, schedule.ID, 4)
+		if len(runs) != 4 {
+			t.Fatalf("Expected 4 catch-up runs, got %d", len(runs))
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if commands != 4 {
+			t.Errorf("Expected 4 commands, got %d", commands)
+		}
+	})
+
+	t.Run("Leased Elsewhere", func(t *testing.T) {
+		schedule := createMissed(t, models.MisfireRunOnce)
+		leaseUntil := time.Now().Add(time.Minute)
+		database.DB.Model(schedule).UpdateColumns(map[string]interface{}{"lease_owner": "other-panel", "lease_expires_at": leaseUntil})
+
+		services.ReconcileSchedules()
+		time.Sleep(300 * time.Millisecond)
+		if runs, _ := services.GetScheduleRuns(schedule.ID, 0); len(runs) != 0 {
+			t.Errorf("Expected no runs while another panel holds the lease, got %d", len(runs))
+		}
+		updated, _ := services.GetScheduleByID(schedule.ID)
+		if updated.NextRunAt == nil || !updated.NextRunAt.Equal(missedSince) {
+			t.Errorf("Expected next run to stay at %v, got %v", missedSince, updated.NextRunAt)
+		}
+	})
+
+	t.Run("Reject Invalid Policy", func(t *testing.T) {
+		if err := services.ValidateMisfirePolicy("sometimes"); err == nil {
+			t.Error("Expected invalid misfire policy to be rejected")
+		}
+		if err := services.ValidateCronExpression("every tuesday"); err == nil {
+			t.Error("Expected invalid cron expression to be rejected")
+		}
+	})
+}
+
+func TestScheduleTimezone(t *testing.T) {
+	requireDB(t)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+
+	t.Run("Next Run Follows DST", func(t *testing.T) {
+		schedule := &models.Schedule{CronExpression: "0 0 5 * * *", Timezone: "Europe/Berlin"}
+		cases := []struct {
+			after time.Time
+			want  time.Time
+		}{
+			{time.Date(2026, 3, 27, 12, 0, 0, 0, time.UTC), time.Date(2026, 3, 28, 4, 0, 0, 0, time.UTC)},
+			{time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC), time.Date(2026, 3, 29, 3, 0, 0, 0, time.UTC)},
+			{time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC), time.Date(2026, 10, 25, 4, 0, 0, 0, time.UTC)},
+		}
+		for _, c := range cases {
+			next, err := services.NextScheduleRun(schedule, c.after)
+			if err != nil {
+				t.Fatalf("Failed to compute next run: %v", err)
+			}
+			if !next.Equal(c.want) {
+				t.Errorf("After %s expected %s, got %s", c.after, c.want, next)
+			}
+			if next.In(berlin).Hour() != 5 || next.Location().String() != "Europe/Berlin" {
+				t.Errorf("Expected 05:00 Berlin time, got %s", next)
+			}
+		}
+	})
+
+	app, adminUser := mockScheduleApp()
+	defer database.DB.Where("id = ?", adminUser.ID).Delete(&models.User{})
+
+	testNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Schedule Timezones", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "mock_token_123"}
+	database.DB.Create(testNode)
+	defer database.DB.Where("id = ?", testNode.ID).Delete(&models.Node{})
+
+	testServer := &models.Server{ID: uuid.New(), Name: "Mock Server Schedule Timezones", NodeID: testNode.ID, UserID: adminUser.ID, PackageID: uuid.New()}
+	database.DB.Create(testServer)
+	defer database.DB.Where("id = ?", testServer.ID).Delete(&models.Server{})
+
+	app.Post("/servers/:id/schedules", handlers.CreateSchedule)
+
+	createSchedule := func(tz string) *http.Response {
+		body := toJSONBody(map[string]interface{}{
+			"name":            "Berlin Restart",
+			"cron_expression": "0 0 5 * * *",
+			"timezone":        tz,
+			"is_active":       true,
+			"tasks":           []models.ScheduleTask{{Sequence: 1, Action: "power", Payload: "restart"}},
+		})
+		req := httptest.NewRequest("POST", fmt.Sprintf("/servers/%s/schedules", testServer.ID), body)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Failed to create schedule: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("Create With Timezone", func(t *testing.T) {
+		resp := createSchedule("Europe/Berlin")
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+		}
+		var result struct {
+			Data struct {
+				ID        uuid.UUID `json:"id"`
+				Timezone  string    `json:"timezone"`
+				NextRunAt string    `json:"next_run_at"`
+			} `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&result)
+		defer services.DeleteSchedule(result.Data.ID)
+
+		if result.Data.Timezone != "Europe/Berlin" {
+			t.Errorf("Expected timezone Europe/Berlin, got %q", result.Data.Timezone)
+		}
+		if !strings.HasSuffix(result.Data.NextRunAt, "+01:00") && !strings.HasSuffix(result.Data.NextRunAt, "+02:00") {
+			t.Errorf("Expected next_run_at with Berlin offset, got %q", result.Data.NextRunAt)
+		}
+
+		stored, err := services.GetScheduleByID(result.Data.ID)
+		if err != nil {
+			t.Fatalf("Failed to load schedule: %v", err)
+		}
+		if stored.NextRunAt == nil || stored.NextRunAt.Location().String() != "Europe/Berlin" || stored.NextRunAt.Hour() != 5 {
+			t.Errorf("Expected stored next run at 05:00 Berlin time, got %v", stored.NextRunAt)
+		}
+	})
+
+	t.Run("Reject Unknown Timezone", func(t *testing.T) {
+		resp := createSchedule("Mars/Olympus_Mons")
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/handlers/server"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestServerStateSync(t *testing.T) {
+	requireDB(t)
+
+	var starts int32
+	mockDaemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/start") {
+			atomic.AddInt32(&starts, 1)
+		}
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer mockDaemon.Close()
+
+	u, _ := url.Parse(mockDaemon.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	owner := &models.User{ID: uuid.New(), Username: "test_server_state", Email: "test_server_state@test.com"}
+	database.DB.Create(owner)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - Server State", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "state"}
+	database.DB.Create(node)
+	otherNode := &models.Node{ID: uuid.New(), Name: "Mock Node - Server State Other", FQDN: host, Port: port, TokenID: uuid.New().String(), DaemonToken: "other"}
+	database.DB.Create(otherNode)
+	pkg := &models.Package{ID: uuid.New(), Name: "Server State Package", DockerImage: "alpine", Startup: "true", CrashPolicy: []byte(`{"max_restarts":2,"window":600,"backoff":0}`)}
+	database.DB.Create(pkg)
+	srv := &models.Server{ID: uuid.New(), Name: "Server State", NodeID: node.ID, UserID: owner.ID, PackageID: pkg.ID, Status: models.ServerStatusStopped}
+	database.DB.Create(srv)
+
+	defer func() {
+		database.DB.Where("server_id = ?", srv.ID).Delete(&models.ServerCrash{})
+		database.DB.Where("id = ?", srv.ID).Delete(&models.Server{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Where("id IN ?", []uuid.UUID{node.ID, otherNode.ID}).Delete(&models.Node{})
+		database.DB.Where("id = ?", owner.ID).Delete(&models.User{})
+	}()
+
+	var crashes []*models.ServerCrash
+	services.SetServerStateListener(func(server *models.Server, report services.ServerStateReport, crash *models.ServerCrash) {
+		if crash != nil {
+			crashes = append(crashes, crash)
+		}
+	})
+	defer services.SetServerStateListener(nil)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", owner)
+		if c.Get("X-Node") == otherNode.DaemonToken {
+			c.Locals("node", otherNode)
+		} else {
+			c.Locals("node", node)
+		}
+		return c.Next()
+	})
+	app.Post("/internal/nodes/servers/state", handlers.NodeServerState)
+	app.Get("/servers/:id/crashes", server.GetServerCrashes)
+	app.Put("/servers/:id/crash-policy", server.UpdateServerCrashPolicy)
+
+	request := func(method, path string, body interface{}, token string) (*http.Response, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Node", token)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp, parseJSONResponse(resp)
+	}
+	now := time.Now().UTC()
+	at := 0
+	report := func(body map[string]interface{}) *http.Response {
+		at++
+		body["server_id"] = srv.ID.String()
+		if _, ok := body["time"]; !ok {
+			body["time"] = now.Add(time.Duration(at) * time.Second)
+		}
+		resp, _ := request("POST", "/internal/nodes/servers/state", body, node.DaemonToken)
+		return resp
+	}
+	stored := func() models.Server {
+		var s models.Server
+		database.DB.Where("id = ?", srv.ID).First(&s)
+		return s
+	}
+	waitForStarts := func(n int32) {
+		deadline := time.Now().Add(5 * time.Second)
+		for atomic.LoadInt32(&starts) < n && time.Now().Before(deadline) {
+			time.Sleep(20 * time.Millisecond)
+		}
+		if got := atomic.LoadInt32(&starts); got != n {
+			t.Fatalf("Expected %d restarts, got %d", n, got)
+		}
+	}
+
+	t.Run("Rejects Other Nodes", func(t *testing.T) {
+		resp, _ := request("POST", "/internal/nodes/servers/state", map[string]interface{}{"server_id": srv.ID.String(), "state": "running", "time": now}, otherNode.DaemonToken)
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected 404 for a server on another node, got %d", resp.StatusCode)
+		}
+		if resp := report(map[string]interface{}{"state": "exploded"}); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for an unknown state, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("Start And Health", func(t *testing.T) {
+		if resp := report(map[string]interface{}{"state": "running"}); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		if s := stored(); s.Status != models.ServerStatusRunning {
+			t.Errorf("Expected running, got %s", s.Status)
+		}
+		report(map[string]interface{}{"state": "running", "health": "unhealthy"})
+		if s := stored(); s.Health != "unhealthy" {
+			t.Errorf("Expected health to be stored, got %q", s.Health)
+		}
+	})
+
+	t.Run("Ignores Stale Events", func(t *testing.T) {
+		report(map[string]interface{}{"state": "stopped", "time": now})
+		if s := stored(); s.Status != models.ServerStatusRunning {
+			t.Errorf("Expected an out of order event to be ignored, got %s", s.Status)
+		}
+	})
+
+	t.Run("Crash Restarts", func(t *testing.T) {
+		report(map[string]interface{}{"state": "crashed", "exit_code": 137, "oom": true, "logs": []string{"Loading world", "java.lang.OutOfMemoryError"}})
+		if len(crashes) != 1 || crashes[0].Action != models.CrashActionRestarted || !crashes[0].OOM || crashes[0].ExitCode != 137 {
+			t.Fatalf("Expected a restarted OOM crash, got %+v", crashes)
+		}
+		if s := stored(); s.Health != "" {
+			t.Errorf("Expected health to be cleared, got %q", s.Health)
+		}
+		waitForStarts(1)
+
+		report(map[string]interface{}{"state": "running"})
+		report(map[string]interface{}{"state": "crashed", "exit_code": 1})
+		waitForStarts(2)
+		if len(crashes) != 2 || crashes[1].Action != models.CrashActionRestarted {
+			t.Errorf("Expected second crash to restart, got %+v", crashes)
+		}
+	})
+
+	t.Run("Crash Loop Marks Failed", func(t *testing.T) {
+		report(map[string]interface{}{"state": "running"})
+		report(map[string]interface{}{"state": "crashed", "exit_code": 1})
+		if len(crashes) != 3 || crashes[2].Action != models.CrashActionFailed {
+			t.Fatalf("Expected third crash to fail the server, got %+v", crashes)
+		}
+		if s := stored(); s.Status != models.ServerStatusFailed {
+			t.Errorf("Expected failed, got %s", s.Status)
+		}
+		report(map[string]interface{}{"state": "stopped", "sync": true})
+		if s := stored(); s.Status != models.ServerStatusFailed {
+			t.Errorf("Expected failed status to survive a stopped report, got %s", s.Status)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if got := atomic.LoadInt32(&starts); got != 2 {
+			t.Errorf("Expected no restart after failing, got %d starts", got)
+		}
+	})
+
+	t.Run("Crash History", func(t *testing.T) {
+		resp, data := request("GET", fmt.Sprintf("/servers/%s/crashes", srv.ID), nil, "")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		history := data["data"].(map[string]interface{})
+		list := history["crashes"].([]interface{})
+		if len(list) != 3 || history["custom"] != false {
+			t.Fatalf("Expected 3 crashes with the package policy, got %v", history)
+		}
+		last := list[len(list)-1].(map[string]interface{})
+		if last["logs"] != "Loading world\njava.lang.OutOfMemoryError" || last["oom"] != true {
+			t.Errorf("Expected console lines on the first crash, got %v", last)
+		}
+	})
+
+	t.Run("Server Policy", func(t *testing.T) {
+		path := fmt.Sprintf("/servers/%s/crash-policy", srv.ID)
+		if resp, _ := request("PUT", path, map[string]interface{}{"policy": map[string]int{"max_restarts": 99, "window": 60}}, ""); resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for an invalid policy, got %d", resp.StatusCode)
+		}
+		if resp, _ := request("PUT", path, map[string]interface{}{"policy": map[string]int{"max_restarts": 0, "window": 60}}, ""); resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		s := stored()
+		if policy := services.ServerCrashPolicy(&s); policy.MaxRestarts != 0 || policy.Window != 60 {
+			t.Errorf("Expected server policy to override the package, got %+v", policy)
+		}
+
+		report(map[string]interface{}{"state": "running"})
+		report(map[string]interface{}{"state": "crashed", "exit_code": 2})
+		if crashes[len(crashes)-1].Action != models.CrashActionNone {
+			t.Errorf("Expected no restart with restarts disabled, got %s", crashes[len(crashes)-1].Action)
+		}
+
+		request("PUT", path, map[string]interface{}{"policy": nil}, "")
+		if s := stored(); len(s.CrashPolicy) != 0 && string(s.CrashPolicy) != "null" {
+			t.Errorf("Expected server policy to be cleared, got %s", s.CrashPolicy)
+		}
+	})
+
+	t.Run("Restarts After Node Reboot", func(t *testing.T) {
+		database.DB.Model(&models.Server{}).Where("id = ?", srv.ID).Update("status", models.ServerStatusRunning)
+		report(map[string]interface{}{"state": "stopped", "sync": true})
+		waitForStarts(3)
+	})
+
+	t.Run("Keeps Installing", func(t *testing.T) {
+		database.DB.Model(&models.Server{}).Where("id = ?", srv.ID).Update("status", models.ServerStatusInstalling)
+		report(map[string]interface{}{"state": "stopped"})
+		if s := stored(); s.Status != models.ServerStatusInstalling {
+			t.Errorf("Expected installing status to survive the old container stopping, got %s", s.Status)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers"
+	"birdactyl-panel-backend/internal/models"
+	"birdactyl-panel-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestSFTPActivity(t *testing.T) {
+	requireDB(t)
+
+	user := &models.User{ID: uuid.New(), Username: "test_sftp_activity", Email: "test_sftp_activity@test.com"}
+	database.DB.Create(user)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - SFTP Activity", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "sftp-activity"}
+	database.DB.Create(node)
+	other := &models.Node{ID: uuid.New(), Name: "Mock Node - SFTP Activity Other", FQDN: "127.0.0.1", Port: 2, TokenID: uuid.New().String(), DaemonToken: "sftp-activity-other"}
+	database.DB.Create(other)
+	pkg := &models.Package{ID: uuid.New(), Name: "SFTP Activity Package", DockerImage: "alpine", Startup: "true"}
+	database.DB.Create(pkg)
+	server := &models.Server{ID: uuid.New(), Name: "SFTP Activity", NodeID: node.ID, UserID: user.ID, PackageID: pkg.ID, Status: models.ServerStatusStopped}
+	database.DB.Create(server)
+
+	defer func() {
+		database.DB.Where("user_id = ?", user.ID).Delete(&models.ActivityLog{})
+		database.DB.Where("id = ?", server.ID).Delete(&models.Server{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Unscoped().Where("id IN ?", []uuid.UUID{node.ID, other.ID}).Delete(&models.Node{})
+		database.DB.Unscoped().Where("id = ?", user.ID).Delete(&models.User{})
+	}()
+
+	report := func(from *models.Node, events []services.SFTPEvent) int {
+		app := fiber.New(fiber.Config{DisableStartupMessage: true})
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("node", from)
+			return c.Next()
+		})
+		app.Post("/internal/nodes/sftp/activity", handlers.NodeSFTPActivity)
+
+		req := httptest.NewRequest("POST", "/internal/nodes/sftp/activity", toJSONBody(services.SFTPActivityReport{Events: events}))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		data := parseJSONResponse(resp)["data"].(map[string]interface{})
+		return int(data["recorded"].(float64))
+	}
+
+	at := time.Now().Add(-time.Hour).Truncate(time.Second)
+	event := func(action, path string) services.SFTPEvent {
+		return services.SFTPEvent{ServerID: server.ID.String(), UserID: user.ID.String(), Action: action, Path: path, IP: "203.0.113.7", Client: "SSH-2.0-OpenSSH_9.6", Time: at}
+	}
+
+	t.Run("Record", func(t *testing.T) {
+		write := event("write", "/server.properties")
+		write.Bytes = 1024
+		rename := event("rename", "/old")
+		rename.Target = "/new"
+		if n := report(node, []services.SFTPEvent{write, event("delete", "/world"), rename, event("create_folder", "/plugins")}); n != 4 {
+			t.Fatalf("Expected 4 recorded events, got %d", n)
+		}
+
+		var logs []models.ActivityLog
+		database.DB.Where("user_id = ?", user.ID).Order("action").Find(&logs)
+		if len(logs) != 4 {
+			t.Fatalf("Expected 4 activity logs, got %d", len(logs))
+		}
+		byAction := map[string]models.ActivityLog{}
+		for _, l := range logs {
+			byAction[l.Action] = l
+		}
+		del, ok := byAction[handlers.ActionSFTPDelete]
+		if !ok {
+			t.Fatalf("Expected a delete entry, got %v", logs)
+		}
+		if del.Username != user.Username || del.IP != "203.0.113.7" || !del.CreatedAt.Equal(at) {
+			t.Errorf("Unexpected delete entry: %+v", del)
+		}
+		if w := byAction[handlers.ActionSFTPWrite]; w.Description != "Wrote /server.properties (1024 bytes) over SFTP" {
+			t.Errorf("Unexpected write description %q", w.Description)
+		}
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		unknown := event("chmod", "/x")
+		stranger := event("delete", "/x")
+		stranger.UserID = uuid.New().String()
+		if n := report(node, []services.SFTPEvent{unknown, stranger}); n != 0 {
+			t.Errorf("Expected unknown actions and users to be dropped, got %d", n)
+		}
+		if n := report(other, []services.SFTPEvent{event("delete", "/x")}); n != 0 {
+			t.Errorf("Expected events from a node that does not host the server to be dropped, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"birdactyl-panel-backend/internal/database"
+	"birdactyl-panel-backend/internal/handlers/auth"
+	"birdactyl-panel-backend/internal/middleware"
+	"birdactyl-panel-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSFTPAuth(t *testing.T) {
+	requireDB(t)
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("sftp-password"), bcrypt.MinCost)
+	owner := &models.User{ID: uuid.New(), Username: "test_sftp_owner", Email: "test_sftp_owner@test.com", PasswordHash: string(hash)}
+	database.DB.Create(owner)
+	sub := &models.User{ID: uuid.New(), Username: "test_sftp_sub", Email: "test_sftp_sub@test.com", PasswordHash: string(hash)}
+	database.DB.Create(sub)
+	outsider := &models.User{ID: uuid.New(), Username: "test_sftp_outsider", Email: "test_sftp_outsider@test.com", PasswordHash: string(hash)}
+	database.DB.Create(outsider)
+	totp := &models.User{ID: uuid.New(), Username: "test_sftp_totp", Email: "test_sftp_totp@test.com", PasswordHash: string(hash), TOTPEnabled: true}
+	database.DB.Create(totp)
+	node := &models.Node{ID: uuid.New(), Name: "Mock Node - SFTP", FQDN: "127.0.0.1", Port: 1, TokenID: uuid.New().String(), DaemonToken: "sftp", IsOnline: true}
+	database.DB.Create(node)
+	other := &models.Node{ID: uuid.New(), Name: "Mock Node - SFTP Other", FQDN: "127.0.0.1", Port: 2, TokenID: uuid.New().String(), DaemonToken: "sftp-other", IsOnline: true}
+	database.DB.Create(other)
+	pkg := &models.Package{ID: uuid.New(), Name: "SFTP Package", DockerImage: "alpine", Startup: "true"}
+	database.DB.Create(pkg)
+	server := &models.Server{ID: uuid.New(), Name: "SFTP Server", NodeID: node.ID, UserID: owner.ID, PackageID: pkg.ID, Status: models.ServerStatusStopped}
+	database.DB.Create(server)
+	noSFTP := &models.User{ID: uuid.New(), Username: "test_sftp_nosftp", Email: "test_sftp_nosftp@test.com", PasswordHash: string(hash)}
+	database.DB.Create(noSFTP)
+	database.DB.Create(&models.Subuser{ServerID: server.ID, UserID: sub.ID, Permissions: []byte(`["sftp.view","file.list","file.read"]`)})
+	database.DB.Create(&models.Subuser{ServerID: server.ID, UserID: totp.ID, Permissions: []byte(`["sftp.view","file.list"]`)})
+	database.DB.Create(&models.Subuser{ServerID: server.ID, UserID: noSFTP.ID, Permissions: []byte(`["file.list","file.read"]`)})
+	apiKey := "birdactyl_sftptestkey0123456789"
+	database.DB.Create(&models.APIKey{UserID: totp.ID, Name: "SFTP", KeyHash: auth.HashAPIKey(apiKey), KeyPrefix: apiKey[:18]})
+
+	users := []uuid.UUID{owner.ID, sub.ID, outsider.ID, totp.ID, noSFTP.ID}
+	defer func() {
+		database.DB.Where("user_id IN ?", users).Delete(&models.APIKey{})
+		database.DB.Where("user_id IN ?", users).Delete(&models.SSHKey{})
+		database.DB.Where("server_id = ?", server.ID).Delete(&models.Subuser{})
+		database.DB.Where("id = ?", server.ID).Delete(&models.Server{})
+		database.DB.Where("id = ?", pkg.ID).Delete(&models.Package{})
+		database.DB.Unscoped().Where("id IN ?", []uuid.UUID{node.ID, other.ID}).Delete(&models.Node{})
+		database.DB.Unscoped().Where("id IN ?", users).Delete(&models.User{})
+	}()
+
+	var authenticateWith func(from *models.Node, body map[string]string) (int, map[string]interface{})
+	authenticate := func(from *models.Node, username, password string) (int, map[string]interface{}) {
+		return authenticateWith(from, map[string]string{"server_id": server.ID.String(), "username": username, "password": password})
+	}
+	authenticateWith = func(from *models.Node, body map[string]string) (int, map[string]interface{}) {
+		app := fiber.New(fiber.Config{DisableStartupMessage: true})
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("node", from)
+			return c.Next()
+		})
+		app.Post("/internal/sftp/auth", auth.ValidateSFTPAuth)
+
+		req := httptest.NewRequest("POST", "/internal/sftp/auth", toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp.StatusCode, parseJSONResponse(resp)
+	}
+	permissions := func(data map[string]interface{}) []interface{} {
+		payload, _ := data["data"].(map[string]interface{})
+		perms, _ := payload["permissions"].([]interface{})
+		return perms
+	}
+
+	t.Run("Owner", func(t *testing.T) {
+		status, data := authenticate(node, owner.Username, "sftp-password")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", status, data)
+		}
+		if perms := permissions(data); len(perms) != 1 || perms[0] != models.PermAdmin {
+			t.Errorf("Expected full access for the owner, got %v", perms)
+		}
+	})
+
+	t.Run("Subuser", func(t *testing.T) {
+		status, data := authenticate(node, sub.Username, "sftp-password")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", status, data)
+		}
+		if perms := permissions(data); len(perms) != 3 || perms[1] != models.PermFileList || perms[2] != models.PermFileRead {
+			t.Errorf("Expected the subuser permissions, got %v", perms)
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		if status, _ := authenticate(node, sub.Username, "wrong"); status != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 for a wrong password, got %d", status)
+		}
+		if status, _ := authenticate(node, totp.Username, "sftp-password"); status != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 for a password on a 2FA account, got %d", status)
+		}
+		if status, _ := authenticate(node, outsider.Username, "sftp-password"); status != fiber.StatusForbidden {
+			t.Errorf("Expected 403 for a user without access, got %d", status)
+		}
+		if status, _ := authenticate(other, owner.Username, "sftp-password"); status != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 from a node that does not host the server, got %d", status)
+		}
+		if status, _ := authenticate(node, noSFTP.Username, "sftp-password"); status != fiber.StatusForbidden {
+			t.Errorf("Expected 403 for a subuser without sftp.view, got %d", status)
+		}
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		app := fiber.New(fiber.Config{DisableStartupMessage: true})
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals("node", node)
+			return c.Next()
+		})
+		app.Post("/internal/sftp/auth", middleware.SFTPAuthLimit(), auth.ValidateSFTPAuth)
+		attempt := func(password, ip string) int {
+			req := httptest.NewRequest("POST", "/internal/sftp/auth", toJSONBody(map[string]string{"server_id": server.ID.String(), "username": owner.Username, "password": password, "remote_ip": ip}))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			return resp.StatusCode
+		}
+
+		for i := 0; i < 3; i++ {
+			if status := attempt("sftp-password", "203.0.113.7"); status != fiber.StatusOK {
+				t.Fatalf("Expected successful logins not to be throttled, got %d", status)
+			}
+		}
+		status := fiber.StatusUnauthorized
+		for i := 0; i < 25 && status == fiber.StatusUnauthorized; i++ {
+			status = attempt("wrong", fmt.Sprintf("203.0.113.%d", i%3))
+		}
+		if status != fiber.StatusTooManyRequests {
+			t.Fatalf("Expected repeated failures to be throttled, got %d", status)
+		}
+		if status := attempt("sftp-password", "198.51.100.1"); status != fiber.StatusTooManyRequests {
+			t.Errorf("Expected the username to stay throttled from another address, got %d", status)
+		}
+	})
+
+	t.Run("API Key", func(t *testing.T) {
+		status, data := authenticate(node, totp.Username, apiKey)
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", status, data)
+		}
+		if perms := permissions(data); len(perms) != 2 || perms[1] != models.PermFileList {
+			t.Errorf("Expected the subuser permissions, got %v", perms)
+		}
+		if status, _ := authenticate(node, sub.Username, apiKey); status != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 when the key belongs to another user, got %d", status)
+		}
+	})
+
+	t.Run("SSH Key", func(t *testing.T) {
+		pub, _, _ := ed25519.GenerateKey(rand.Reader)
+		sshPub, _ := ssh.NewPublicKey(pub)
+		fingerprint := ssh.FingerprintSHA256(sshPub)
+		database.DB.Create(&models.SSHKey{UserID: totp.ID, Name: "Laptop", Fingerprint: fingerprint, PublicKey: string(ssh.MarshalAuthorizedKey(sshPub))})
+
+		status, data := authenticateWith(node, map[string]string{"server_id": server.ID.String(), "username": totp.Username, "fingerprint": fingerprint})
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %v", status, data)
+		}
+		if perms := permissions(data); len(perms) != 2 || perms[1] != models.PermFileList {
+			t.Errorf("Expected the subuser permissions, got %v", perms)
+		}
+		if status, _ := authenticateWith(node, map[string]string{"server_id": server.ID.String(), "username": sub.Username, "fingerprint": fingerprint}); status != fiber.StatusUnauthorized {
+			t.Errorf("Expected 401 when the key belongs to another user, got %d", status)
+		}
+	})
+}
+
+func TestSSHKeys(t *testing.T) {
+	requireDB(t)
+
+	user := &models.User{ID: uuid.New(), Username: "test_ssh_keys", Email: "test_ssh_keys@test.com"}
+	database.DB.Create(user)
+	defer func() {
+		database.DB.Where("user_id = ?", user.ID).Delete(&models.SSHKey{})
+		database.DB.Unscoped().Where("id = ?", user.ID).Delete(&models.User{})
+	}()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", user)
+		return c.Next()
+	})
+	app.Get("/ssh-keys", auth.GetSSHKeys)
+	app.Post("/ssh-keys", auth.CreateSSHKey)
+	app.Delete("/ssh-keys/:id", auth.DeleteSSHKey)
+
+	request := func(method, path string, body interface{}) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, toJSONBody(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return resp.StatusCode, parseJSONResponse(resp)
+	}
+
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	sshPub, _ := ssh.NewPublicKey(pub)
+	authorized := string(ssh.MarshalAuthorizedKey(sshPub))
+	var keyID string
+
+	t.Run("Create", func(t *testing.T) {
+		status, data := request("POST", "/ssh-keys", map[string]string{"public_key": authorized[:len(authorized)-1] + " me@laptop"})
+		if status != fiber.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %v", status, data)
+		}
+		key := data["data"].(map[string]interface{})
+		if key["fingerprint"] != ssh.FingerprintSHA256(sshPub) {
+			t.Errorf("Expected fingerprint %s, got %v", ssh.FingerprintSHA256(sshPub), key["fingerprint"])
+		}
+		if key["name"] != "me@laptop" {
+			t.Errorf("Expected the key comment as name, got %v", key["name"])
+		}
+		keyID, _ = key["id"].(string)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		if status, _ := request("POST", "/ssh-keys", map[string]string{"public_key": "not a key"}); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for an invalid key, got %d", status)
+		}
+		if status, _ := request("POST", "/ssh-keys", map[string]string{"public_key": authorized, "name": strings.Repeat("k", 256)}); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for a name over 255 characters, got %d", status)
+		}
+		if status, _ := request("POST", "/ssh-keys", map[string]string{"public_key": authorized}); status != fiber.StatusConflict {
+			t.Errorf("Expected 409 for a duplicate key, got %d", status)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if status, _ := request("DELETE", "/ssh-keys/"+keyID, nil); status != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", status)
+		}
+		_, data := request("GET", "/ssh-keys", nil)
+		if keys, _ := data["data"].([]interface{}); len(keys) != 0 {
+			t.Errorf("Expected no keys after delete, got %v", keys)
+		}
+	})
+}